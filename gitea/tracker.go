@@ -0,0 +1,388 @@
+// Package gitea implements an issue tracker backed by the REST API of Gitea (or Forgejo).
+package gitea
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gov4git/gov4git/v2/tracker"
+	"github.com/gov4git/lib4git/base"
+	"github.com/gov4git/lib4git/git"
+	"github.com/gov4git/lib4git/must"
+)
+
+// Repo identifies a repo on a Gitea server.
+type Repo struct {
+	BaseURL string `json:"gitea_base_url"` // e.g. https://gitea.com
+	Owner   string `json:"gitea_repo_owner"`
+	Name    string `json:"gitea_repo_name"`
+}
+
+func (x Repo) HTTPS() string {
+	return strings.TrimRight(x.BaseURL, "/") + "/" + x.Owner + "/" + x.Name
+}
+
+// ParseRepo parses a Gitea "owner/repo" pair, hosted at baseURL.
+func ParseRepo(ctx context.Context, baseURL string, s string) Repo {
+	first, second, ok := strings.Cut(s, "/")
+	must.Assertf(ctx, ok, "not a gitea repo: %v", s)
+	return Repo{BaseURL: strings.TrimRight(baseURL, "/"), Owner: first, Name: second}
+}
+
+// Tracker implements tracker.Tracker for a Gitea repo.
+type Tracker struct {
+	Repo   Repo
+	Token  string       // access token; if empty, requests are not authenticated
+	Client *http.Client // if nil, http.DefaultClient is used
+
+	refRegexp      *regexp.Regexp
+	issueURLRegexp *regexp.Regexp
+}
+
+func NewTracker(repo Repo, token string, client *http.Client) *Tracker {
+	// silence CodeQL on missing anchors in the regex
+	// lgtm[go/regex/missing-regexp-anchor]
+	issueURL := regexp.QuoteMeta(repo.BaseURL) + `/([a-zA-Z0-9\.\-_]+)/([a-zA-Z0-9\.\-_]+)/(issues|pulls)/(\d+)`
+	return &Tracker{
+		Repo:           repo,
+		Token:          token,
+		Client:         client,
+		refRegexp:      regexp.MustCompile(`(?i)([a-zA-Z0-9\-:_]+)\s+` + issueURL),
+		issueURLRegexp: regexp.MustCompile(`(?i)^` + issueURL + `$`),
+	}
+}
+
+func (x *Tracker) String() string {
+	return x.Repo.HTTPS()
+}
+
+const pageLimit = 50
+
+type giteaUser struct {
+	Login string `json:"login"`
+	Email string `json:"email"`
+}
+
+type giteaLabel struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+type giteaIssue struct {
+	Number      int64        `json:"number"`
+	HTMLURL     string       `json:"html_url"`
+	User        *giteaUser   `json:"user"`
+	Title       string       `json:"title"`
+	Body        string       `json:"body"`
+	Labels      []giteaLabel `json:"labels"`
+	State       string       `json:"state"`
+	Comments    int          `json:"comments"`
+	IsLocked    bool         `json:"is_locked"`
+	PullRequest *struct{}    `json:"pull_request"`
+	CreatedAt   *time.Time   `json:"created_at"`
+	UpdatedAt   *time.Time   `json:"updated_at"`
+	ClosedAt    *time.Time   `json:"closed_at"`
+}
+
+type giteaTeam struct {
+	ID         int64  `json:"id"`
+	Name       string `json:"name"`
+	Permission string `json:"permission"`
+}
+
+type giteaComment struct {
+	User *giteaUser `json:"user"`
+	Body string     `json:"body"`
+}
+
+func (x *Tracker) ListIssues(ctx context.Context, state tracker.State, labels ...string) []tracker.Issue {
+
+	q := url.Values{}
+	q.Set("state", string(state))
+	if len(labels) > 0 {
+		q.Set("labels", strings.Join(labels, ","))
+	}
+	return x.listIssues(ctx, q)
+}
+
+func (x *Tracker) ListIssuesSince(ctx context.Context, since time.Time) []tracker.Issue {
+
+	q := url.Values{}
+	q.Set("state", string(tracker.StateAll))
+	q.Set("since", since.Format(time.RFC3339))
+	return x.listIssues(ctx, q)
+}
+
+func (x *Tracker) listIssues(ctx context.Context, q url.Values) []tracker.Issue {
+
+	var allIssues []tracker.Issue
+	for _, issue := range listPages[giteaIssue](ctx, x, x.repoPath("issues"), q) {
+		allIssues = append(allIssues, transformGiteaIssue(issue))
+	}
+	return allIssues
+}
+
+// listPages fetches all pages of a list from the Gitea API.
+func listPages[T any](ctx context.Context, x *Tracker, path string, q url.Values) []T {
+
+	if q == nil {
+		q = url.Values{}
+	}
+	q.Set("limit", strconv.Itoa(pageLimit))
+	var all []T
+	for page := 1; ; page++ {
+		q.Set("page", strconv.Itoa(page))
+		var items []T
+		x.call(ctx, http.MethodGet, path+"?"+q.Encode(), nil, &items)
+		all = append(all, items...)
+		if len(items) < pageLimit {
+			break
+		}
+	}
+	return all
+}
+
+func (x *Tracker) GetIssue(ctx context.Context, number int64) tracker.Issue {
+	var issue giteaIssue
+	if !x.callFound(ctx, http.MethodGet, x.repoPath("issues", number), nil, &issue) {
+		must.Panic(ctx, fmt.Errorf("issue %d: %w", number, tracker.ErrIssueNotFound))
+	}
+	return transformGiteaIssue(issue)
+}
+
+func transformGiteaIssue(issue giteaIssue) tracker.Issue {
+	var author, email string
+	if issue.User != nil {
+		author, email = strings.ToLower(issue.User.Login), issue.User.Email
+	}
+	var labels []string
+	for _, l := range issue.Labels {
+		labels = append(labels, l.Name)
+	}
+	slices.Sort(labels)
+	return tracker.Issue{
+		Number:      issue.Number,
+		URL:         issue.HTMLURL,
+		Author:      author,
+		AuthorEmail: email,
+		Title:       issue.Title,
+		Body:        issue.Body,
+		Labels:      labels,
+		Comments:    issue.Comments,
+		Locked:      issue.IsLocked,
+		Closed:      issue.State == "closed",
+		PullRequest: issue.PullRequest != nil,
+		CreatedAt:   issue.CreatedAt,
+		UpdatedAt:   issue.UpdatedAt,
+		ClosedAt:    issue.ClosedAt,
+	}
+}
+
+func (x *Tracker) IsMerged(ctx context.Context, number int64) bool {
+	var pr struct {
+		Merged bool `json:"merged"`
+	}
+	x.call(ctx, http.MethodGet, x.repoPath("pulls", number), nil, &pr)
+	return pr.Merged
+}
+
+func (x *Tracker) ListComments(ctx context.Context, number int64) []tracker.Comment {
+	comments := listPages[giteaComment](ctx, x, x.repoPath("issues", number, "comments"), nil)
+	r := make([]tracker.Comment, 0, len(comments))
+	for _, c := range comments {
+		var author string
+		if c.User != nil {
+			author = strings.ToLower(c.User.Login)
+		}
+		r = append(r, tracker.Comment{Author: author, Body: c.Body})
+	}
+	return r
+}
+
+// ListMaintainers returns the owner of the repo and the collaborators with admin or owner permission on the repo.
+// If the repo is owned by an organization, the members of the organization's owner teams take the place of the repo owner.
+func (x *Tracker) ListMaintainers(ctx context.Context) []string {
+
+	var repo struct {
+		Owner giteaUser `json:"owner"`
+	}
+	x.call(ctx, http.MethodGet, x.repoPath(), nil, &repo)
+	m := map[string]bool{}
+	if x.callFound(ctx, http.MethodGet, orgPath(repo.Owner.Login), nil, nil) {
+		for _, team := range listPages[giteaTeam](ctx, x, orgPath(repo.Owner.Login, "teams"), nil) {
+			if team.Permission != "owner" {
+				continue
+			}
+			for _, u := range listPages[giteaUser](ctx, x, teamPath(team.ID, "members"), nil) {
+				m[strings.ToLower(u.Login)] = true
+			}
+		}
+	} else {
+		m[strings.ToLower(repo.Owner.Login)] = true
+	}
+
+	for _, u := range listPages[giteaUser](ctx, x, x.repoPath("collaborators"), nil) {
+		var perm struct {
+			Permission string `json:"permission"`
+		}
+		x.call(ctx, http.MethodGet, x.repoPath("collaborators", u.Login, "permission"), nil, &perm)
+		if perm.Permission == "admin" || perm.Permission == "owner" {
+			m[strings.ToLower(u.Login)] = true
+		}
+	}
+
+	r := []string{}
+	for login := range m {
+		r = append(r, login)
+	}
+	slices.Sort(r)
+	return r
+}
+
+func (x *Tracker) PostComment(ctx context.Context, number int64, body string) {
+	x.call(ctx, http.MethodPost, x.repoPath("issues", number, "comments"), map[string]any{"body": body}, nil)
+}
+
+func (x *Tracker) CloseIssue(ctx context.Context, number int64) {
+	x.call(ctx, http.MethodPatch, x.repoPath("issues", number), map[string]any{"state": "closed"}, nil)
+}
+
+func (x *Tracker) UpsertIssue(ctx context.Context, label string, title string, body string) {
+
+	// check if there is an existing issue
+	issues := x.ListIssues(ctx, tracker.StateOpen, label)
+	if len(issues) > 0 {
+		x.call(ctx, http.MethodPatch, x.repoPath("issues", issues[0].Number), map[string]any{"title": title, "body": body}, nil)
+		return
+	}
+
+	// create an issue if there is none; gitea refers to labels by id
+	req := map[string]any{"title": title, "body": body, "labels": []int64{x.findOrCreateLabel(ctx, label)}}
+	x.call(ctx, http.MethodPost, x.repoPath("issues"), req, nil)
+}
+
+func (x *Tracker) findOrCreateLabel(ctx context.Context, name string) int64 {
+	for _, l := range listPages[giteaLabel](ctx, x, x.repoPath("labels"), nil) {
+		if l.Name == name {
+			return l.ID
+		}
+	}
+	var created giteaLabel
+	x.call(ctx, http.MethodPost, x.repoPath("labels"), map[string]any{"name": name, "color": "#ededed"}, &created)
+	return created.ID
+}
+
+// ParseRefs parses all references to issues or pull requests from the body of an issue.
+// Reference directives are of the form: "addresses|resolves|etc. https://gitea.com/owner/repo/issues/2"
+// References are extracted syntactically and are not guaranteed to correspond to real issues.
+func (x *Tracker) ParseRefs(body string) []tracker.Ref {
+
+	refs := []tracker.Ref{}
+	matches := x.refRegexp.FindAllStringSubmatch(body, -1)
+	for _, m := range matches {
+		n, err := strconv.Atoi(m[5])
+		if err != nil {
+			base.Infof("reference %q has unparsable issue number %q", m[0], m[5])
+			continue
+		}
+		refs = append(refs, tracker.Ref{To: int64(n), Type: strings.ToLower(m[1])})
+	}
+	return refs
+}
+
+func (x *Tracker) ParseIssueURL(url string) (int64, bool) {
+	m := x.issueURLRegexp.FindStringSubmatch(url)
+	if m == nil {
+		return 0, false
+	}
+	n, err := strconv.Atoi(m[4])
+	if err != nil {
+		return 0, false
+	}
+	return int64(n), true
+}
+
+// RawFileURL expects addr to be an HTTPS URL of a repo on the same Gitea server as the tracker.
+func (x *Tracker) RawFileURL(ctx context.Context, addr git.Address, path string) string {
+	repoPath, ok := strings.CutPrefix(string(addr.Repo), x.Repo.BaseURL+"/")
+	must.Assertf(ctx, ok, "repo %v is not hosted on %v", addr.Repo, x.Repo.BaseURL)
+	repoPath = strings.TrimSuffix(repoPath, ".git")
+	return fmt.Sprintf("%s/%s/raw/branch/%s/%s", x.Repo.BaseURL, repoPath, addr.Branch, path)
+}
+
+func (x *Tracker) repoPath(elems ...any) string {
+	return apiPath(append([]any{"repos", x.Repo.Owner, x.Repo.Name}, elems...)...)
+}
+
+func orgPath(org string, elems ...any) string {
+	return apiPath(append([]any{"orgs", org}, elems...)...)
+}
+
+func teamPath(team int64, elems ...any) string {
+	return apiPath(append([]any{"teams", team}, elems...)...)
+}
+
+func apiPath(elems ...any) string {
+	p := "/api/v1"
+	for _, e := range elems {
+		p += "/" + url.PathEscape(fmt.Sprint(e))
+	}
+	return p
+}
+
+// call performs a request against the Gitea API, and decodes the response into result, unless result is nil.
+func (x *Tracker) call(ctx context.Context, method string, path string, body any, result any) {
+	if !x.callFound(ctx, method, path, body, result) {
+		must.Errorf(ctx, "gitea %s %s returned %s", method, path, http.StatusText(http.StatusNotFound))
+	}
+}
+
+// callFound is like call, except that it returns false, instead of failing, if the resource is not found.
+func (x *Tracker) callFound(ctx context.Context, method string, path string, body any, result any) bool {
+
+	var r io.Reader
+	if body != nil {
+		buf, err := json.Marshal(body)
+		must.NoError(ctx, err)
+		r = bytes.NewReader(buf)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, x.Repo.BaseURL+path, r)
+	must.NoError(ctx, err)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if x.Token != "" {
+		req.Header.Set("Authorization", "token "+x.Token)
+	}
+
+	client := x.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	must.NoError(ctx, err)
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return false
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		must.Errorf(ctx, "gitea %s %s returned %s: %s", method, path, resp.Status, strings.TrimSpace(string(msg)))
+	}
+	if result != nil {
+		must.NoError(ctx, json.NewDecoder(resp.Body).Decode(result))
+	}
+	return true
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/google/go-github/v58/github"
 	"github.com/gov4git/gov4git/v2/gov4git/api"
+	"github.com/gov4git/gov4git/v2/proto"
 	"github.com/gov4git/gov4git/v2/proto/boot"
 	"github.com/gov4git/gov4git/v2/proto/gov"
 	"github.com/gov4git/gov4git/v2/proto/id"
@@ -132,7 +133,7 @@ func installGithubActions(
 	git.StringToFileStage(ctx, t, ns.NS{".github", "python", "requirements.txt"}, pythonRequirementsTXT)
 
 	git.Commit(ctx, t, "install gov4git github actions")
-	govCloned.Push(ctx)
+	proto.Push(ctx, govCloned)
 }
 
 func createGovernanceIssueLabels(
@@ -212,6 +213,7 @@ func createDeployEnvironment(
 
 const (
 	DefaultGithubFreq       = 120     // seconds
+	DefaultFullSyncFreq     = 60 * 60 // seconds
 	DefaultCommunityFreq    = 60 * 60 // seconds
 	DefaultFetchParallelism = 5
 )
@@ -13,6 +13,7 @@ import (
 	"github.com/gov4git/gov4git/v2/proto/member"
 	"github.com/gov4git/gov4git/v2/proto/motion/motionapi"
 	"github.com/gov4git/gov4git/v2/proto/motion/motionpolicies/pmp_0"
+	"github.com/gov4git/gov4git/v2/tracker"
 	"github.com/gov4git/lib4git/base"
 	"github.com/gov4git/lib4git/form"
 	"github.com/gov4git/lib4git/git"
@@ -70,9 +71,10 @@ func ProcessDirectiveIssuesByMaintainer(
 
 ) git.Change[form.Map, ProcessDirectiveIssueReports] {
 
-	maintainers := FetchRepoMaintainers(ctx, repo, ghc)
+	tr := NewTracker(ctx, repo, ghc)
+	maintainers := tr.ListMaintainers(ctx)
 	base.Infof("maintainers for %v are %v", repo, form.SprintJSON(maintainers))
-	return ProcessDirectiveIssues(ctx, repo, ghc, govAddr, maintainers)
+	return ProcessDirectiveIssues(ctx, repo, tr.Client, govAddr, maintainers)
 }
 
 func ProcessDirectiveIssues(
@@ -84,40 +86,74 @@ func ProcessDirectiveIssues(
 
 ) git.Change[form.Map, ProcessDirectiveIssueReports] {
 
-	cloned := gov.CloneOwner(ctx, govAddr)
-	report := ProcessDirectiveIssues_StageOnly(ctx, repo, ghc, govAddr, cloned, maintainers)
-	chg := git.NewChange[form.Map, ProcessDirectiveIssueReports](
-		fmt.Sprintf("Process %d organizer directives", len(report)),
-		"github_directive_issues",
-		form.Map{},
-		report,
-		nil,
-	)
-	status, err := cloned.Public.Tree().Status()
-	must.NoError(ctx, err)
-	if !status.IsClean() {
-		proto.Commit(ctx, cloned.Public.Tree(), chg)
-		cloned.Public.Push(ctx)
-	}
-	return chg
+	return RetryWithTracker(ctx, NewTracker(ctx, repo, ghc), func(tr tracker.Tracker) git.Change[form.Map, ProcessDirectiveIssueReports] {
+		cloned := gov.CloneOwner(ctx, govAddr)
+		report := ProcessDirectiveIssues_StageOnly(ctx, tr, govAddr, cloned, maintainers)
+		chg := git.NewChange[form.Map, ProcessDirectiveIssueReports](
+			fmt.Sprintf("Process %d organizer directives", len(report)),
+			"github_directive_issues",
+			form.Map{},
+			report,
+			nil,
+		)
+		status, err := cloned.Public.Tree().Status()
+		must.NoError(ctx, err)
+		if !status.IsClean() {
+			proto.Commit(ctx, cloned.Public.Tree(), chg)
+			proto.Push(ctx, cloned.Public)
+		}
+		return chg
+	})
 }
 
 func ProcessDirectiveIssues_StageOnly(
 	ctx context.Context,
-	repo Repo,
-	ghc *github.Client, // if nil, a new client for repo will be created
+	tr tracker.Tracker,
 	govAddr gov.OwnerAddress,
 	govCloned gov.OwnerCloned,
 	maintainers []string,
 
 ) ProcessDirectiveIssueReports { // return list of processed directives
 
-	report := ProcessDirectiveIssueReports{}
-
 	// fetch open issues labelled gov4git:directive
-	issues := fetchOpenIssues(ctx, repo, ghc, DirectiveLabel)
+	issues := tr.ListIssues(ctx, tracker.StateOpen, DirectiveLabel)
+	return processDirectiveIssues_StageOnly(ctx, tr, govAddr, govCloned, maintainers, issues)
+}
+
+// ProcessDirectiveIssue_StageOnly processes a single issue, if it is an open directive.
+func ProcessDirectiveIssue_StageOnly(
+	ctx context.Context,
+	tr tracker.Tracker,
+	govAddr gov.OwnerAddress,
+	govCloned gov.OwnerCloned,
+	maintainers []string,
+	issue tracker.Issue,
+
+) ProcessDirectiveIssueReports {
+
+	if issue.Closed || !isDirectiveIssue(issue) {
+		return ProcessDirectiveIssueReports{}
+	}
+	return processDirectiveIssues_StageOnly(ctx, tr, govAddr, govCloned, maintainers, []tracker.Issue{issue})
+}
+
+func isDirectiveIssue(issue tracker.Issue) bool {
+	return util.IsIn(DirectiveLabel, issue.Labels...)
+}
+
+func processDirectiveIssues_StageOnly(
+	ctx context.Context,
+	tr tracker.Tracker,
+	govAddr gov.OwnerAddress,
+	govCloned gov.OwnerCloned,
+	maintainers []string,
+	issues []tracker.Issue,
+
+) ProcessDirectiveIssueReports {
+
+	report := ProcessDirectiveIssueReports{}
 	for _, issue := range issues {
-		directive, err := processDirectiveIssue_StageOnly(ctx, repo, ghc, govAddr, govCloned, maintainers, issue)
+		directive, err := processDirectiveIssue_StageOnly(ctx, tr, govAddr, govCloned, maintainers, issue)
 		if err != nil {
 			report = append(report, ProcessDirectiveIssueReport{
 				Directive: directive,
@@ -135,40 +171,33 @@ func ProcessDirectiveIssues_StageOnly(
 
 func processDirectiveIssue_StageOnly(
 	ctx context.Context,
-	repo Repo,
-	ghc *github.Client,
+	tr tracker.Tracker,
 	govAddr gov.OwnerAddress,
 	cloned gov.OwnerCloned,
 	maintainers []string,
-	issue *github.Issue,
+	issue tracker.Issue,
 
 ) (DirectiveIssue, error) {
 
 	must.Assertf(ctx, len(maintainers) > 0, "no maintainers found")
 
-	u := issue.GetUser()
-	if u == nil {
-		base.Infof("github identity of issue author is not available: %v", form.SprintJSON(issue))
-		replyAndCloseIssue(ctx, repo, ghc, issue, FollowUpSubject, "The GitHub identity of the issue's author is not available.")
-		return DirectiveIssue{}, fmt.Errorf("identity of issue author is not available")
-	}
-	login := strings.ToLower(u.GetLogin())
+	login := issue.Author
 	if login == "" {
-		base.Infof("github user of issue author is not available: %v", form.SprintJSON(issue))
-		replyAndCloseIssue(ctx, repo, ghc, issue, FollowUpSubject, "The GitHub login of the issue's author is not available.")
-		return DirectiveIssue{}, fmt.Errorf("user of issue author is not available")
+		base.Infof("identity of issue author is not available: %v", form.SprintJSON(issue))
+		replyAndCloseIssue(ctx, tr, issue, FollowUpSubject, "The identity of the issue's author is not available.")
+		return DirectiveIssue{}, fmt.Errorf("identity of issue author is not available")
 	}
 	if !util.IsIn[string](login, maintainers...) {
 		base.Infof("directive author %q is not a maintainer", login)
-		replyAndCloseIssue(ctx, repo, ghc, issue, FollowUpSubject,
+		replyAndCloseIssue(ctx, tr, issue, FollowUpSubject,
 			fmt.Sprintf("directive author @%s is not a maintainer", login))
 		return DirectiveIssue{}, fmt.Errorf("directive author is not a maintainer")
 	}
 
-	d, err := parseDirective(login, issue.GetBody())
+	d, err := parseDirective(tr, login, issue.Body)
 	if err != nil {
-		base.Infof("directive cannot be parsed (%v): %q", err, issue.GetBody())
-		replyAndCloseIssue(ctx, repo, ghc, issue, FollowUpSubject, "Your directive cannot be parsed.")
+		base.Infof("directive cannot be parsed (%v): %q", err, issue.Body)
+		replyAndCloseIssue(ctx, tr, issue, FollowUpSubject, "Your directive cannot be parsed.")
 		return DirectiveIssue{}, err
 	}
 
@@ -182,7 +211,7 @@ func processDirectiveIssue_StageOnly(
 					cloned.PublicClone(),
 					member.UserAccountID(member.User(d.IssueVotingCredits.To)),
 					account.H(account.PluralAsset, d.IssueVotingCredits.Amount),
-					fmt.Sprintf("directive from GitHub issue #%v", issue.GetNumber()),
+					fmt.Sprintf("directive from issue #%v", issue.Number),
 				)
 			},
 		)
@@ -190,13 +219,13 @@ func processDirectiveIssue_StageOnly(
 			base.Infof("could not issue %v credits to member %v (%v)",
 				d.IssueVotingCredits.Amount, d.IssueVotingCredits.To, err)
 			replyAndCloseIssue(
-				ctx, repo, ghc, issue,
+				ctx, tr, issue,
 				FollowUpSubject,
 				fmt.Sprintf("Could not issue `%v` credits to member @%v. Reopen the issue to retry.\n\nBecause: `%v`",
 					d.IssueVotingCredits.Amount, d.IssueVotingCredits.To, err))
 			return DirectiveIssue{}, err
 		}
-		replyAndCloseIssue(ctx, repo, ghc, issue,
+		replyAndCloseIssue(ctx, tr, issue,
 			FollowUpSubject,
 			fmt.Sprintf("Issued `%v` credits to member @%v.",
 				d.IssueVotingCredits.Amount, d.IssueVotingCredits.To))
@@ -211,7 +240,7 @@ func processDirectiveIssue_StageOnly(
 					member.UserAccountID(member.User(d.TransferVotingCredits.From)),
 					member.UserAccountID(member.User(d.TransferVotingCredits.To)),
 					account.H(account.PluralAsset, d.TransferVotingCredits.Amount),
-					fmt.Sprintf("directive from GitHub issue #%v", issue.GetNumber()),
+					fmt.Sprintf("directive from issue #%v", issue.Number),
 				)
 			},
 		)
@@ -219,13 +248,13 @@ func processDirectiveIssue_StageOnly(
 			base.Infof("could not transfer %v credits from member %v to member %v (%v)",
 				d.TransferVotingCredits.Amount, d.TransferVotingCredits.From, d.TransferVotingCredits.To, err)
 			replyAndCloseIssue(
-				ctx, repo, ghc, issue,
+				ctx, tr, issue,
 				FollowUpSubject,
 				fmt.Sprintf("Could not transfer `%v` credits from member @%v to member @%v. Reopen the issue to retry.\n\nBecause: `%v`",
 					d.TransferVotingCredits.Amount, d.TransferVotingCredits.From, d.TransferVotingCredits.To, err))
 			return DirectiveIssue{}, err
 		}
-		replyAndCloseIssue(ctx, repo, ghc, issue,
+		replyAndCloseIssue(ctx, tr, issue,
 			FollowUpSubject,
 			fmt.Sprintf("Transferred `%v` credits from member @%v to member @%v.",
 				d.TransferVotingCredits.Amount, d.TransferVotingCredits.From, d.TransferVotingCredits.To))
@@ -241,12 +270,12 @@ func processDirectiveIssue_StageOnly(
 		if err != nil {
 			base.Infof("could not freeze issue/PR %v (%v)", d.Freeze.IssueURL, err)
 			replyAndCloseIssue(
-				ctx, repo, ghc, issue, FollowUpSubject,
+				ctx, tr, issue, FollowUpSubject,
 				fmt.Sprintf("Could not freeze issue/PR %v. Reopen this issue to retry.\n\nBecause: `%v`", d.Freeze.IssueURL, err))
 			return DirectiveIssue{}, err
 		}
 		replyAndCloseIssue(
-			ctx, repo, ghc, issue, FollowUpSubject,
+			ctx, tr, issue, FollowUpSubject,
 			fmt.Sprintf("Froze issue/PR %v.", d.Freeze.IssueURL),
 		)
 		return d, nil
@@ -261,12 +290,12 @@ func processDirectiveIssue_StageOnly(
 		if err != nil {
 			base.Infof("could not unfreeze issue/PR %v (%v)", d.Unfreeze.IssueURL, err)
 			replyAndCloseIssue(
-				ctx, repo, ghc, issue, FollowUpSubject,
+				ctx, tr, issue, FollowUpSubject,
 				fmt.Sprintf("Could not unfreeze issue/PR %v. Reopen this issue to retry.\n\nBecause: `%v`", d.Unfreeze.IssueURL, err))
 			return DirectiveIssue{}, err
 		}
 		replyAndCloseIssue(
-			ctx, repo, ghc, issue, FollowUpSubject,
+			ctx, tr, issue, FollowUpSubject,
 			fmt.Sprintf("Unfroze issue/PR %v.", d.Unfreeze.IssueURL),
 		)
 		return d, nil
@@ -280,7 +309,7 @@ func processDirectiveIssue_StageOnly(
 					member.UserAccountID(member.User(d.GiveToMatchingFund.From)),
 					pmp_0.MatchingPoolAccountID,
 					account.H(account.PluralAsset, d.GiveToMatchingFund.Amount),
-					fmt.Sprintf("directive from GitHub issue #%v", issue.GetNumber()),
+					fmt.Sprintf("directive from issue #%v", issue.Number),
 				)
 			},
 		)
@@ -288,13 +317,13 @@ func processDirectiveIssue_StageOnly(
 			base.Infof("could not transfer %v credits from member %v to member %v (%v)",
 				d.GiveToMatchingFund.Amount, d.GiveToMatchingFund.From, pmp_0.MatchingPoolAccountID, err)
 			replyAndCloseIssue(
-				ctx, repo, ghc, issue,
+				ctx, tr, issue,
 				FollowUpSubject,
 				fmt.Sprintf("Could not transfer `%v` credits from member @%v to member @%v. Reopen the issue to retry.\n\nBecause: `%v`",
 					d.GiveToMatchingFund.Amount, d.GiveToMatchingFund.From, pmp_0.MatchingPoolAccountID, err))
 			return DirectiveIssue{}, err
 		}
-		replyAndCloseIssue(ctx, repo, ghc, issue,
+		replyAndCloseIssue(ctx, tr, issue,
 			FollowUpSubject,
 			fmt.Sprintf("Transferred `%v` credits from member @%v to the matching fund.",
 				d.GiveToMatchingFund.Amount, d.GiveToMatchingFund.From))
@@ -309,7 +338,7 @@ func processDirectiveIssue_StageOnly(
 //	"issue 30 credits to @user"
 //	"transfer 20 credits from @user1 to @user2"
 //	"give 10 credits to matching fund"
-func parseDirective(author, body string) (DirectiveIssue, error) {
+func parseDirective(tr tracker.Tracker, author, body string) (DirectiveIssue, error) {
 	body = strings.ToLower(body)
 	body = strings.ReplaceAll(body, "\n", " ")
 	body = strings.ReplaceAll(body, "\r", " ")
@@ -330,11 +359,11 @@ func parseDirective(author, body string) (DirectiveIssue, error) {
 		return d, nil
 	}
 
-	if d, err := parseFreezeIssueDirective(words); err == nil {
+	if d, err := parseFreezeIssueDirective(tr, words); err == nil {
 		return d, nil
 	}
 
-	if d, err := parseUnfreezeIssueDirective(words); err == nil {
+	if d, err := parseUnfreezeIssueDirective(tr, words); err == nil {
 		return d, nil
 	}
 	if d, err := parseGiveToMatchingFundDirective(author, words); err == nil {
@@ -412,47 +441,39 @@ func parseGiveToMatchingFundDirective(author string, words []string) (DirectiveI
 }
 
 // "freeze issueURL"
-func parseFreezeIssueDirective(words []string) (DirectiveIssue, error) {
+func parseFreezeIssueDirective(tr tracker.Tracker, words []string) (DirectiveIssue, error) {
 	if len(words) < 2 || words[0] != "freeze" {
 		return DirectiveIssue{}, fmt.Errorf("cannot parse freeze issue directive")
 	}
 
-	matches := refRegexp.FindStringSubmatch(words[1])
-	if matches == nil {
+	n, ok := tr.ParseIssueURL(words[1])
+	if !ok {
 		return DirectiveIssue{}, fmt.Errorf("cannot parse freeze issue directive (expecting an issue URL)")
 	}
-	n, err := strconv.Atoi(matches[5])
-	if err != nil {
-		return DirectiveIssue{}, fmt.Errorf("cannot parse freeze issue directive (issue number not parsable)")
-	}
 
 	return DirectiveIssue{
 		Freeze: &FreezeDirective{
-			IssueURL:    matches[0],
-			IssueNumber: int64(n),
+			IssueURL:    words[1],
+			IssueNumber: n,
 		},
 	}, nil
 }
 
 // "unfreeze issueURL"
-func parseUnfreezeIssueDirective(words []string) (DirectiveIssue, error) {
+func parseUnfreezeIssueDirective(tr tracker.Tracker, words []string) (DirectiveIssue, error) {
 	if len(words) < 2 || words[0] != "unfreeze" {
 		return DirectiveIssue{}, fmt.Errorf("cannot parse unfreeze issue directive")
 	}
 
-	matches := refRegexp.FindStringSubmatch(words[1])
-	if matches == nil {
+	n, ok := tr.ParseIssueURL(words[1])
+	if !ok {
 		return DirectiveIssue{}, fmt.Errorf("cannot parse unfreeze issue directive (expecting an issue URL)")
 	}
-	n, err := strconv.Atoi(matches[5])
-	if err != nil {
-		return DirectiveIssue{}, fmt.Errorf("cannot parse unfreeze issue directive (issue number not parsable)")
-	}
 
 	return DirectiveIssue{
 		Unfreeze: &UnfreezeDirective{
-			IssueURL:    matches[0],
-			IssueNumber: int64(n),
+			IssueURL:    words[1],
+			IssueNumber: n,
 		},
 	}, nil
 }
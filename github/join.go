@@ -10,6 +10,7 @@ import (
 	"github.com/gov4git/gov4git/v2/proto/gov"
 	"github.com/gov4git/gov4git/v2/proto/id"
 	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/gov4git/v2/tracker"
 	"github.com/gov4git/lib4git/base"
 	"github.com/gov4git/lib4git/form"
 	"github.com/gov4git/lib4git/git"
@@ -24,9 +25,10 @@ func ProcessJoinRequestIssuesApprovedByMaintainer(
 	allowNonGithubJoins bool,
 ) git.Change[form.Map, ProcessJoinRequestIssuesReport] {
 
-	maintainers := FetchRepoMaintainers(ctx, repo, ghc)
+	tr := NewTracker(ctx, repo, ghc)
+	maintainers := tr.ListMaintainers(ctx)
 	base.Infof("maintainers for %v are %v", repo, form.SprintJSON(maintainers))
-	return ProcessJoinRequestIssues(ctx, repo, ghc, govAddr, maintainers, allowNonGithubJoins)
+	return ProcessJoinRequestIssues(ctx, repo, tr.Client, govAddr, maintainers, allowNonGithubJoins)
 }
 
 func ProcessJoinRequestIssues(
@@ -38,30 +40,31 @@ func ProcessJoinRequestIssues(
 	allowNonGithubJoins bool,
 ) git.Change[form.Map, ProcessJoinRequestIssuesReport] {
 
-	govCloned := gov.CloneOwner(ctx, govAddr)
-	report := ProcessJoinRequestIssues_StageOnly(
-		ctx,
-		repo,
-		ghc,
-		govAddr,
-		govCloned,
-		approverGitHubUsers,
-		allowNonGithubJoins,
-	)
-	chg := git.NewChange[form.Map, ProcessJoinRequestIssuesReport](
-		fmt.Sprintf("Add %d new community members; skipped %d", len(report.Joined), len(report.NotJoined)),
-		"github_process_join_request_issues",
-		form.Map{},
-		report,
-		nil,
-	)
-	status, err := govCloned.Public.Tree().Status()
-	must.NoError(ctx, err)
-	if !status.IsClean() {
-		proto.Commit(ctx, govCloned.Public.Tree(), chg)
-		govCloned.Public.Push(ctx)
-	}
-	return chg
+	return RetryWithTracker(ctx, NewTracker(ctx, repo, ghc), func(tr tracker.Tracker) git.Change[form.Map, ProcessJoinRequestIssuesReport] {
+		govCloned := gov.CloneOwner(ctx, govAddr)
+		report := ProcessJoinRequestIssues_StageOnly(
+			ctx,
+			tr,
+			govAddr,
+			govCloned,
+			approverGitHubUsers,
+			allowNonGithubJoins,
+		)
+		chg := git.NewChange[form.Map, ProcessJoinRequestIssuesReport](
+			fmt.Sprintf("Add %d new community members; skipped %d", len(report.Joined), len(report.NotJoined)),
+			"github_process_join_request_issues",
+			form.Map{},
+			report,
+			nil,
+		)
+		status, err := govCloned.Public.Tree().Status()
+		must.NoError(ctx, err)
+		if !status.IsClean() {
+			proto.Commit(ctx, govCloned.Public.Tree(), chg)
+			proto.Push(ctx, govCloned.Public)
+		}
+		return chg
+	})
 }
 
 type ProcessJoinRequestIssuesReport struct {
@@ -71,48 +74,75 @@ type ProcessJoinRequestIssuesReport struct {
 
 func ProcessJoinRequestIssues_StageOnly(
 	ctx context.Context,
-	repo Repo,
-	ghc *github.Client, // if nil, a new client for repo will be created
+	tr tracker.Tracker,
 	govAddr gov.OwnerAddress,
 	govCloned gov.OwnerCloned,
 	approvers []string,
 	allowNonGithubJoins bool,
 ) ProcessJoinRequestIssuesReport { // return list of new member usernames
 
-	report := ProcessJoinRequestIssuesReport{}
-
 	// fetch open issues
-	issues := fetchOpenIssues(ctx, repo, ghc)
+	issues := tr.ListIssues(ctx, tracker.StateOpen)
+	return processJoinRequestIssues_StageOnly(ctx, tr, govAddr, govCloned, approvers, allowNonGithubJoins, issues)
+}
+
+// ProcessJoinRequestIssue_StageOnly processes a single issue, if it is an open join request.
+func ProcessJoinRequestIssue_StageOnly(
+	ctx context.Context,
+	tr tracker.Tracker,
+	govAddr gov.OwnerAddress,
+	govCloned gov.OwnerCloned,
+	approvers []string,
+	allowNonGithubJoins bool,
+	issue tracker.Issue,
+) ProcessJoinRequestIssuesReport {
+
+	if issue.Closed {
+		return ProcessJoinRequestIssuesReport{}
+	}
+	return processJoinRequestIssues_StageOnly(ctx, tr, govAddr, govCloned, approvers, allowNonGithubJoins, []tracker.Issue{issue})
+}
+
+func processJoinRequestIssues_StageOnly(
+	ctx context.Context,
+	tr tracker.Tracker,
+	govAddr gov.OwnerAddress,
+	govCloned gov.OwnerCloned,
+	approvers []string,
+	allowNonGithubJoins bool,
+	issues []tracker.Issue,
+) ProcessJoinRequestIssuesReport {
+
+	report := ProcessJoinRequestIssuesReport{}
 	for _, issue := range issues {
 		if !isJoinRequestIssue(issue) {
 			continue
 		}
-		newMember := processJoinRequestIssue_StageOnly(ctx, repo, ghc, govAddr, govCloned, approvers, allowNonGithubJoins, issue)
+		newMember := processJoinRequestIssue_StageOnly(ctx, tr, govAddr, govCloned, approvers, allowNonGithubJoins, issue)
 		if newMember != "" {
 			report.Joined = append(report.Joined, newMember)
 		} else {
-			if issue.User != nil {
-				report.NotJoined = append(report.NotJoined, strings.ToLower(issue.User.GetLogin()))
+			if issue.Author != "" {
+				report.NotJoined = append(report.NotJoined, issue.Author)
 			}
 		}
 	}
 	return report
 }
 
-func isJoinRequestIssue(issue *github.Issue) bool {
-	_, _, err := parseJoinBody(issue.GetBody())
+func isJoinRequestIssue(issue tracker.Issue) bool {
+	_, _, err := parseJoinBody(issue.Body)
 	return err == nil
 }
 
 func processJoinRequestIssue_StageOnly(
 	ctx context.Context,
-	repo Repo,
-	ghc *github.Client, // if nil, a new client for repo will be created
+	tr tracker.Tracker,
 	govAddr gov.OwnerAddress,
 	govCloned gov.OwnerCloned,
 	approverGitHubUsers []string,
 	allowNonGithubJoins bool,
-	issue *github.Issue,
+	issue tracker.Issue,
 ) string { // return new member username, if joined
 
 	must.Assertf(ctx, len(approverGitHubUsers) > 0, "no membership approvers")
@@ -121,36 +151,30 @@ func processJoinRequestIssue_StageOnly(
 		return ""
 	}
 
-	// find the github login of the requesting user
-	u := issue.GetUser()
-	if u == nil {
-		base.Infof("github identity of issue author is not available: %v", form.SprintJSON(issue))
-		replyAndCloseIssue(ctx, repo, ghc, issue, FollowUpSubject, "The GitHub identity of the issue's author is not available.")
-		return ""
-	}
-	login := strings.ToLower(u.GetLogin())
+	// find the login of the requesting user
+	login := issue.Author
 	if login == "" {
-		base.Infof("github user of issue author is not available: %v", form.SprintJSON(issue))
-		replyAndCloseIssue(ctx, repo, ghc, issue, FollowUpSubject, "The GitHub user of the issue's author is not available.")
+		base.Infof("identity of issue author is not available: %v", form.SprintJSON(issue))
+		replyAndCloseIssue(ctx, tr, issue, FollowUpSubject, "The identity of the issue's author is not available.")
 		return ""
 	}
 
 	// extract the join request from the github issue body
-	info, err := parseJoinRequest(login, issue.GetBody())
+	info, err := parseJoinRequest(login, issue.Body)
 	if err != nil {
-		base.Infof("request form cannot be parsed: %q", issue.GetBody())
-		replyAndCloseIssue(ctx, repo, ghc, issue, FollowUpSubject, "The join request form cannot be parsed.")
+		base.Infof("request form cannot be parsed: %q", issue.Body)
+		replyAndCloseIssue(ctx, tr, issue, FollowUpSubject, "The join request form cannot be parsed.")
 		return ""
 	}
 	if info.Email == "" {
-		info.Email = u.GetEmail()
+		info.Email = issue.AuthorEmail
 	}
 
 	// verify that the gov4git repo url matches the login of the requesting user
 	if !allowNonGithubJoins && info.PublicRepo.Owner != info.User {
 		base.Infof("reguster's GitHub login %s does not match the public repo owner %s", info.User, info.PublicRepo.Owner)
 		replyAndCloseIssue(
-			ctx, repo, ghc, issue, FollowUpSubject,
+			ctx, tr, issue, FollowUpSubject,
 			fmt.Sprintf(
 				"The regusting user, @%s, does not match the owner, @%s, of the provided Gov4Git public identity repo.",
 				info.User, info.PublicRepo.Owner,
@@ -160,7 +184,7 @@ func processJoinRequestIssue_StageOnly(
 	}
 
 	// fetch comments and find a join approval
-	comments := fetchIssueComments(ctx, repo, ghc, issue)
+	comments := fetchIssueComments(ctx, tr, issue)
 	if !isJoinApprovalPresent(ctx, approverGitHubUsers, comments) {
 		return ""
 	}
@@ -173,11 +197,11 @@ func processJoinRequestIssue_StageOnly(
 	)
 	if err != nil {
 		base.Infof("could not add member %v (%v)", login, err)
-		replyAndCloseIssue(ctx, repo, ghc, issue, FollowUpSubject, fmt.Sprintf("Could not add member due to `%v`. Reopen the issue to retry.", err))
+		replyAndCloseIssue(ctx, tr, issue, FollowUpSubject, fmt.Sprintf("Could not add member due to `%v`. Reopen the issue to retry.", err))
 		return ""
 	}
 
-	replyAndCloseIssue(ctx, repo, ghc, issue, FollowUpSubject, fmt.Sprintf("@%v was added to the community.", login))
+	replyAndCloseIssue(ctx, tr, issue, FollowUpSubject, fmt.Sprintf("@%v was added to the community.", login))
 	return login
 }
 
@@ -2,10 +2,7 @@ package github
 
 import (
 	"context"
-	"regexp"
 	"slices"
-	"strconv"
-	"strings"
 	"time"
 
 	"github.com/google/go-github/v58/github"
@@ -13,28 +10,39 @@ import (
 	"github.com/gov4git/gov4git/v2/proto/motion/motionpolicies/pmp_0"
 	"github.com/gov4git/gov4git/v2/proto/motion/motionpolicies/pmp_1"
 	"github.com/gov4git/gov4git/v2/proto/motion/motionpolicies/waimea"
-	"github.com/gov4git/lib4git/base"
-	"github.com/gov4git/lib4git/must"
+	"github.com/gov4git/gov4git/v2/tracker"
 	"github.com/gov4git/lib4git/util"
 )
 
 func LoadIssues(
 	ctx context.Context,
-	ghc *github.Client, // if nil, a new client for repo will be created
-	repo Repo,
+	tr tracker.Tracker,
 	loadPR LoadPRFunc,
 
 ) (ImportedIssues, map[string]ImportedIssue) {
 
-	if ghc == nil {
-		ghc = GetGithubClient(ctx, repo)
-	}
+	return LoadIssuesSince(ctx, tr, time.Time{}, loadPR)
+}
+
+// LoadIssuesSince loads the issues updated at or after since. If since is zero, all issues are loaded.
+func LoadIssuesSince(
+	ctx context.Context,
+	tr tracker.Tracker,
+	since time.Time,
+	loadPR LoadPRFunc,
+
+) (ImportedIssues, map[string]ImportedIssue) {
 
-	issues := FetchIssues(ctx, repo, ghc)
+	var issues []tracker.Issue
+	if since.IsZero() {
+		issues = tr.ListIssues(ctx, tracker.StateAll)
+	} else {
+		issues = tr.ListIssuesSince(ctx, since)
+	}
 	key := map[string]ImportedIssue{}
 	order := ImportedIssues{}
 	for _, issue := range issues {
-		ghIssue := TransformIssue(ctx, ghc, repo, issue, loadPR)
+		ghIssue := TransformIssue(ctx, tr, issue, loadPR)
 		key[ghIssue.Key()] = ghIssue
 		order = append(order, ghIssue)
 	}
@@ -42,23 +50,6 @@ func LoadIssues(
 	return order, key
 }
 
-func FetchIssues(ctx context.Context, repo Repo, ghc *github.Client) []*github.Issue {
-
-	opt := &github.IssueListByRepoOptions{State: "all"}
-	var allIssues []*github.Issue
-	for {
-		issues, resp, err := ghc.Issues.ListByRepo(ctx, repo.Owner, repo.Name, opt)
-		must.NoError(ctx, err)
-		allIssues = append(allIssues, issues...)
-		if resp.NextPage == 0 {
-			break
-		}
-		opt.Page = resp.NextPage
-	}
-
-	return allIssues
-}
-
 func LabelsToStrings(labels []*github.Label) []string {
 	var labelStrings []string
 	for _, label := range labels {
@@ -68,29 +59,29 @@ func LabelsToStrings(labels []*github.Label) []string {
 	return labelStrings
 }
 
-func policyForIssue(issue *github.Issue) motion.PolicyName {
-	labels := LabelsToStrings(issue.Labels)
+func policyForIssue(issue tracker.Issue) motion.PolicyName {
+	labels := issue.Labels
 	switch {
 	case util.IsIn(IssueIsManagedLabel, labels...):
-		if issue.IsPullRequest() {
+		if issue.PullRequest {
 			return waimea.ProposalPolicyName
 		} else {
 			return waimea.ConcernPolicyName
 		}
 	case util.IsIn(IssueIsManagedByWaimeaLabel, labels...):
-		if issue.IsPullRequest() {
+		if issue.PullRequest {
 			return waimea.ProposalPolicyName
 		} else {
 			return waimea.ConcernPolicyName
 		}
 	case util.IsIn(IssueIsManagedByPMPv0Label, labels...):
-		if issue.IsPullRequest() {
+		if issue.PullRequest {
 			return pmp_0.ProposalPolicyName
 		} else {
 			return pmp_0.ConcernPolicyName
 		}
 	case util.IsIn(IssueIsManagedByPMPv1Label, labels...):
-		if issue.IsPullRequest() {
+		if issue.PullRequest {
 			return pmp_1.ProposalPolicyName
 		} else {
 			return pmp_1.ConcernPolicyName
@@ -99,48 +90,44 @@ func policyForIssue(issue *github.Issue) motion.PolicyName {
 	return ""
 }
 
-func IsIssueManaged(issue *github.Issue) bool {
+func IsIssueManaged(issue tracker.Issue) bool {
 	return policyForIssue(issue) != ""
 }
 
 type LoadPRFunc func(
 	ctx context.Context,
-	repo Repo,
-	issue *github.Issue,
+	issue tracker.Issue,
 ) bool
 
 func TransformIssue(
 	ctx context.Context,
-	ghc *github.Client,
-	repo Repo,
-	issue *github.Issue,
+	tr tracker.Tracker,
+	issue tracker.Issue,
 	loadPR LoadPRFunc,
 
 ) ImportedIssue {
 
-	author, _ := getIssueAuthorLogin(issue)
-	var pr *github.PullRequest
-	if issue.IsPullRequest() && loadPR(ctx, repo, issue) {
-		var err error
-		pr, _, err = ghc.PullRequests.Get(ctx, repo.Owner, repo.Name, issue.GetNumber())
-		must.NoError(ctx, err)
+	merged := issue.PullRequest && loadPR(ctx, issue) && tr.IsMerged(ctx, issue.Number)
+	refs := []ImportedRef{}
+	for _, ref := range tr.ParseRefs(issue.Body) {
+		refs = append(refs, ImportedRef{To: ref.To, Type: ref.Type})
 	}
 	return ImportedIssue{
 		ManagedByPolicy: policyForIssue(issue),
-		URL:             issue.GetHTMLURL(),
-		Author:          author,
-		Number:          int64(issue.GetNumber()),
-		Title:           issue.GetTitle(),
-		Body:            issue.GetBody(),
-		Labels:          LabelsToStrings(issue.Labels),
-		ClosedAt:        unwrapTimestamp(issue.ClosedAt),
-		CreatedAt:       unwrapTimestamp(issue.CreatedAt),
-		UpdatedAt:       unwrapTimestamp(issue.UpdatedAt),
-		Refs:            parseIssueRefs(ctx, repo, issue),
-		Locked:          issue.GetLocked(),
-		Closed:          issue.GetState() == "closed",
-		PullRequest:     issue.IsPullRequest(),
-		Merged:          pr != nil && pr.GetMerged(),
+		URL:             issue.URL,
+		Author:          issue.Author,
+		Number:          issue.Number,
+		Title:           issue.Title,
+		Body:            issue.Body,
+		Labels:          issue.Labels,
+		ClosedAt:        issue.ClosedAt,
+		CreatedAt:       issue.CreatedAt,
+		UpdatedAt:       issue.UpdatedAt,
+		Refs:            refs,
+		Locked:          issue.Locked,
+		Closed:          issue.Closed,
+		PullRequest:     issue.PullRequest,
+		Merged:          merged,
 	}
 }
 
@@ -150,31 +137,3 @@ func unwrapTimestamp(ts *github.Timestamp) *time.Time {
 	}
 	return &ts.Time
 }
-
-// parseIssueRefs parses all references to issues or pull requests from the body of an issue.
-// Reference directives are of the form: "addresses|resolves|etc. https://github.com/gov4git/testing.project/issues/2"
-// References are extracted syntactically and are not guaranteed to correspond to real issues.
-func parseIssueRefs(ctx context.Context, repo Repo, issue *github.Issue) []ImportedRef {
-
-	refs := []ImportedRef{}
-	matches := refRegexp.FindAllStringSubmatch(issue.GetBody(), -1)
-	for _, m := range matches {
-		n, err := strconv.Atoi(m[5])
-		if err != nil {
-			// an attacker could inject invalid github issue links
-			base.Infof("reference %q has unparsable issue number %q", m[0], m[5])
-			continue
-		}
-		ref := ImportedRef{To: int64(n), Type: strings.ToLower(m[1])}
-		refs = append(refs, ref)
-	}
-	return refs
-}
-
-// silence CodeQL on missing anchors in the regex
-// lgtm[go/regex/missing-regexp-anchor]
-const refRegexpSrc = `([a-zA-Z0-9\-:_]+)\s+https://github\.com/([a-zA-Z0-9\-]+)/([a-zA-Z0-9\.\-]+)/(issues|pull)/(\d+)`
-
-// silence CodeQL on missing anchors in the regex
-// lgtm[go/regex/missing-regexp-anchor]
-var refRegexp = regexp.MustCompile(refRegexpSrc)
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/google/go-github/v58/github"
 	"github.com/gov4git/gov4git/v2/proto"
@@ -18,6 +19,7 @@ import (
 
 	"github.com/gov4git/gov4git/v2/proto/motion/motionproto"
 	"github.com/gov4git/gov4git/v2/proto/notice"
+	"github.com/gov4git/gov4git/v2/tracker"
 	"github.com/gov4git/lib4git/base"
 	"github.com/gov4git/lib4git/form"
 	"github.com/gov4git/lib4git/git"
@@ -32,16 +34,18 @@ func SyncManagedIssues(
 
 ) git.Change[form.Map, *SyncManagedChanges] {
 
-	govCloned := gov.CloneOwner(ctx, govAddr)
-	syncChanges := SyncManagedIssues_StageOnly(ctx, repo, githubClient, govAddr, govCloned)
-	chg := git.NewChange[form.Map, *SyncManagedChanges](
-		fmt.Sprintf("Sync %d managed GitHub issues", len(syncChanges.IssuesCausingChange)),
-		"github_sync",
-		form.Map{},
-		syncChanges,
-		nil,
-	)
-	return proto.CommitIfChanged(ctx, govCloned.Public, chg)
+	return RetryWithTracker(ctx, NewTracker(ctx, repo, githubClient), func(tr tracker.Tracker) git.Change[form.Map, *SyncManagedChanges] {
+		govCloned := gov.CloneOwner(ctx, govAddr)
+		syncChanges := SyncManagedIssues_StageOnly(ctx, tr, govAddr, govCloned)
+		chg := git.NewChange[form.Map, *SyncManagedChanges](
+			fmt.Sprintf("Sync %d managed GitHub issues", len(syncChanges.IssuesCausingChange)),
+			"github_sync",
+			form.Map{},
+			syncChanges,
+			nil,
+		)
+		return proto.CommitIfChanged(ctx, govCloned.Public, chg)
+	})
 }
 
 type SyncManagedChanges struct {
@@ -54,6 +58,7 @@ type SyncManagedChanges struct {
 	Unfroze             motionproto.MotionIDSet `json:"unfroze_motions"`
 	AddedRefs           motionproto.RefSet      `json:"added_refs"`
 	RemovedRefs         motionproto.RefSet      `json:"removed_refs"`
+	Cursor              time.Time               `json:"cursor"` // latest update time of the synced issues
 }
 
 func newSyncManagedChanges() *SyncManagedChanges {
@@ -70,60 +75,131 @@ func newSyncManagedChanges() *SyncManagedChanges {
 	}
 }
 
+// SyncManagedIssues_StageOnly performs a full reconciliation of all issues with their motions.
 func SyncManagedIssues_StageOnly(
 	ctx context.Context,
-	repo Repo,
-	ghc *github.Client,
+	tr tracker.Tracker,
+	addr gov.OwnerAddress,
+	cloned gov.OwnerCloned,
+
+) (syncChanges *SyncManagedChanges) {
+
+	return SyncManagedIssuesSince_StageOnly(ctx, tr, addr, cloned, time.Time{})
+}
+
+// SyncManagedIssuesSince_StageOnly syncs only the issues updated at or after since, or all issues if since is zero.
+// An incremental sync cannot observe deleted issues, so it should be complemented by a periodic full reconciliation.
+// The cursor of the returned changes can be used as the since argument of the next incremental sync.
+func SyncManagedIssuesSince_StageOnly(
+	ctx context.Context,
+	tr tracker.Tracker,
 	addr gov.OwnerAddress,
 	cloned gov.OwnerCloned,
+	since time.Time,
 
 ) (syncChanges *SyncManagedChanges) {
 
 	syncChanges = newSyncManagedChanges()
+	syncChanges.Cursor = since
 
 	t := cloned.Public.Tree()
 
-	// load github issues and governance motions, and
+	// load tracker issues and governance motions, and
 	// index them under a common key space
 
 	index := indexMotions(motionapi.ListMotions_Local(ctx, t))
 
-	loadPR := func(ctx context.Context,
-		repo Repo,
-		issue *github.Issue,
-	) bool {
+	_, issues := LoadIssuesSince(ctx, tr, since, managedLoadPR(index))
+	for _, issue := range issues {
+		if issue.UpdatedAt != nil && issue.UpdatedAt.After(syncChanges.Cursor) {
+			syncChanges.Cursor = *issue.UpdatedAt
+		}
+	}
 
-		id := IssueNumberToMotionID(int64(issue.GetNumber()))
-		m, motionExists := index[id]
+	// call twice, to capture ref effects on newly created issues
+	incremental := !since.IsZero()
+	syncRefsThenMotions(ctx, tr, addr, cloned, syncChanges, issues, incremental)
+	syncRefsThenMotions(ctx, tr, addr, cloned, syncChanges, issues, incremental)
 
-		return IsIssueManaged(issue) && // merged state not relevant if issue is not managed
-			issue.GetState() == "closed" && // merged state is not relevant for open prs
-			(!motionExists || !m.Closed) // merged state is relevant, when no corresponding motion exists or motion is open
+	syncChanges.IssuesCausingChange.Sort()
+	return
+}
+
+// SyncManagedIssue_StageOnly syncs a single issue with its motion, e.g. in response to a webhook event about the issue.
+func SyncManagedIssue_StageOnly(
+	ctx context.Context,
+	tr tracker.Tracker,
+	addr gov.OwnerAddress,
+	cloned gov.OwnerCloned,
+	issue tracker.Issue,
+
+) (syncChanges *SyncManagedChanges) {
+
+	syncChanges = newSyncManagedChanges()
+	if issue.UpdatedAt != nil {
+		syncChanges.Cursor = *issue.UpdatedAt
 	}
 
-	_, issues := LoadIssues(ctx, ghc, repo, loadPR)
+	index := indexMotions(motionapi.ListMotions_Local(ctx, cloned.Public.Tree()))
+	imported := TransformIssue(ctx, tr, issue, managedLoadPR(index))
+	issues := map[string]ImportedIssue{imported.Key(): imported}
 
 	// call twice, to capture ref effects on newly created issues
-	syncRefsThenMotions(ctx, repo, ghc, addr, cloned, syncChanges, issues)
-	syncRefsThenMotions(ctx, repo, ghc, addr, cloned, syncChanges, issues)
+	syncRefsThenMotions(ctx, tr, addr, cloned, syncChanges, issues, true)
+	syncRefsThenMotions(ctx, tr, addr, cloned, syncChanges, issues, true)
 
 	syncChanges.IssuesCausingChange.Sort()
 	return
 }
 
+// SyncDeletedIssue_StageOnly cancels the motion of an issue, which no longer exists in the issue tracker, if the motion is open.
+func SyncDeletedIssue_StageOnly(
+	ctx context.Context,
+	cloned gov.OwnerCloned,
+	number int64,
+
+) (syncChanges *SyncManagedChanges) {
+
+	syncChanges = newSyncManagedChanges()
+	id := IssueNumberToMotionID(number)
+	if !motionapi.IsMotion_Local(ctx, cloned.Public.Tree(), id) {
+		return
+	}
+	if motionapi.LookupMotion_Local(ctx, cloned.PublicClone(), id).Closed {
+		return
+	}
+	cancelMotionForDeletedIssue_StageOnly(ctx, cloned, syncChanges, id)
+	return
+}
+
+func managedLoadPR(index map[motionproto.MotionID]motionproto.Motion) LoadPRFunc {
+
+	return func(ctx context.Context,
+		issue tracker.Issue,
+	) bool {
+
+		id := IssueNumberToMotionID(issue.Number)
+		m, motionExists := index[id]
+
+		return IsIssueManaged(issue) && // merged state not relevant if issue is not managed
+			issue.Closed && // merged state is not relevant for open prs
+			(!motionExists || !m.Closed) // merged state is relevant, when no corresponding motion exists or motion is open
+	}
+}
+
 func syncRefsThenMotions(
 	ctx context.Context,
-	repo Repo,
-	ghc *github.Client,
+	tr tracker.Tracker,
 	addr gov.OwnerAddress,
 	cloned gov.OwnerCloned,
 	syncChanges *SyncManagedChanges,
 	issues map[string]ImportedIssue,
+	incremental bool, // issues holds only the recently updated issues
 
 ) {
 	// update references
 	index := indexMotions(motionapi.ListMotions_Local(ctx, cloned.PublicClone().Tree()))
-	syncRefs(ctx, cloned, syncChanges, issues, index)
+	syncRefs(ctx, cloned, syncChanges, issues, index, incremental)
 
 	// update motions
 	motionapi.Pipeline_StageOnly(ctx, cloned)
@@ -131,33 +207,32 @@ func syncRefsThenMotions(
 	// sync motions with issues
 	syncMotions(
 		ctx,
-		repo,
-		ghc,
+		tr,
 		addr,
 		cloned,
 		syncChanges,
 		index,
 		issues,
+		incremental,
 	)
 }
 
 func syncMotions(
 	ctx context.Context,
-	repo Repo,
-	ghc *github.Client,
+	tr tracker.Tracker,
 	addr gov.OwnerAddress,
 	cloned gov.OwnerCloned,
 	syncChanges *SyncManagedChanges,
 	motions map[motionproto.MotionID]motionproto.Motion,
 	issues map[string]ImportedIssue,
+	incremental bool, // issues holds only the recently updated issues
 ) {
 
 	for key, issue := range issues {
 		id := motionproto.MotionID(key)
 		syncMotion(
 			ctx,
-			repo,
-			ghc,
+			tr,
 			addr,
 			cloned,
 			syncChanges,
@@ -166,13 +241,51 @@ func syncMotions(
 			issue,
 		)
 	}
-	// don't touch motions that have no corresponding issue
+
+	// an incremental sync does not load all issues, so it doesn't touch motions that have no corresponding issue;
+	// in a full sync, the issues of such motions were deleted or transferred
+	if incremental {
+		return
+	}
+	for id, motion := range motions {
+		if _, ok := issues[id.String()]; ok || motion.Closed {
+			continue
+		}
+		if _, err := MotionIDToIssueNumber(id); err != nil { // motion is not tracked by the issue tracker, e.g. opened through the bureau
+			continue
+		}
+		cancelMotionForDeletedIssue_StageOnly(ctx, cloned, syncChanges, id)
+	}
+}
+
+// cancelMotionForDeletedIssue_StageOnly cancels an open motion, whose issue no longer exists in the issue tracker.
+// The notices of the motion are kept in the governance repo, but are not displayed, as there is no issue to display them on.
+func cancelMotionForDeletedIssue_StageOnly(
+	ctx context.Context,
+	cloned gov.OwnerCloned,
+	syncChanges *SyncManagedChanges,
+	id motionproto.MotionID,
+) {
+
+	base.Infof("cancelling motion %v, whose issue no longer exists", id)
+	motionapi.CancelMotion_StageOnly(ctx, cloned, id)
+	motionapi.AppendMotionNotices_StageOnly(
+		ctx,
+		cloned.PublicClone(),
+		id,
+		notice.Noticef(ctx, "This motion was cancelled, as its issue no longer exists in the issue tracker."),
+	)
+	queue := motionapi.LoadMotionNotices_Local(ctx, cloned.PublicClone(), id)
+	for _, nstate := range queue.NoticeStates {
+		nstate.MarkShown()
+	}
+	motionapi.SaveMotionNotices_StageOnly(ctx, cloned.PublicClone(), id, queue)
+	syncChanges.Cancelled.Add(id)
 }
 
 func syncMotion(
 	ctx context.Context,
-	repo Repo,
-	ghc *github.Client,
+	tr tracker.Tracker,
 	addr gov.OwnerAddress,
 	cloned gov.OwnerCloned,
 	syncChanges *SyncManagedChanges,
@@ -210,7 +323,7 @@ func syncMotion(
 
 				err := must.Try(
 					func() {
-						closeIssue(ctx, repo, ghc, int(issue.Number))
+						tr.CloseIssue(ctx, issue.Number)
 					},
 				)
 				if err != nil {
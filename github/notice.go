@@ -5,17 +5,16 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/google/go-github/v58/github"
 	"github.com/gov4git/gov4git/v2/proto/gov"
 	"github.com/gov4git/gov4git/v2/proto/motion/motionapi"
 	"github.com/gov4git/gov4git/v2/proto/notice"
+	"github.com/gov4git/gov4git/v2/tracker"
 	"github.com/gov4git/lib4git/base"
 )
 
 func DisplayNotices_StageOnly(
 	ctx context.Context,
-	repo Repo,
-	ghc *github.Client,
+	tr tracker.Tracker,
 	cloned gov.Cloned,
 ) {
 
@@ -28,18 +27,17 @@ func DisplayNotices_StageOnly(
 			continue
 		}
 		queue := motionapi.LoadMotionNotices_Local(ctx, cloned, motion.ID)
-		flushNotices(ctx, repo, ghc, cloned, queue, issueNum)
+		flushNotices(ctx, tr, cloned, queue, int64(issueNum))
 		motionapi.SaveMotionNotices_StageOnly(ctx, cloned, motion.ID, queue)
 	}
 }
 
 func flushNotices(
 	ctx context.Context,
-	repo Repo,
-	ghc *github.Client,
+	tr tracker.Tracker,
 	cloned gov.Cloned,
 	queue *notice.NoticeQueue,
-	issueNum int,
+	issueNum int64,
 ) {
 
 	var w bytes.Buffer
@@ -52,7 +50,7 @@ func flushNotices(
 			continue
 		}
 
-		// TODO: check if notice already displayed, according to github
+		// TODO: check if notice already displayed, according to the issue tracker
 
 		fmt.Fprintf(&w, "#### Notice `%v`\n%s\n\n", nstate.ID, nstate.Notice.Body)
 		nstate.MarkShown()
@@ -60,6 +58,6 @@ func flushNotices(
 	}
 
 	if notShown > 0 {
-		replyToIssue(ctx, repo, ghc, issueNum, "Gov4Git notices", w.String())
+		replyToIssue(ctx, tr, issueNum, "Gov4Git notices", w.String())
 	}
 }
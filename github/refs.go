@@ -15,6 +15,7 @@ func syncRefs(
 	chg *SyncManagedChanges,
 	issues map[string]ImportedIssue,
 	motions map[motionproto.MotionID]motionproto.Motion,
+	incremental bool, // issues holds only the recently updated issues
 
 ) {
 
@@ -58,11 +59,23 @@ func syncRefs(
 	}
 
 	// remove refs in motions, not in issues
+	// refs between motions without a corresponding issue (e.g. opened through the bureau) are left alone
+	// in an incremental sync, only the refs of the loaded issues are known, so the refs of other motions are left alone;
+	// in a full sync, the refs of motions whose issues no longer exist are removed as well
 	for motionRef := range motionRefs {
-		if !issueRefs[motionRef] {
+		if _, fromLoaded := issues[motionRef.From.String()]; incremental && !fromLoaded {
+			continue
+		}
+		if !issueRefs[motionRef] && isIssueMotionID(motionRef.From) && isIssueMotionID(motionRef.To) {
 			motionapi.UnlinkMotions_StageOnly(ctx, cloned, motionRef.From, motionRef.To, motionRef.Type)
 			chg.RemovedRefs.Add(motionRef)
 		}
 	}
 
 }
+
+// isIssueMotionID returns true if the motion id is an issue number, i.e. the motion corresponds to an issue in the issue tracker.
+func isIssueMotionID(id motionproto.MotionID) bool {
+	_, err := MotionIDToIssueNumber(id)
+	return err == nil
+}
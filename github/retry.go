@@ -0,0 +1,26 @@
+package github
+
+import (
+	"context"
+
+	"github.com/gov4git/gov4git/v2/proto"
+	"github.com/gov4git/gov4git/v2/tracker"
+)
+
+// RetryWithTracker runs f like proto.Retry1, except that f writes to the issue tracker through a tracker.Deferred.
+// The writes of the attempt that succeeds are performed once, after it returns; the writes of failed attempts are discarded.
+// If tr is nil, f is given a nil tracker.
+func RetryWithTracker[R any](ctx context.Context, tr tracker.Tracker, f func(tr tracker.Tracker) R) R {
+	var deferred *tracker.Deferred
+	r := proto.Retry1(ctx, func() R {
+		if tr == nil {
+			return f(nil)
+		}
+		deferred = tracker.NewDeferred(tr)
+		return f(deferred)
+	})
+	if deferred != nil {
+		deferred.Flush(ctx)
+	}
+	return r
+}
@@ -0,0 +1,221 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v58/github"
+	"github.com/gov4git/gov4git/v2/tracker"
+	"github.com/gov4git/lib4git/base"
+	"github.com/gov4git/lib4git/git"
+	"github.com/gov4git/lib4git/must"
+)
+
+// Tracker implements tracker.Tracker for a GitHub repo.
+type Tracker struct {
+	Repo   Repo
+	Client *github.Client
+}
+
+// NewTracker returns a tracker backed by a GitHub repo.
+func NewTracker(
+	ctx context.Context,
+	repo Repo,
+	ghc *github.Client, // if nil, a new client for repo will be created
+) *Tracker {
+
+	if ghc == nil {
+		ghc = GetGithubClient(ctx, repo)
+	}
+	return &Tracker{Repo: repo, Client: ghc}
+}
+
+func (x *Tracker) String() string {
+	return x.Repo.HTTPS()
+}
+
+func (x *Tracker) ListIssues(ctx context.Context, state tracker.State, labels ...string) []tracker.Issue {
+	return x.listIssues(ctx, &github.IssueListByRepoOptions{State: string(state), Labels: labels})
+}
+
+func (x *Tracker) ListIssuesSince(ctx context.Context, since time.Time) []tracker.Issue {
+	return x.listIssues(ctx, &github.IssueListByRepoOptions{State: string(tracker.StateAll), Since: since})
+}
+
+func (x *Tracker) listIssues(ctx context.Context, opt *github.IssueListByRepoOptions) []tracker.Issue {
+
+	var allIssues []tracker.Issue
+	for {
+		issues, resp, err := x.Client.Issues.ListByRepo(ctx, x.Repo.Owner, x.Repo.Name, opt)
+		must.NoError(ctx, err)
+		for _, issue := range issues {
+			allIssues = append(allIssues, transformGithubIssue(issue))
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+	return allIssues
+}
+
+func (x *Tracker) GetIssue(ctx context.Context, number int64) tracker.Issue {
+	issue, resp, err := x.Client.Issues.Get(ctx, x.Repo.Owner, x.Repo.Name, int(number))
+	if resp != nil && (resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone) {
+		must.Panic(ctx, fmt.Errorf("issue %d: %w", number, tracker.ErrIssueNotFound))
+	}
+	must.NoError(ctx, err)
+	return transformGithubIssue(issue)
+}
+
+func transformGithubIssue(issue *github.Issue) tracker.Issue {
+	var author, email string
+	if u := issue.GetUser(); u != nil {
+		author, email = strings.ToLower(u.GetLogin()), u.GetEmail()
+	}
+	return tracker.Issue{
+		Number:      int64(issue.GetNumber()),
+		URL:         issue.GetHTMLURL(),
+		Author:      author,
+		AuthorEmail: email,
+		Title:       issue.GetTitle(),
+		Body:        issue.GetBody(),
+		Labels:      LabelsToStrings(issue.Labels),
+		Comments:    issue.GetComments(),
+		Locked:      issue.GetLocked(),
+		Closed:      issue.GetState() == "closed",
+		PullRequest: issue.IsPullRequest(),
+		CreatedAt:   unwrapTimestamp(issue.CreatedAt),
+		UpdatedAt:   unwrapTimestamp(issue.UpdatedAt),
+		ClosedAt:    unwrapTimestamp(issue.ClosedAt),
+	}
+}
+
+func (x *Tracker) IsMerged(ctx context.Context, number int64) bool {
+	pr, _, err := x.Client.PullRequests.Get(ctx, x.Repo.Owner, x.Repo.Name, int(number))
+	must.NoError(ctx, err)
+	return pr.GetMerged()
+}
+
+func (x *Tracker) ListComments(ctx context.Context, number int64) []tracker.Comment {
+	opts := &github.IssueListCommentsOptions{}
+	comments, _, err := x.Client.Issues.ListComments(ctx, x.Repo.Owner, x.Repo.Name, int(number), opts)
+	must.NoError(ctx, err)
+	r := make([]tracker.Comment, 0, len(comments))
+	for _, c := range comments {
+		var author string
+		if u := c.GetUser(); u != nil {
+			author = strings.ToLower(u.GetLogin())
+		}
+		r = append(r, tracker.Comment{Author: author, Body: c.GetBody()})
+	}
+	return r
+}
+
+func (x *Tracker) ListMaintainers(ctx context.Context) []string {
+	return FetchRepoMaintainers(ctx, x.Repo, x.Client)
+}
+
+func (x *Tracker) PostComment(ctx context.Context, number int64, body string) {
+	comment := &github.IssueComment{
+		Body: github.String(body),
+	}
+	_, _, err := x.Client.Issues.CreateComment(ctx, x.Repo.Owner, x.Repo.Name, int(number), comment)
+	must.NoError(ctx, err)
+}
+
+func (x *Tracker) CloseIssue(ctx context.Context, number int64) {
+	req := &github.IssueRequest{
+		State: github.String("closed"),
+	}
+	_, _, err := x.Client.Issues.Edit(ctx, x.Repo.Owner, x.Repo.Name, int(number), req)
+	must.NoError(ctx, err)
+}
+
+func (x *Tracker) UpsertIssue(ctx context.Context, label string, title string, body string) {
+
+	labels := []string{label}
+
+	// check if there is an existing issue
+	opt := &github.IssueListByRepoOptions{
+		State:  "open",
+		Labels: labels,
+	}
+	issues, _, err := x.Client.Issues.ListByRepo(ctx, x.Repo.Owner, x.Repo.Name, opt)
+	must.NoError(ctx, err)
+
+	// create an issue if there is none
+	req := &github.IssueRequest{
+		Title:  github.String(title),
+		Body:   github.String(body),
+		Labels: &labels,
+	}
+	if len(issues) == 0 {
+		_, _, err := x.Client.Issues.Create(ctx, x.Repo.Owner, x.Repo.Name, req)
+		must.NoError(ctx, err)
+	} else {
+		_, _, err := x.Client.Issues.Edit(ctx, x.Repo.Owner, x.Repo.Name, issues[0].GetNumber(), req)
+		must.NoError(ctx, err)
+	}
+}
+
+// ParseRefs parses all references to issues or pull requests from the body of an issue.
+// Reference directives are of the form: "addresses|resolves|etc. https://github.com/gov4git/testing.project/issues/2"
+// References are extracted syntactically and are not guaranteed to correspond to real issues.
+func (x *Tracker) ParseRefs(body string) []tracker.Ref {
+
+	refs := []tracker.Ref{}
+	matches := refRegexp.FindAllStringSubmatch(body, -1)
+	for _, m := range matches {
+		n, err := strconv.Atoi(m[5])
+		if err != nil {
+			// an attacker could inject invalid github issue links
+			base.Infof("reference %q has unparsable issue number %q", m[0], m[5])
+			continue
+		}
+		refs = append(refs, tracker.Ref{To: int64(n), Type: strings.ToLower(m[1])})
+	}
+	return refs
+}
+
+func (x *Tracker) ParseIssueURL(url string) (int64, bool) {
+	m := issueURLRegexp.FindStringSubmatch(url)
+	if m == nil {
+		return 0, false
+	}
+	n, err := strconv.Atoi(m[4])
+	if err != nil {
+		return 0, false
+	}
+	return int64(n), true
+}
+
+func (x *Tracker) RawFileURL(ctx context.Context, addr git.Address, path string) string {
+	repo, err := ParseGithubRepoURL(string(addr.Repo))
+	must.NoError(ctx, err)
+	return fmt.Sprintf(
+		"https://raw.githubusercontent.com/%s/%s/%s/%s",
+		repo.Owner,
+		repo.Name,
+		addr.Branch,
+		path,
+	)
+}
+
+const issueURLRegexpSrc = `https://github\.com/([a-zA-Z0-9\-]+)/([a-zA-Z0-9\.\-]+)/(issues|pull)/(\d+)`
+
+// silence CodeQL on missing anchors in the regex
+// lgtm[go/regex/missing-regexp-anchor]
+const refRegexpSrc = `([a-zA-Z0-9\-:_]+)\s+` + issueURLRegexpSrc
+
+// silence CodeQL on missing anchors in the regex
+// lgtm[go/regex/missing-regexp-anchor]
+var (
+	refRegexp      = regexp.MustCompile(refRegexpSrc)
+	issueURLRegexp = regexp.MustCompile(`^` + issueURLRegexpSrc + `$`)
+)
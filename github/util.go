@@ -9,6 +9,7 @@ import (
 	"github.com/google/go-github/v58/github"
 	"github.com/gov4git/gov4git/v2"
 	"github.com/gov4git/gov4git/v2/materials"
+	"github.com/gov4git/gov4git/v2/tracker"
 	"github.com/gov4git/lib4git/must"
 	"github.com/gov4git/lib4git/util"
 )
@@ -32,38 +33,21 @@ func FetchRepoMaintainers(
 	return m
 }
 
-func fetchOpenIssues(ctx context.Context, repo Repo, ghc *github.Client, labelled ...string) []*github.Issue {
-	opt := &github.IssueListByRepoOptions{State: "open", Labels: labelled}
-	var allIssues []*github.Issue
-	for {
-		issues, resp, err := ghc.Issues.ListByRepo(ctx, repo.Owner, repo.Name, opt)
-		must.NoError(ctx, err)
-		allIssues = append(allIssues, issues...)
-		if resp.NextPage == 0 {
-			break
-		}
-		opt.Page = resp.NextPage
-	}
-	return allIssues
-}
-
 func replyAndCloseIssue(
 	ctx context.Context,
-	repo Repo,
-	ghc *github.Client,
-	issue *github.Issue,
+	tr tracker.Tracker,
+	issue tracker.Issue,
 	subject string,
 	payload string,
 ) {
-	replyToIssue(ctx, repo, ghc, issue.GetNumber(), subject, payload)
-	closeIssue(ctx, repo, ghc, issue.GetNumber())
+	replyToIssue(ctx, tr, issue.Number, subject, payload)
+	tr.CloseIssue(ctx, issue.Number)
 }
 
 func replyToIssue(
 	ctx context.Context,
-	repo Repo,
-	ghc *github.Client,
-	issueNum int,
+	tr tracker.Tracker,
+	issueNum int64,
 	subject string,
 	payload string,
 ) {
@@ -78,57 +62,35 @@ func replyToIssue(
 		gov4git.GetVersionInfo().Version,
 	)
 
-	comment := &github.IssueComment{
-		Body: github.String(header + payload),
-	}
-	_, _, err := ghc.Issues.CreateComment(ctx, repo.Owner, repo.Name, issueNum, comment)
-	must.NoError(ctx, err)
+	tr.PostComment(ctx, issueNum, header+payload)
 }
 
 const (
 	FollowUpSubject = "Follow up"
 )
 
-func closeIssue(
-	ctx context.Context,
-	repo Repo,
-	ghc *github.Client,
-	issueNumber int,
-) {
-	req := &github.IssueRequest{
-		State: github.String("closed"),
-	}
-	_, _, err := ghc.Issues.Edit(ctx, repo.Owner, repo.Name, issueNumber, req)
-	must.NoError(ctx, err)
-}
-
 func fetchIssueComments(
 	ctx context.Context,
-	repo Repo,
-	ghc *github.Client,
-	issue *github.Issue,
-) []*github.IssueComment {
+	tr tracker.Tracker,
+	issue tracker.Issue,
+) []tracker.Comment {
 
-	if issue.GetComments() == 0 {
+	if issue.Comments == 0 {
 		return nil
 	}
-	opts := &github.IssueListCommentsOptions{}
-	comments, _, err := ghc.Issues.ListComments(ctx, repo.Owner, repo.Name, issue.GetNumber(), opts)
-	must.NoError(ctx, err)
-	return comments
+	return tr.ListComments(ctx, issue.Number)
 }
 
-func isJoinApprovalPresent(ctx context.Context, approvers []string, comments []*github.IssueComment) bool {
+func isJoinApprovalPresent(ctx context.Context, approvers []string, comments []tracker.Comment) bool {
 	for _, comment := range comments {
-		u := comment.GetUser()
-		if u == nil {
+		if comment.Author == "" {
 			continue
 		}
-		if !util.IsIn(strings.ToLower(u.GetLogin()), approvers...) {
+		if !util.IsIn(comment.Author, approvers...) {
 			continue
 		}
 		// trim empty lines and spaces
-		trimmed := strings.ToLower(strings.Trim(comment.GetBody(), ". \t\n\r"))
+		trimmed := strings.ToLower(strings.Trim(comment.Body, ". \t\n\r"))
 		if strings.Index(trimmed, JoinRequestApprovalWord) < 0 {
 			continue
 		}
@@ -136,15 +98,3 @@ func isJoinApprovalPresent(ctx context.Context, approvers []string, comments []*
 	}
 	return false
 }
-
-func getIssueAuthorLogin(issue *github.Issue) (string, error) {
-	u := issue.GetUser()
-	if u == nil {
-		return "", fmt.Errorf("github issue without author")
-	}
-	login := strings.ToLower(u.GetLogin())
-	if login == "" {
-		return "", fmt.Errorf("github issue author has no login")
-	}
-	return login, nil
-}
@@ -0,0 +1,170 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/google/go-github/v58/github"
+	"github.com/gov4git/gov4git/v2/proto"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/tracker"
+	"github.com/gov4git/lib4git/base"
+	"github.com/gov4git/lib4git/form"
+	"github.com/gov4git/lib4git/git"
+	"github.com/gov4git/lib4git/must"
+)
+
+// WebhookHandler receives GitHub webhook deliveries and syncs the affected issues with governance,
+// as an alternative to waiting for the next polling sync by cron.
+type WebhookHandler struct {
+	ctx     context.Context
+	tr      tracker.Tracker
+	govAddr gov.OwnerAddress
+	secret  []byte     // webhook secret, used to verify the signature of deliveries
+	lk      sync.Mutex // serializes changes to the community repo
+}
+
+func NewWebhookHandler(
+	ctx context.Context,
+	tr tracker.Tracker,
+	govAddr gov.OwnerAddress,
+	secret []byte,
+
+) *WebhookHandler {
+
+	must.Assertf(ctx, len(secret) > 0, "webhook secret must not be empty")
+	return &WebhookHandler{ctx: ctx, tr: tr, govAddr: govAddr, secret: secret}
+}
+
+func (x *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+
+	if r.Method != http.MethodPost {
+		http.Error(w, "webhook deliveries must be posted", http.StatusMethodNotAllowed)
+		return
+	}
+	payload, err := github.ValidatePayload(r, x.secret)
+	if err != nil {
+		base.Infof("webhook delivery %v rejected (%v)", github.DeliveryID(r), err)
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+	eventType := github.WebHookType(r)
+	event, err := github.ParseWebHook(eventType, payload)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("cannot parse event (%v)", err), http.StatusBadRequest)
+		return
+	}
+
+	x.lk.Lock()
+	defer x.lk.Unlock()
+	chg, mustErr := must.Try1Thru(
+		func() git.Change[form.Map, WebhookReport] {
+			return ProcessWebhookEvent(x.ctx, x.tr, x.govAddr, eventType, event)
+		},
+	)
+	if mustErr != nil {
+		base.Infof("webhook delivery %v failed (%v)", github.DeliveryID(r), mustErr)
+		http.Error(w, mustErr.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write([]byte(form.SprintJSON(chg.Result)))
+}
+
+type WebhookReport struct {
+	Event      string                          `json:"event"`
+	Action     string                          `json:"action,omitempty"`
+	Issue      int64                           `json:"issue,omitempty"`   // the affected issue, if any
+	Deleted    bool                            `json:"deleted,omitempty"` // whether the affected issue no longer exists
+	Synced     *SyncManagedChanges             `json:"synced,omitempty"`
+	Joins      *ProcessJoinRequestIssuesReport `json:"processed_joins,omitempty"`
+	Directives ProcessDirectiveIssueReports    `json:"processed_directives,omitempty"`
+}
+
+// ProcessWebhookEvent applies a parsed webhook event to governance and commits the resulting changes, if any.
+// Replies to the affected issue are posted after the changes are pushed.
+func ProcessWebhookEvent(
+	ctx context.Context,
+	tr tracker.Tracker,
+	govAddr gov.OwnerAddress,
+	eventType string,
+	event any,
+
+) git.Change[form.Map, WebhookReport] {
+
+	return RetryWithTracker(ctx, tr, func(tr tracker.Tracker) git.Change[form.Map, WebhookReport] {
+		cloned := gov.CloneOwner(ctx, govAddr)
+		report := ProcessWebhookEvent_StageOnly(ctx, tr, govAddr, cloned, eventType, event)
+		chg := git.NewChange[form.Map, WebhookReport](
+			fmt.Sprintf("Process GitHub %s event", eventType),
+			"github_webhook",
+			form.Map{},
+			report,
+			nil,
+		)
+		return proto.CommitIfChanged(ctx, cloned.Public, chg)
+	})
+}
+
+// ProcessWebhookEvent_StageOnly handles issues, pull_request and issue_comment events by syncing only the affected issue.
+// The affected issue is fetched from the tracker, rather than taken from the event,
+// so that redelivered or out-of-order events do not overwrite governance with a stale snapshot of the issue.
+// If the affected issue no longer exists, its motion is cancelled.
+// Label events can affect any number of issues, so they trigger a full sync.
+// Other events are ignored.
+func ProcessWebhookEvent_StageOnly(
+	ctx context.Context,
+	tr tracker.Tracker,
+	govAddr gov.OwnerAddress,
+	cloned gov.OwnerCloned,
+	eventType string,
+	event any,
+
+) WebhookReport {
+
+	report := WebhookReport{Event: eventType}
+	var number int64
+	switch e := event.(type) {
+	case *github.IssuesEvent:
+		report.Action = e.GetAction()
+		number = int64(e.GetIssue().GetNumber())
+	case *github.IssueCommentEvent:
+		report.Action = e.GetAction()
+		number = int64(e.GetIssue().GetNumber())
+	case *github.PullRequestEvent:
+		report.Action = e.GetAction()
+		number = int64(e.GetPullRequest().GetNumber())
+	case *github.LabelEvent:
+		report.Action = e.GetAction()
+		report.Synced = SyncManagedIssues_StageOnly(ctx, tr, govAddr, cloned)
+		return report
+	default:
+		base.Infof("ignoring github %s event", eventType)
+		return report
+	}
+
+	issue, err := must.Try1(func() tracker.Issue { return tr.GetIssue(ctx, number) })
+	if errors.Is(err, tracker.ErrIssueNotFound) {
+		// the issue was deleted or transferred, so its motion is cancelled (rather than failing, which would cause redeliveries)
+		report.Issue = number
+		report.Deleted = true
+		report.Synced = SyncDeletedIssue_StageOnly(ctx, cloned, number)
+		return report
+	}
+	must.NoError(ctx, err)
+	report.Issue = issue.Number
+	report.Synced = SyncManagedIssue_StageOnly(ctx, tr, govAddr, cloned, issue)
+
+	// join approvals and directives are checked against the maintainers, whose lookup is only done when needed
+	if !issue.Closed && (isJoinRequestIssue(issue) || isDirectiveIssue(issue)) {
+		maintainers := tr.ListMaintainers(ctx)
+		joins := ProcessJoinRequestIssue_StageOnly(ctx, tr, govAddr, cloned, maintainers, false, issue)
+		report.Joins = &joins
+		report.Directives = ProcessDirectiveIssue_StageOnly(ctx, tr, govAddr, cloned, maintainers, issue)
+	}
+
+	return report
+}
@@ -1,8 +1,12 @@
 package cmd
 
 import (
+	"os"
+	"time"
+
 	"github.com/gov4git/gov4git/v2/gov4git/api"
 	"github.com/gov4git/gov4git/v2/proto/account"
+	"github.com/gov4git/lib4git/must"
 	"github.com/spf13/cobra"
 )
 
@@ -137,6 +141,116 @@ var (
 			)
 		},
 	}
+
+	accountAuditCmd = &cobra.Command{
+		Use:   "audit",
+		Short: "Verify account balances against the metric history and check conservation of assets",
+		Long:  ``,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke1(
+				func() any {
+					LoadConfig()
+					return account.Audit(
+						ctx,
+						setup.Gov,
+					)
+				},
+			)
+		},
+	}
+
+	accountStatementCmd = &cobra.Command{
+		Use:   "statement",
+		Short: "Show the debits and credits of an account",
+		Long:  ``,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke1(
+				func() any {
+					LoadConfig()
+					st := account.Statement(
+						ctx,
+						setup.Gov,
+						account.AccountID(accountID),
+						optionalTime(parseOptionalTime(ctx, accountFrom)),
+						optionalTime(parseOptionalTime(ctx, accountTo)),
+					)
+					if accountCSV != "" {
+						f, err := os.Create(accountCSV)
+						must.NoError(ctx, err)
+						defer f.Close()
+						must.NoError(ctx, st.WriteCSV(f))
+					}
+					return st
+				},
+			)
+		},
+	}
+
+	accountAssetCmd = &cobra.Command{
+		Use:   "asset",
+		Short: "Manage assets",
+		Long:  ``,
+		Run:   func(cmd *cobra.Command, args []string) {},
+	}
+
+	accountAssetDefineCmd = &cobra.Command{
+		Use:   "define",
+		Short: "Define a new asset",
+		Long:  ``,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke(
+				func() {
+					LoadConfig()
+					account.DefineAsset(
+						ctx,
+						setup.Gov,
+						account.AssetInfo{
+							Asset:        account.Asset(accountAsset),
+							Description:  accountAssetDesc,
+							Transferable: accountAssetTransferable,
+							Divisible:    accountAssetDivisible,
+							Issuer:       account.AccountID(accountAssetIssuer),
+						},
+					)
+				},
+			)
+		},
+	}
+
+	accountAssetListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List assets",
+		Long:  ``,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke1(
+				func() any {
+					LoadConfig()
+					return account.ListAssets(
+						ctx,
+						setup.Gov,
+					)
+				},
+			)
+		},
+	}
+
+	accountAssetShowCmd = &cobra.Command{
+		Use:   "show",
+		Short: "Show asset",
+		Long:  ``,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke1(
+				func() any {
+					LoadConfig()
+					return account.LookupAsset(
+						ctx,
+						setup.Gov,
+						account.Asset(accountAsset),
+					)
+				},
+			)
+		},
+	}
 )
 
 var (
@@ -146,6 +260,14 @@ var (
 	accountAsset    string
 	accountQuantity float64
 	accountNote     string
+	accountFrom     string
+	accountTo       string
+	accountCSV      string
+
+	accountAssetDesc         string
+	accountAssetTransferable bool
+	accountAssetDivisible    bool
+	accountAssetIssuer       string
 )
 
 func init() {
@@ -190,4 +312,33 @@ func init() {
 	accountBalanceCmd.MarkFlagRequired("id")
 	accountBalanceCmd.Flags().StringVarP(&accountAsset, "asset", "a", "", "asset")
 	accountBalanceCmd.MarkFlagRequired("asset")
+	// audit
+	accountCmd.AddCommand(accountAuditCmd)
+	// statement
+	accountCmd.AddCommand(accountStatementCmd)
+	accountStatementCmd.Flags().StringVar(&accountID, "id", "", "account id")
+	accountStatementCmd.MarkFlagRequired("id")
+	accountStatementCmd.Flags().StringVar(&accountFrom, "from", "", "start of statement period (RFC3339)")
+	accountStatementCmd.Flags().StringVar(&accountTo, "to", "", "end of statement period (RFC3339)")
+	accountStatementCmd.Flags().StringVar(&accountCSV, "csv", "", "also export the statement entries as CSV to this file")
+	// asset
+	accountCmd.AddCommand(accountAssetCmd)
+	accountAssetCmd.AddCommand(accountAssetDefineCmd)
+	accountAssetDefineCmd.Flags().StringVarP(&accountAsset, "asset", "a", "", "asset")
+	accountAssetDefineCmd.MarkFlagRequired("asset")
+	accountAssetDefineCmd.Flags().StringVar(&accountAssetDesc, "desc", "", "asset description")
+	accountAssetDefineCmd.Flags().BoolVar(&accountAssetTransferable, "transferable", true, "members can transfer the asset to each other")
+	accountAssetDefineCmd.Flags().BoolVar(&accountAssetDivisible, "divisible", true, "asset can be held in fractional quantities")
+	accountAssetDefineCmd.Flags().StringVar(&accountAssetIssuer, "issuer", "", "account issuing the asset (the issue account, if empty)")
+	accountAssetCmd.AddCommand(accountAssetListCmd)
+	accountAssetCmd.AddCommand(accountAssetShowCmd)
+	accountAssetShowCmd.Flags().StringVarP(&accountAsset, "asset", "a", "", "asset")
+	accountAssetShowCmd.MarkFlagRequired("asset")
+}
+
+func optionalTime(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return *t
 }
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"context"
+	"time"
 
 	"github.com/gov4git/gov4git/v2/gov4git/api"
 	"github.com/gov4git/gov4git/v2/proto/account"
@@ -32,7 +33,7 @@ var (
 					LoadConfig()
 					chg := ballotapi.Open(
 						ctx,
-						ballotio.QVPolicyName,
+						ballotproto.PolicyName(ballotPolicy),
 						setup.Organizer,
 						ballotproto.ParseBallotID(ballotName),
 						account.NobodyAccountID,
@@ -42,6 +43,7 @@ var (
 						ballotDescription,
 						ballotChoices,
 						member.Group(ballotGroup),
+						ballotOpenOptions()...,
 					)
 					return chg.Result
 				},
@@ -145,6 +147,24 @@ var (
 		},
 	}
 
+	ballotFetchReportCmd = &cobra.Command{
+		Use:   "fetch-report",
+		Short: "Show why votes on a ballot were not received or not counted",
+		Long:  ``,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke1(
+				func() any {
+					LoadConfig()
+					return ballotapi.LoadFetchReport(
+						ctx,
+						setup.Gov,
+						ballotproto.ParseBallotID(ballotName),
+					)
+				},
+			)
+		},
+	}
+
 	ballotListCmd = &cobra.Command{
 		Use:   "list",
 		Short: "List ballots",
@@ -179,13 +199,13 @@ var (
 			api.Invoke1(
 				func() any {
 					LoadConfig()
-					chg := ballotapi.Tally(
+					chg, report := ballotapi.TallyWithReport(
 						ctx,
 						setup.Organizer,
 						ballotproto.ParseBallotID(ballotName),
 						ballotFetchPar,
 					)
-					return chg.Result
+					return ballotapi.TallyReport{Tally: chg.Result, FetchReport: report}
 				},
 			)
 		},
@@ -204,7 +224,27 @@ var (
 						setup.Member,
 						setup.Gov,
 						ballotproto.ParseBallotID(ballotName),
-						parseElections(ctx, ballotElectionChoice, ballotElectionStrength),
+						parseElections(ctx, ballotElectionChoice, ballotElectionStrength, ballotElectionRanking),
+					)
+					return chg.Result
+				},
+			)
+		},
+	}
+
+	ballotRevealCmd = &cobra.Command{
+		Use:   "reveal",
+		Short: "Reveal committed votes on a frozen secret ballot",
+		Long:  ``,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke1(
+				func() any {
+					LoadConfig()
+					chg := ballotapi.Reveal(
+						ctx,
+						setup.Member,
+						setup.Gov,
+						ballotproto.ParseBallotID(ballotName),
 					)
 					return chg.Result
 				},
@@ -212,6 +252,24 @@ var (
 		},
 	}
 
+	ballotDecisionCmd = &cobra.Command{
+		Use:   "decision",
+		Short: "Show whether a ballot would pass, if it were closed now",
+		Long:  ``,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke1(
+				func() any {
+					LoadConfig()
+					return ballotapi.WouldPass(
+						ctx,
+						setup.Gov,
+						ballotproto.ParseBallotID(ballotName),
+					)
+				},
+			)
+		},
+	}
+
 	ballotTrackCmd = &cobra.Command{
 		Use:   "track",
 		Short: "Track the status of votes",
@@ -263,6 +321,8 @@ var (
 	ballotGroup            string
 	ballotElectionChoice   []string
 	ballotElectionStrength []float64
+	ballotElectionRanking  []string
+	ballotPolicy           string
 	ballotUseVotingCredits bool
 	ballotOnlyNames        bool
 	ballotOnlyOpen         bool
@@ -271,6 +331,17 @@ var (
 	ballotWithParticipant  string
 	ballotFetchPar         int
 	ballotEscrowTo         string
+	ballotSecret           bool
+	ballotSecretDeposit    float64
+	ballotSecretUnrevealed string
+	ballotEncrypted        bool
+	ballotOpensAt          string
+	ballotFreezesAt        string
+	ballotClosesAt         string
+	ballotMinVoters        int
+	ballotMinCap           float64
+	ballotSupermajority    float64
+	ballotAsset            string
 )
 
 func init() {
@@ -287,6 +358,18 @@ func init() {
 	ballotOpenCmd.Flags().StringVar(&ballotGroup, "group", "", "group of ballot participants")
 	ballotOpenCmd.MarkFlagRequired("group")
 	ballotOpenCmd.Flags().BoolVar(&ballotUseVotingCredits, "use_credits", false, "use voting credits")
+	ballotOpenCmd.Flags().StringVar(&ballotPolicy, "policy", ballotio.QVPolicyName.String(), "ballot policy (qv, linear, approval, score, conviction, irv or schulze)")
+	ballotOpenCmd.Flags().BoolVar(&ballotSecret, "secret", false, "use commit-reveal voting, so votes remain secret until the ballot is frozen")
+	ballotOpenCmd.Flags().Float64Var(&ballotSecretDeposit, "deposit", 0, "credits escrowed with each vote commitment on a secret ballot, returned when revealed")
+	ballotOpenCmd.Flags().StringVar(&ballotOpensAt, "opens_at", "", "time when the ballot starts accepting votes, in RFC3339 format")
+	ballotOpenCmd.Flags().StringVar(&ballotFreezesAt, "freezes_at", "", "time when the ballot is frozen automatically, in RFC3339 format")
+	ballotOpenCmd.Flags().StringVar(&ballotClosesAt, "closes_at", "", "time when the ballot is closed automatically, in RFC3339 format")
+	ballotOpenCmd.Flags().IntVar(&ballotMinVoters, "min_voters", 0, "minimum number of voters for the ballot to pass")
+	ballotOpenCmd.Flags().Float64Var(&ballotMinCap, "min_capitalization", 0, "minimum total credits spent by voters for the ballot to pass")
+	ballotOpenCmd.Flags().Float64Var(&ballotSupermajority, "supermajority", 0, "minimum fraction of support (between 0 and 1) for the leading choice to pass")
+	ballotOpenCmd.Flags().BoolVar(&ballotEncrypted, "encrypted", false, "encrypt votes to a community key for the ballot, so they are not disclosed in voter repos; votes are disclosed in the public tally, once tallied")
+	ballotOpenCmd.Flags().StringVar(&ballotAsset, "asset", "", "asset charged to voters, instead of the one charged by the policy")
+	ballotOpenCmd.Flags().StringVar(&ballotSecretUnrevealed, "unrevealed", string(ballotproto.UnrevealedRefund), "deposits of unrevealed commitments on a secret ballot are either refunded or penalized")
 
 	// close
 	ballotCmd.AddCommand(ballotCloseCmd)
@@ -314,6 +397,11 @@ func init() {
 	ballotShowCmd.Flags().StringVar(&ballotName, "name", "", "ballot name")
 	ballotShowCmd.MarkFlagRequired("name")
 
+	// fetch report
+	ballotCmd.AddCommand(ballotFetchReportCmd)
+	ballotFetchReportCmd.Flags().StringVar(&ballotName, "name", "", "ballot name")
+	ballotFetchReportCmd.MarkFlagRequired("name")
+
 	// list
 	ballotCmd.AddCommand(ballotListCmd)
 	ballotListCmd.Flags().BoolVar(&ballotOnlyNames, "only_names", false, "list only ballot names")
@@ -333,9 +421,20 @@ func init() {
 	ballotVoteCmd.Flags().StringVar(&ballotName, "name", "", "ballot name")
 	ballotVoteCmd.MarkFlagRequired("name")
 	ballotVoteCmd.Flags().StringSliceVar(&ballotElectionChoice, "choices", nil, "list of elected choices")
-	ballotVoteCmd.MarkFlagRequired("choices")
 	ballotVoteCmd.Flags().Float64SliceVar(&ballotElectionStrength, "strengths", nil, "list of elected vote strengths")
-	ballotVoteCmd.MarkFlagRequired("strengths")
+	ballotVoteCmd.Flags().StringSliceVar(&ballotElectionRanking, "ranking", nil, "ranked choices, most preferred first (for ranked-choice ballots)")
+	ballotVoteCmd.MarkFlagsRequiredTogether("choices", "strengths")
+	ballotVoteCmd.MarkFlagsMutuallyExclusive("choices", "ranking")
+
+	// decision
+	ballotCmd.AddCommand(ballotDecisionCmd)
+	ballotDecisionCmd.Flags().StringVar(&ballotName, "name", "", "ballot name")
+	ballotDecisionCmd.MarkFlagRequired("name")
+
+	// reveal
+	ballotCmd.AddCommand(ballotRevealCmd)
+	ballotRevealCmd.Flags().StringVar(&ballotName, "name", "", "ballot name")
+	ballotRevealCmd.MarkFlagRequired("name")
 
 	// track
 	ballotCmd.AddCommand(ballotTrackCmd)
@@ -348,7 +447,13 @@ func init() {
 	ballotEraseCmd.MarkFlagRequired("name")
 }
 
-func parseElections(ctx context.Context, choices []string, strengths []float64) ballotproto.Elections {
+func parseElections(ctx context.Context, choices []string, strengths []float64, ranking []string) ballotproto.Elections {
+	if len(ranking) > 0 {
+		return ballotproto.Elections{ballotproto.NewRankedElection(ranking)}
+	}
+	if len(choices) == 0 {
+		must.Errorf(ctx, "either elected choices or a ranking must be given")
+	}
 	if len(choices) != len(strengths) {
 		must.Errorf(ctx, "elected choices must match elected strengths in count")
 	}
@@ -358,3 +463,53 @@ func parseElections(ctx context.Context, choices []string, strengths []float64)
 	}
 	return el
 }
+
+func ballotOpenOptions() []ballotapi.OpenOption {
+	opts := []ballotapi.OpenOption{}
+	if ballotSecret {
+		opts = append(opts,
+			ballotapi.WithCommitReveal(
+				ballotproto.CommitReveal{
+					Deposit:    ballotSecretDeposit,
+					Unrevealed: ballotproto.UnrevealedPolicy(ballotSecretUnrevealed),
+				},
+			),
+		)
+	}
+	if ballotEncrypted {
+		opts = append(opts, ballotapi.WithEncryption())
+	}
+	if ballotAsset != "" {
+		opts = append(opts, ballotapi.WithAsset(account.Asset(ballotAsset)))
+	}
+	if ballotMinVoters != 0 || ballotMinCap != 0 || ballotSupermajority != 0 {
+		opts = append(opts,
+			ballotapi.WithDecisionRule(
+				ballotproto.DecisionRule{
+					MinVoters:         ballotMinVoters,
+					MinCapitalization: ballotMinCap,
+					Supermajority:     ballotSupermajority,
+				},
+			),
+		)
+	}
+	if ballotOpensAt != "" || ballotFreezesAt != "" || ballotClosesAt != "" {
+		opts = append(opts,
+			ballotapi.WithSchedule(
+				parseOptionalTime(ctx, ballotOpensAt),
+				parseOptionalTime(ctx, ballotFreezesAt),
+				parseOptionalTime(ctx, ballotClosesAt),
+			),
+		)
+	}
+	return opts
+}
+
+func parseOptionalTime(ctx context.Context, s string) *time.Time {
+	if s == "" {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	must.NoError(ctx, err)
+	return &t
+}
@@ -1,9 +1,15 @@
 package cmd
 
 import (
+	"strings"
+
 	"github.com/gov4git/gov4git/v2/gov4git/api"
+	"github.com/gov4git/gov4git/v2/proto/account"
 	"github.com/gov4git/gov4git/v2/proto/bureau"
 	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/gov4git/v2/proto/motion"
+	"github.com/gov4git/gov4git/v2/proto/motion/motionproto"
+	"github.com/gov4git/gov4git/v2/proto/purpose"
 	"github.com/spf13/cobra"
 )
 
@@ -20,14 +26,14 @@ var (
 		Short: "Fetch and process requests from community members",
 		Long:  ``,
 		Run: func(cmd *cobra.Command, args []string) {
-			api.Invoke(
-				func() {
+			api.Invoke1(
+				func() any {
 					LoadConfig()
-					bureau.Process(
+					return bureau.Process(
 						ctx,
 						setup.Organizer,
 						member.Group(bureauGroup),
-					)
+					).Result
 				},
 			)
 		},
@@ -47,19 +53,217 @@ var (
 						setup.Gov,
 						member.User(bureauFromUser),
 						member.User(bureauToUser),
+						account.Asset(bureauAsset),
 						bureauAmount,
 					)
 				},
 			)
 		},
 	}
+
+	bureauDelegateCmd = &cobra.Command{
+		Use:   "delegate",
+		Short: "Make a request to delegate your voting power to another member",
+		Long:  ``,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke(
+				func() {
+					LoadConfig()
+					bureau.Delegate(
+						ctx,
+						setup.Member,
+						setup.Gov,
+						member.User(bureauFromUser),
+						member.User(bureauToUser),
+						purpose.Purpose(bureauPurpose),
+						bureauRevoke,
+					)
+				},
+			)
+		},
+	}
+
+	bureauEscrowCmd = &cobra.Command{
+		Use:   "escrow",
+		Short: "Make a request to hold a transfer in escrow, until a date or until a motion is accepted",
+		Long:  ``,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke1(
+				func() any {
+					LoadConfig()
+					return bureau.Escrow(
+						ctx,
+						setup.Member,
+						setup.Gov,
+						bureau.EscrowRequest{
+							ID:        bureau.EscrowID(bureauEscrowID),
+							FromUser:  member.User(bureauFromUser),
+							ToUser:    member.User(bureauToUser),
+							Asset:     account.Asset(bureauAsset),
+							Amount:    bureauAmount,
+							ReleaseAt: optionalTime(parseOptionalTime(ctx, bureauReleaseAt)),
+							Motion:    motionproto.MotionID(bureauMotion),
+							Revocable: bureauRevocable,
+						},
+					).Query
+				},
+			)
+		},
+	}
+
+	bureauSettleCmd = &cobra.Command{
+		Use:   "settle",
+		Short: "Make a request to release one of your escrows early, or to revoke it",
+		Long:  ``,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke(
+				func() {
+					LoadConfig()
+					bureau.Settle(
+						ctx,
+						setup.Member,
+						setup.Gov,
+						member.User(bureauFromUser),
+						bureau.EscrowID(bureauEscrowID),
+						bureauRevoke,
+					)
+				},
+			)
+		},
+	}
+
+	bureauEscrowsCmd = &cobra.Command{
+		Use:   "escrows",
+		Short: "List escrows held by the bureau",
+		Long:  ``,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke1(
+				func() any {
+					LoadConfig()
+					return bureau.ListEscrows(
+						ctx,
+						setup.Gov,
+					)
+				},
+			)
+		},
+	}
+
+	bureauOpenCmd = &cobra.Command{
+		Use:   "open",
+		Short: "Make a request to open a motion",
+		Long:  ``,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke1(
+				func() any {
+					LoadConfig()
+					return bureau.Open(
+						ctx,
+						setup.Member,
+						setup.Gov,
+						bureau.OpenRequest{
+							Author: member.User(bureauFromUser),
+							ID:     motionproto.MotionID(bureauMotion),
+							Type:   motionproto.ParseMotionType(ctx, bureauMotionType),
+							Policy: motion.PolicyName(bureauMotionPolicy),
+							Title:  bureauMotionTitle,
+							Body:   bureauMotionBody,
+							Labels: bureauMotionLabels,
+						},
+					).Query
+				},
+			)
+		},
+	}
+
+	bureauRefCmd = &cobra.Command{
+		Use:   "ref",
+		Short: "Make a request to add or remove a reference from one of your motions to another motion",
+		Long:  ``,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke(
+				func() {
+					LoadConfig()
+					bureau.Ref(
+						ctx,
+						setup.Member,
+						setup.Gov,
+						member.User(bureauFromUser),
+						motionproto.MotionID(bureauRefFrom),
+						motionproto.MotionID(bureauRefTo),
+						motionproto.RefType(bureauRefType),
+						bureauRevoke,
+					)
+				},
+			)
+		},
+	}
+
+	bureauTipCmd = &cobra.Command{
+		Use:   "tip",
+		Short: "Make a request to tip another member, with a memo",
+		Long:  ``,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke(
+				func() {
+					LoadConfig()
+					bureau.Tip(
+						ctx,
+						setup.Member,
+						setup.Gov,
+						member.User(bureauFromUser),
+						member.User(bureauToUser),
+						account.Asset(bureauAsset),
+						bureauAmount,
+						bureauMemo,
+					)
+				},
+			)
+		},
+	}
+
+	bureauStatusCmd = &cobra.Command{
+		Use:   "status",
+		Short: "Show the outcome of your requests to the community",
+		Long:  ``,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke1(
+				func() any {
+					LoadConfig()
+					return bureau.Status(
+						ctx,
+						setup.Member.Public,
+						setup.Gov,
+					)
+				},
+			)
+		},
+	}
 )
 
 var (
 	bureauGroup    string
 	bureauFromUser string
 	bureauToUser   string
+	bureauAsset    string
 	bureauAmount   float64
+	bureauPurpose  string
+	bureauRevoke   bool
+
+	bureauEscrowID  string
+	bureauReleaseAt string
+	bureauMotion    string
+	bureauRevocable bool
+
+	bureauMotionType   string
+	bureauMotionPolicy string
+	bureauMotionTitle  string
+	bureauMotionBody   string
+	bureauMotionLabels []string
+	bureauRefFrom      string
+	bureauRefTo        string
+	bureauRefType      string
+	bureauMemo         string
 )
 
 func init() {
@@ -70,6 +274,66 @@ func init() {
 	bureauCmd.AddCommand(bureauTransferCmd)
 	bureauTransferCmd.Flags().StringVar(&bureauFromUser, "from", "", "transfer from user")
 	bureauTransferCmd.Flags().StringVar(&bureauToUser, "to", "", "transfer to user")
+	bureauTransferCmd.Flags().StringVar(&bureauAsset, "asset", "", "asset to transfer (plural credit, if empty)")
 	bureauTransferCmd.Flags().Float64Var(&bureauAmount, "amount", 0, "transfer amount")
 	bureauTransferCmd.MarkFlagRequired("amount")
+
+	bureauCmd.AddCommand(bureauDelegateCmd)
+	bureauDelegateCmd.Flags().StringVar(&bureauFromUser, "from", "", "delegating user")
+	bureauDelegateCmd.Flags().StringVar(&bureauToUser, "to", "", "delegate user")
+	bureauDelegateCmd.Flags().StringVar(&bureauPurpose, "purpose", "", "purpose of ballots to delegate (all purposes, if empty)")
+	bureauDelegateCmd.Flags().BoolVar(&bureauRevoke, "revoke", false, "revoke the delegation for the given purpose")
+	bureauDelegateCmd.MarkFlagsMutuallyExclusive("to", "revoke")
+
+	bureauCmd.AddCommand(bureauEscrowCmd)
+	bureauEscrowCmd.Flags().StringVar(&bureauEscrowID, "id", "", "escrow id (random, if empty)")
+	bureauEscrowCmd.Flags().StringVar(&bureauFromUser, "from", "", "escrow from user")
+	bureauEscrowCmd.Flags().StringVar(&bureauToUser, "to", "", "recipient user")
+	bureauEscrowCmd.Flags().StringVar(&bureauAsset, "asset", "", "asset to escrow (plural credit, if empty)")
+	bureauEscrowCmd.Flags().Float64Var(&bureauAmount, "amount", 0, "escrow amount")
+	bureauEscrowCmd.Flags().StringVar(&bureauReleaseAt, "release-at", "", "release no earlier than this time (RFC3339)")
+	bureauEscrowCmd.Flags().StringVar(&bureauMotion, "motion", "", "release only when this motion closes as accepted")
+	bureauEscrowCmd.Flags().BoolVar(&bureauRevocable, "revocable", false, "allow the escrow to be revoked before release")
+	bureauEscrowCmd.MarkFlagRequired("to")
+	bureauEscrowCmd.MarkFlagRequired("amount")
+
+	bureauCmd.AddCommand(bureauSettleCmd)
+	bureauSettleCmd.Flags().StringVar(&bureauFromUser, "from", "", "escrowing user")
+	bureauSettleCmd.Flags().StringVar(&bureauEscrowID, "id", "", "escrow id")
+	bureauSettleCmd.Flags().BoolVar(&bureauRevoke, "revoke", false, "refund the escrow instead of releasing it")
+	bureauSettleCmd.MarkFlagRequired("id")
+
+	bureauCmd.AddCommand(bureauEscrowsCmd)
+
+	bureauCmd.AddCommand(bureauOpenCmd)
+	bureauOpenCmd.Flags().StringVar(&bureauFromUser, "author", "", "author user")
+	bureauOpenCmd.Flags().StringVar(&bureauMotion, "id", "", "motion id, starting with \"b\" (random, if empty)")
+	bureauOpenCmd.Flags().StringVar(&bureauMotionType, "type", "concern", "type of motion (concern, proposal)")
+	bureauOpenCmd.Flags().StringVar(&bureauMotionPolicy, "policy", "", "policy ("+strings.Join(motionproto.InstalledPolicyKeys(), ", ")+")")
+	bureauOpenCmd.Flags().StringVar(&bureauMotionTitle, "title", "", "title for motion")
+	bureauOpenCmd.Flags().StringVar(&bureauMotionBody, "desc", "", "description for motion")
+	bureauOpenCmd.Flags().StringSliceVar(&bureauMotionLabels, "labels", nil, "labels for motion")
+	bureauOpenCmd.MarkFlagRequired("policy")
+	bureauOpenCmd.MarkFlagRequired("title")
+
+	bureauCmd.AddCommand(bureauRefCmd)
+	bureauRefCmd.Flags().StringVar(&bureauFromUser, "user", "", "requesting user")
+	bureauRefCmd.Flags().StringVar(&bureauRefFrom, "from", "", "referring motion")
+	bureauRefCmd.Flags().StringVar(&bureauRefTo, "to", "", "referenced motion")
+	bureauRefCmd.Flags().StringVar(&bureauRefType, "type", "", "reference type")
+	bureauRefCmd.Flags().BoolVar(&bureauRevoke, "remove", false, "remove the reference")
+	bureauRefCmd.MarkFlagRequired("from")
+	bureauRefCmd.MarkFlagRequired("to")
+	bureauRefCmd.MarkFlagRequired("type")
+
+	bureauCmd.AddCommand(bureauTipCmd)
+	bureauTipCmd.Flags().StringVar(&bureauFromUser, "from", "", "tipping user")
+	bureauTipCmd.Flags().StringVar(&bureauToUser, "to", "", "tipped user")
+	bureauTipCmd.Flags().StringVar(&bureauAsset, "asset", "", "asset to tip (plural credit, if empty)")
+	bureauTipCmd.Flags().Float64Var(&bureauAmount, "amount", 0, "tip amount")
+	bureauTipCmd.Flags().StringVar(&bureauMemo, "memo", "", "memo to the tipped user")
+	bureauTipCmd.MarkFlagRequired("to")
+	bureauTipCmd.MarkFlagRequired("amount")
+
+	bureauCmd.AddCommand(bureauStatusCmd)
 }
@@ -3,10 +3,13 @@ package cmd
 import (
 	"time"
 
+	"github.com/gov4git/gov4git/v2/gitea"
 	"github.com/gov4git/gov4git/v2/github"
 	govgh "github.com/gov4git/gov4git/v2/github"
 	"github.com/gov4git/gov4git/v2/gov4git/api"
 	"github.com/gov4git/gov4git/v2/proto/cron"
+	"github.com/gov4git/gov4git/v2/tracker"
+	"github.com/gov4git/lib4git/must"
 	"github.com/spf13/cobra"
 )
 
@@ -19,20 +22,20 @@ This command is intended as a target for a cronjob which runs every couple of mi
 It will ensure that:
 - Governance is synchronized with the issues and pull requests of a GitHub project at a configurable frequency, and
 - Votes from community members are incorporated in governance ballots at a configurable frequency.
+
+Projects hosted on Gitea (or Forgejo) are supported with --tracker=gitea and --tracker_url=GITEA_BASE_URL.
+Communities without an issue tracker use --tracker=none, in which case motion notices are written to motion threads in the governance repo.
 `,
 		Run: func(cmd *cobra.Command, args []string) {
 			api.Invoke1(
 				func() any {
 					LoadConfig()
-					repo := govgh.ParseRepo(ctx, githubProject)
-					govgh.SetTokenSource(ctx, repo, govgh.MakeStaticTokenSource(ctx, githubToken))
-					ghc := govgh.GetGithubClient(ctx, repo)
 					result := cron.Cron(
 						ctx,
-						repo,
-						ghc,
+						cronTracker(),
 						setup.Organizer,
 						time.Duration(cronGithubFreqSeconds)*time.Second,
+						time.Duration(cronFullSyncFreqSeconds)*time.Second,
 						time.Duration(cronCommunityFreqSeconds)*time.Second,
 						syncFetchPar,
 					)
@@ -43,20 +46,42 @@ It will ensure that:
 	}
 )
 
+func cronTracker() tracker.Tracker {
+	if cronTrackerKind == "none" {
+		return nil
+	}
+	must.Assertf(ctx, githubProject != "" && githubToken != "", "issue tracker requires a project and a token")
+	switch cronTrackerKind {
+	case "github":
+		repo := govgh.ParseRepo(ctx, githubProject)
+		govgh.SetTokenSource(ctx, repo, govgh.MakeStaticTokenSource(ctx, githubToken))
+		return govgh.NewTracker(ctx, repo, govgh.GetGithubClient(ctx, repo))
+	case "gitea":
+		must.Assertf(ctx, cronTrackerURL != "", "gitea tracker requires a base url")
+		return gitea.NewTracker(gitea.ParseRepo(ctx, cronTrackerURL, githubProject), githubToken, nil)
+	}
+	must.Errorf(ctx, "unknown issue tracker %q", cronTrackerKind)
+	return nil
+}
+
 var (
 	cronGithubFreqSeconds    int
+	cronFullSyncFreqSeconds  int
 	cronCommunityFreqSeconds int
+	cronTrackerKind          string
+	cronTrackerURL           string
 )
 
 func init() {
 	cronCmd.Flags().StringVar(&githubProject, "project", "", "GitHub project owner/repo")
 	cronCmd.Flags().StringVar(&githubToken, "token", "", "GitHub access token")
+	cronCmd.Flags().StringVar(&cronTrackerKind, "tracker", "github", "issue tracker hosting the project: github, gitea or none")
+	cronCmd.Flags().StringVar(&cronTrackerURL, "tracker_url", "", "base URL of the issue tracker (for gitea)")
 	cronCmd.Flags().IntVar(&cronGithubFreqSeconds, "github_freq", github.DefaultGithubFreq, "frequency of GitHub import, in seconds")
+	cronCmd.Flags().IntVar(&cronFullSyncFreqSeconds, "full_sync_freq", github.DefaultFullSyncFreq, "frequency of full GitHub reconciliation, in seconds; imports in between only fetch updated issues")
 	cronCmd.Flags().IntVar(&cronCommunityFreqSeconds, "community_freq", github.DefaultCommunityFreq, "frequency of community tallies, in seconds")
 	cronCmd.Flags().IntVar(&syncFetchPar, "fetch_par", github.DefaultFetchParallelism, "parallelism while clonging member repos for vote collection")
 
-	cronCmd.MarkFlagRequired("project")
-	cronCmd.MarkFlagRequired("token")
 	cronCmd.MarkFlagRequired("github_freq")
 	cronCmd.MarkFlagRequired("community_freq")
 	cronCmd.MarkFlagRequired("fetch_par")
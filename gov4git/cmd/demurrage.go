@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/gov4git/gov4git/v2/gov4git/api"
+	"github.com/gov4git/gov4git/v2/proto/account"
+	"github.com/gov4git/gov4git/v2/proto/demurrage"
+	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/spf13/cobra"
+)
+
+var (
+	demurrageCmd = &cobra.Command{
+		Use:   "demurrage",
+		Short: "Manage policies decaying idle balances",
+		Long:  ``,
+		Run:   func(cmd *cobra.Command, args []string) {},
+	}
+
+	demurrageSetCmd = &cobra.Command{
+		Use:   "set",
+		Short: "Set the demurrage policy of an asset",
+		Long:  ``,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke(
+				func() {
+					LoadConfig()
+					demurrage.Set(
+						ctx,
+						setup.Gov,
+						demurrage.Policy{
+							Asset:       account.Asset(demurrageAsset),
+							Group:       member.Group(demurrageGroup),
+							Rate:        demurrageRate,
+							Period:      demurragePeriod,
+							Threshold:   account.Q(demurrageThreshold),
+							Destination: account.AccountID(demurrageDestination),
+						},
+					)
+				},
+			)
+		},
+	}
+
+	demurrageRemoveCmd = &cobra.Command{
+		Use:   "remove",
+		Short: "Remove the demurrage policy of an asset",
+		Long:  ``,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke(
+				func() {
+					LoadConfig()
+					demurrage.Remove(
+						ctx,
+						setup.Gov,
+						account.Asset(demurrageAsset),
+					)
+				},
+			)
+		},
+	}
+
+	demurrageListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List demurrage policies",
+		Long:  ``,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke1(
+				func() any {
+					LoadConfig()
+					return demurrage.List(
+						ctx,
+						setup.Gov,
+					)
+				},
+			)
+		},
+	}
+
+	demurrageApplyCmd = &cobra.Command{
+		Use:   "apply",
+		Short: "Charge the demurrage due under all policies",
+		Long:  ``,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke1(
+				func() any {
+					LoadConfig()
+					return demurrage.Apply(
+						ctx,
+						setup.Gov,
+						time.Now(),
+					).Result
+				},
+			)
+		},
+	}
+)
+
+var (
+	demurrageAsset       string
+	demurrageGroup       string
+	demurrageRate        float64
+	demurragePeriod      time.Duration
+	demurrageThreshold   float64
+	demurrageDestination string
+)
+
+func init() {
+	demurrageCmd.AddCommand(demurrageSetCmd)
+	demurrageSetCmd.Flags().StringVarP(&demurrageAsset, "asset", "a", string(account.PluralAsset), "asset")
+	demurrageSetCmd.Flags().StringVar(&demurrageGroup, "group", string(member.Everybody), "group whose member balances decay")
+	demurrageSetCmd.Flags().Float64Var(&demurrageRate, "rate", 0.0, "fraction of the balance above the threshold that decays per period")
+	demurrageSetCmd.MarkFlagRequired("rate")
+	demurrageSetCmd.Flags().DurationVar(&demurragePeriod, "period", 7*24*time.Hour, "demurrage period")
+	demurrageSetCmd.Flags().Float64Var(&demurrageThreshold, "threshold", 0.0, "balances up to this threshold do not decay")
+	demurrageSetCmd.Flags().StringVar(&demurrageDestination, "to", "", "account receiving decayed balances")
+	demurrageSetCmd.MarkFlagRequired("to")
+
+	demurrageCmd.AddCommand(demurrageRemoveCmd)
+	demurrageRemoveCmd.Flags().StringVarP(&demurrageAsset, "asset", "a", string(account.PluralAsset), "asset")
+
+	demurrageCmd.AddCommand(demurrageListCmd)
+	demurrageCmd.AddCommand(demurrageApplyCmd)
+}
@@ -1,9 +1,12 @@
 package cmd
 
 import (
+	"net/http"
+
 	govgh "github.com/gov4git/gov4git/v2/github"
 	"github.com/gov4git/gov4git/v2/github/deploy/tools"
 	"github.com/gov4git/gov4git/v2/gov4git/api"
+	"github.com/gov4git/lib4git/base"
 	"github.com/gov4git/lib4git/must"
 	"github.com/gov4git/vendor4git/github"
 	"github.com/spf13/cobra"
@@ -129,6 +132,40 @@ This creates a public repo. Adding the flag --private will result in creating a
 			)
 		},
 	}
+
+	githubWebhookCmd = &cobra.Command{
+		Use:   "webhook",
+		Short: "Receive GitHub webhook events and sync the affected issues with governance",
+		Long: `Example usage:
+
+	gov4git github webhook \
+		--token=GITHUB_ACCESS_TOKEN \
+		--project=PROJECT_OWNER/PROJECT_REPO \
+		--secret=WEBHOOK_SECRET \
+		--addr=:8081
+
+The project's webhook should deliver issues, pull_request, issue_comment and label events as JSON,
+signed with WEBHOOK_SECRET. Cron remains responsible for tallies and notices, and for periodic full syncs.
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke(
+				func() {
+					LoadConfig()
+					repo := govgh.ParseRepo(ctx, githubProject)
+					govgh.SetTokenSource(ctx, repo, govgh.MakeStaticTokenSource(ctx, githubToken))
+					tr := govgh.NewTracker(ctx, repo, govgh.GetGithubClient(ctx, repo))
+					h := govgh.NewWebhookHandler(ctx, tr, setup.Organizer, []byte(githubWebhookSecret))
+					base.Infof("receiving github webhook events at %v", githubWebhookAddr)
+					must.NoError(ctx, http.ListenAndServe(githubWebhookAddr, h))
+				},
+			)
+		},
+	}
+)
+
+var (
+	githubWebhookSecret string
+	githubWebhookAddr   string
 )
 
 var (
@@ -172,4 +209,13 @@ func init() {
 	githubClearCommentsCmd.MarkFlagRequired("project")
 	githubClearCommentsCmd.MarkFlagRequired("issue")
 
+	githubCmd.AddCommand(githubWebhookCmd)
+	githubWebhookCmd.Flags().StringVar(&githubToken, "token", "", "GitHub access token")
+	githubWebhookCmd.Flags().StringVar(&githubProject, "project", "", "GitHub project owner/repo")
+	githubWebhookCmd.Flags().StringVar(&githubWebhookSecret, "secret", "", "secret used to sign webhook deliveries")
+	githubWebhookCmd.Flags().StringVar(&githubWebhookAddr, "addr", ":8081", "address to listen on")
+	githubWebhookCmd.MarkFlagRequired("token")
+	githubWebhookCmd.MarkFlagRequired("project")
+	githubWebhookCmd.MarkFlagRequired("secret")
+
 }
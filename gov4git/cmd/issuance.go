@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/gov4git/gov4git/v2/gov4git/api"
+	"github.com/gov4git/gov4git/v2/proto/account"
+	"github.com/gov4git/gov4git/v2/proto/issuance"
+	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/spf13/cobra"
+)
+
+var (
+	issuanceCmd = &cobra.Command{
+		Use:   "issuance",
+		Short: "Manage recurring issuance schedules",
+		Long:  ``,
+		Run:   func(cmd *cobra.Command, args []string) {},
+	}
+
+	issuanceCreateCmd = &cobra.Command{
+		Use:   "create",
+		Short: "Create a schedule issuing an asset to every member of a group, once per period",
+		Long:  ``,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke(
+				func() {
+					LoadConfig()
+					var maxBalance *account.Quantity
+					if cmd.Flags().Changed("max_balance") {
+						q := account.Q(issuanceMaxBalance)
+						maxBalance = &q
+					}
+					issuance.Create(
+						ctx,
+						setup.Gov,
+						issuance.Schedule{
+							ID:         issuance.ScheduleID(issuanceID),
+							Group:      member.Group(issuanceGroup),
+							Amount:     account.H(account.Asset(issuanceAsset), issuanceQuantity),
+							Period:     issuancePeriod,
+							Start:      optionalTime(parseOptionalTime(ctx, issuanceStart)),
+							MaxBalance: maxBalance,
+							Note:       issuanceNote,
+						},
+					)
+				},
+			)
+		},
+	}
+
+	issuanceRemoveCmd = &cobra.Command{
+		Use:   "remove",
+		Short: "Remove an issuance schedule",
+		Long:  ``,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke(
+				func() {
+					LoadConfig()
+					issuance.Remove(
+						ctx,
+						setup.Gov,
+						issuance.ScheduleID(issuanceID),
+					)
+				},
+			)
+		},
+	}
+
+	issuanceListCmd = &cobra.Command{
+		Use:   "list",
+		Short: "List issuance schedules",
+		Long:  ``,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke1(
+				func() any {
+					LoadConfig()
+					return issuance.List(
+						ctx,
+						setup.Gov,
+					)
+				},
+			)
+		},
+	}
+
+	issuanceRunCmd = &cobra.Command{
+		Use:   "run",
+		Short: "Issue the current period of all schedules that have not issued it yet",
+		Long:  ``,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke1(
+				func() any {
+					LoadConfig()
+					return issuance.Run(
+						ctx,
+						setup.Gov,
+						time.Now(),
+					).Result
+				},
+			)
+		},
+	}
+)
+
+var (
+	issuanceID         string
+	issuanceGroup      string
+	issuanceAsset      string
+	issuanceQuantity   float64
+	issuancePeriod     time.Duration
+	issuanceStart      string
+	issuanceMaxBalance float64
+	issuanceNote       string
+)
+
+func init() {
+	issuanceCmd.AddCommand(issuanceCreateCmd)
+	issuanceCreateCmd.Flags().StringVar(&issuanceID, "id", "", "schedule id")
+	issuanceCreateCmd.MarkFlagRequired("id")
+	issuanceCreateCmd.Flags().StringVar(&issuanceGroup, "group", "", "group whose members are issued")
+	issuanceCreateCmd.MarkFlagRequired("group")
+	issuanceCreateCmd.Flags().StringVarP(&issuanceAsset, "asset", "a", string(account.PluralAsset), "asset")
+	issuanceCreateCmd.Flags().Float64VarP(&issuanceQuantity, "quantity", "q", 0.0, "quantity issued to each member per period")
+	issuanceCreateCmd.MarkFlagRequired("quantity")
+	issuanceCreateCmd.Flags().DurationVar(&issuancePeriod, "period", 7*24*time.Hour, "issuance period")
+	issuanceCreateCmd.Flags().StringVar(&issuanceStart, "start", "", "start of the first period (RFC3339; now, if empty)")
+	issuanceCreateCmd.Flags().Float64Var(&issuanceMaxBalance, "max_balance", 0.0, "issue only up to this balance")
+	issuanceCreateCmd.Flags().StringVarP(&issuanceNote, "note", "n", "", "note")
+
+	issuanceCmd.AddCommand(issuanceRemoveCmd)
+	issuanceRemoveCmd.Flags().StringVar(&issuanceID, "id", "", "schedule id")
+	issuanceRemoveCmd.MarkFlagRequired("id")
+
+	issuanceCmd.AddCommand(issuanceListCmd)
+	issuanceCmd.AddCommand(issuanceRunCmd)
+}
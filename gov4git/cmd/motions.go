@@ -1,13 +1,17 @@
 package cmd
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/gov4git/gov4git/v2/gov4git/api"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotproto"
 	"github.com/gov4git/gov4git/v2/proto/member"
 	"github.com/gov4git/gov4git/v2/proto/motion"
 	"github.com/gov4git/gov4git/v2/proto/motion/motionapi"
+	"github.com/gov4git/gov4git/v2/proto/motion/motionpolicies/pmp_1/concern"
 	"github.com/gov4git/gov4git/v2/proto/motion/motionproto"
+	"github.com/gov4git/lib4git/form"
 	"github.com/spf13/cobra"
 )
 
@@ -44,6 +48,138 @@ var (
 		},
 	}
 
+	motionEditCmd = &cobra.Command{
+		Use:   "edit",
+		Short: "Edit the author, title, description or tracking URL of a motion",
+		Long:  `Only the fields given as flags are changed.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke1(
+				func() any {
+					LoadConfig()
+					m := motionapi.LookupMotion(ctx, setup.Gov, motionproto.MotionID(motionName))
+					if cmd.Flags().Changed("author") {
+						m.Author = member.User(motionAuthor)
+					}
+					if cmd.Flags().Changed("title") {
+						m.Title = motionTitle
+					}
+					if cmd.Flags().Changed("desc") {
+						m.Body = motionDesc
+					}
+					if cmd.Flags().Changed("tracking") {
+						m.TrackerURL = motionTrackerURL
+					}
+					return motionapi.EditMotion(
+						ctx,
+						setup.Organizer,
+						m.ID,
+						m.Author,
+						m.Title,
+						m.Body,
+						m.TrackerURL,
+						m.Labels,
+					)
+				},
+			)
+		},
+	}
+
+	motionLinkCmd = &cobra.Command{
+		Use:   "link",
+		Short: "Add a reference from one motion to another",
+		Long:  ``,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke1(
+				func() any {
+					LoadConfig()
+					fromReport, fromNotices, toReport, toNotices := motionapi.LinkMotions(
+						ctx,
+						setup.Organizer,
+						motionproto.MotionID(motionFrom),
+						motionproto.MotionID(motionTo),
+						motionproto.RefType(motionRefType),
+					)
+					return form.Map{"from_report": fromReport, "from_notices": fromNotices, "to_report": toReport, "to_notices": toNotices}
+				},
+			)
+		},
+	}
+
+	motionUnlinkCmd = &cobra.Command{
+		Use:   "unlink",
+		Short: "Remove a reference from one motion to another",
+		Long:  ``,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke1(
+				func() any {
+					LoadConfig()
+					fromReport, fromNotices, toReport, toNotices := motionapi.UnlinkMotions(
+						ctx,
+						setup.Organizer,
+						motionproto.MotionID(motionFrom),
+						motionproto.MotionID(motionTo),
+						motionproto.RefType(motionRefType),
+					)
+					return form.Map{"from_report": fromReport, "from_notices": fromNotices, "to_report": toReport, "to_notices": toNotices}
+				},
+			)
+		},
+	}
+
+	motionLabelCmd = &cobra.Command{
+		Use:   "label",
+		Short: "Add or remove labels of a motion",
+		Long:  ``,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke1(
+				func() any {
+					LoadConfig()
+					return motionapi.LabelMotion(
+						ctx,
+						setup.Organizer,
+						motionproto.MotionID(motionName),
+						motionAddLabels,
+						motionRemoveLabels,
+					)
+				},
+			)
+		},
+	}
+
+	motionCommentCmd = &cobra.Command{
+		Use:   "comment",
+		Short: "Add a comment to the thread of a motion",
+		Long:  ``,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke1(
+				func() any {
+					LoadConfig()
+					return motionapi.CommentMotion(
+						ctx,
+						setup.Organizer,
+						motionproto.MotionID(motionName),
+						member.User(motionAuthor),
+						motionDesc,
+					)
+				},
+			)
+		},
+	}
+
+	motionThreadCmd = &cobra.Command{
+		Use:   "thread",
+		Short: "Show the thread of comments and notices of a motion",
+		Long:  ``,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke(
+				func() {
+					LoadConfig()
+					fmt.Print(motionapi.LoadMotionThread(ctx, setup.Gov, motionproto.MotionID(motionName)))
+				},
+			)
+		},
+	}
+
 	motionCloseCmd = &cobra.Command{
 		Use:   "close",
 		Short: "Close a motion",
@@ -117,6 +253,24 @@ var (
 			)
 		},
 	}
+
+	motionPriorityPollCmd = &cobra.Command{
+		Use:   "priority-poll",
+		Short: "Set the ballot policy of priority polls of newly opened concerns (PMP v1)",
+		Long:  ``,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke(
+				func() {
+					LoadConfig()
+					concern.SetPriorityPollPolicy(
+						ctx,
+						setup.Organizer,
+						ballotproto.PolicyName(motionPollPolicy),
+					)
+				},
+			)
+		},
+	}
 )
 
 var (
@@ -129,6 +283,13 @@ var (
 	motionTrackerURL string
 	motionAccept     bool
 	motionTrack      bool
+	motionPollPolicy string
+
+	motionFrom         string
+	motionTo           string
+	motionRefType      string
+	motionAddLabels    []string
+	motionRemoveLabels []string
 )
 
 func init() {
@@ -145,6 +306,47 @@ func init() {
 	motionOpenCmd.Flags().StringVar(&motionType, "type", "concern", "type of motion (concern, proposal)")
 	motionOpenCmd.Flags().StringVar(&motionTrackerURL, "tracking", "", "tracking URL for motion")
 
+	motionCmd.AddCommand(motionEditCmd)
+	motionEditCmd.Flags().StringVar(&motionName, "name", "", "name of motion")
+	motionEditCmd.MarkFlagRequired("name")
+	motionEditCmd.Flags().StringVar(&motionAuthor, "author", "", "author user name")
+	motionEditCmd.Flags().StringVar(&motionTitle, "title", "", "title for motion")
+	motionEditCmd.Flags().StringVar(&motionDesc, "desc", "", "description for motion")
+	motionEditCmd.Flags().StringVar(&motionTrackerURL, "tracking", "", "tracking URL for motion")
+
+	motionCmd.AddCommand(motionLinkCmd)
+	motionLinkCmd.Flags().StringVar(&motionFrom, "from", "", "name of referring motion")
+	motionLinkCmd.MarkFlagRequired("from")
+	motionLinkCmd.Flags().StringVar(&motionTo, "to", "", "name of referenced motion")
+	motionLinkCmd.MarkFlagRequired("to")
+	motionLinkCmd.Flags().StringVar(&motionRefType, "type", "", "reference type")
+	motionLinkCmd.MarkFlagRequired("type")
+
+	motionCmd.AddCommand(motionUnlinkCmd)
+	motionUnlinkCmd.Flags().StringVar(&motionFrom, "from", "", "name of referring motion")
+	motionUnlinkCmd.MarkFlagRequired("from")
+	motionUnlinkCmd.Flags().StringVar(&motionTo, "to", "", "name of referenced motion")
+	motionUnlinkCmd.MarkFlagRequired("to")
+	motionUnlinkCmd.Flags().StringVar(&motionRefType, "type", "", "reference type")
+	motionUnlinkCmd.MarkFlagRequired("type")
+
+	motionCmd.AddCommand(motionLabelCmd)
+	motionLabelCmd.Flags().StringVar(&motionName, "name", "", "name of motion")
+	motionLabelCmd.MarkFlagRequired("name")
+	motionLabelCmd.Flags().StringSliceVar(&motionAddLabels, "add", nil, "labels to add")
+	motionLabelCmd.Flags().StringSliceVar(&motionRemoveLabels, "remove", nil, "labels to remove")
+
+	motionCmd.AddCommand(motionCommentCmd)
+	motionCommentCmd.Flags().StringVar(&motionName, "name", "", "name of motion")
+	motionCommentCmd.MarkFlagRequired("name")
+	motionCommentCmd.Flags().StringVar(&motionAuthor, "author", "", "author user name")
+	motionCommentCmd.Flags().StringVar(&motionDesc, "body", "", "comment body (markdown)")
+	motionCommentCmd.MarkFlagRequired("body")
+
+	motionCmd.AddCommand(motionThreadCmd)
+	motionThreadCmd.Flags().StringVar(&motionName, "name", "", "name of motion")
+	motionThreadCmd.MarkFlagRequired("name")
+
 	motionCmd.AddCommand(motionCloseCmd)
 	motionCloseCmd.Flags().StringVar(&motionName, "name", "", "name of motion")
 	motionCloseCmd.MarkFlagRequired("name")
@@ -159,4 +361,16 @@ func init() {
 	motionShowCmd.Flags().BoolVar(&motionTrack, "track", false, "include this voter's tracking info")
 
 	motionCmd.AddCommand(motionPoliciesCmd)
+
+	motionCmd.AddCommand(motionPriorityPollCmd)
+	motionPriorityPollCmd.Flags().StringVar(&motionPollPolicy, "policy", "", "priority poll policy ("+strings.Join(priorityPollPolicyNames(), ", ")+")")
+	motionPriorityPollCmd.MarkFlagRequired("policy")
+}
+
+func priorityPollPolicyNames() []string {
+	names := make([]string, len(concern.PriorityPollPolicies))
+	for i, p := range concern.PriorityPollPolicies {
+		names[i] = p.String()
+	}
+	return names
 }
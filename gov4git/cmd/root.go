@@ -10,6 +10,7 @@ import (
 	gov4git "github.com/gov4git/gov4git/v2"
 	"github.com/gov4git/gov4git/v2/github"
 	"github.com/gov4git/gov4git/v2/gov4git/api"
+	"github.com/gov4git/gov4git/v2/proto/member"
 	_ "github.com/gov4git/gov4git/v2/runtime"
 	"github.com/gov4git/lib4git/base"
 	"github.com/gov4git/lib4git/form"
@@ -49,6 +50,8 @@ func init() {
 	rootCmd.AddCommand(memberCmd)
 	rootCmd.AddCommand(ballotCmd)
 	rootCmd.AddCommand(accountCmd)
+	rootCmd.AddCommand(issuanceCmd)
+	rootCmd.AddCommand(demurrageCmd)
 	rootCmd.AddCommand(bureauCmd)
 	rootCmd.AddCommand(syncCmd)
 	rootCmd.AddCommand(cronCmd)
@@ -58,6 +61,7 @@ func init() {
 	rootCmd.AddCommand(motionCmd)
 	rootCmd.AddCommand(etcCmd)
 	rootCmd.AddCommand(panoramaCmd)
+	rootCmd.AddCommand(serveCmd)
 }
 
 func initAfterFlags() {
@@ -93,7 +97,7 @@ func LoadConfig() {
 	}
 
 	if config.CacheDir != "" {
-		ctx = git.WithCache(ctx, config.CacheDir)
+		ctx = member.WithGitCache(ctx, config.CacheDir)
 	}
 
 	setup = config.Setup(ctx)
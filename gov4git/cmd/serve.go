@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"net/http"
+
+	"github.com/gov4git/gov4git/v2/gov4git/api"
+	"github.com/gov4git/gov4git/v2/gov4git/server"
+	"github.com/gov4git/lib4git/base"
+	"github.com/gov4git/lib4git/must"
+	"github.com/spf13/cobra"
+)
+
+var (
+	serveCmd = &cobra.Command{
+		Use:   "serve",
+		Short: "Serve the gov4git API over HTTP",
+		Long: `
+This command exposes the ballot, motion, account, member, panorama and bureau APIs as a JSON HTTP API.
+Write operations act on behalf of the organizer or the member in the configuration, and are performed one at a time.
+Requests must carry the token given with --token as a bearer token, and write requests must have JSON bodies.
+An OpenAPI description of the endpoints is served at /openapi.json.
+`,
+		Run: func(cmd *cobra.Command, args []string) {
+			api.Invoke(
+				func() {
+					LoadConfig()
+					srv := server.NewServer(ctx, setup, serveToken)
+					base.Infof("serving the gov4git api at %v", serveAddr)
+					must.NoError(ctx, http.ListenAndServe(serveAddr, srv.Handler()))
+				},
+			)
+		},
+	}
+)
+
+var (
+	serveAddr  string
+	serveToken string
+)
+
+func init() {
+	serveCmd.Flags().StringVar(&serveAddr, "addr", "localhost:8080", "address to listen on")
+	serveCmd.Flags().StringVar(&serveToken, "token", "", "bearer token required from clients")
+}
@@ -0,0 +1,284 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/gov4git/gov4git/v2/proto/account"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotapi"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotproto"
+	"github.com/gov4git/gov4git/v2/proto/bureau"
+	"github.com/gov4git/gov4git/v2/proto/id"
+	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/gov4git/v2/proto/motion"
+	"github.com/gov4git/gov4git/v2/proto/motion/motionapi"
+	"github.com/gov4git/gov4git/v2/proto/motion/motionproto"
+	"github.com/gov4git/gov4git/v2/proto/panorama"
+	"github.com/gov4git/lib4git/git"
+)
+
+// Endpoints lists the operations of the API.
+// Read endpoints work against a (cached) clone of the community's public repo.
+// Write endpoints act on behalf of the organizer or the member configured in the server's setup.
+var Endpoints = []Endpoint{
+	// ballots
+	Read("/v1/ballots", "List ballots", listBallots),
+	Read("/v1/ballot", "Show a ballot", showBallot),
+	Read("/v1/ballot/fetch_report", "Show why votes on a ballot were not received or not counted", ballotFetchReport),
+	Write("/v1/ballot/vote", "Vote on a ballot, on behalf of the member", voteBallot),
+	Write("/v1/ballot/tally", "Tally the votes on a ballot", tallyBallot),
+	// motions
+	Read("/v1/motions", "List motions", listMotions),
+	Read("/v1/motion", "Show a motion", showMotion),
+	Read("/v1/motion/thread", "Show the comment thread of a motion", showMotionThread),
+	Write("/v1/motion/open", "Open a motion", openMotion),
+	Write("/v1/motion/close", "Close a motion", closeMotion),
+	Write("/v1/motion/cancel", "Cancel a motion", cancelMotion),
+	Write("/v1/motion/comment", "Comment on a motion", commentMotion),
+	// accounts
+	Read("/v1/account", "Show an account", getAccount),
+	Read("/v1/account/statement", "Show the debits and credits of an account", accountStatement),
+	Write("/v1/account/issue", "Issue to an account", issueAccount),
+	Write("/v1/account/transfer", "Transfer between accounts", transferAccount),
+	// members
+	Read("/v1/members", "List the users in a group", listMembers),
+	Read("/v1/member", "Show a user", getMember),
+	Write("/v1/member/add", "Add a user to the community", addMember),
+	// panorama
+	Read("/v1/panorama", "Show the community from the member's perspective", showPanorama),
+	// bureau
+	Read("/v1/bureau/status", "Show the status of the member's bureau requests", bureauStatus),
+	Read("/v1/bureau/escrows", "List escrowed funds", listEscrows),
+	Write("/v1/bureau/transfer", "Request a transfer, on behalf of the member", bureauTransfer),
+	Write("/v1/bureau/process", "Process pending bureau requests", bureauProcess),
+}
+
+type Empty struct{}
+
+// ballots
+
+type ListBallotsRequest struct {
+	Open        bool        `json:"open"`
+	Closed      bool        `json:"closed"`
+	Frozen      bool        `json:"frozen"`
+	Participant member.User `json:"participant"`
+}
+
+func listBallots(ctx context.Context, s *Server, req ListBallotsRequest) ballotproto.Advertisements {
+	return ballotapi.ListFilter(ctx, s.setup.Gov, req.Open, req.Closed, req.Frozen, req.Participant)
+}
+
+type BallotRequest struct {
+	ID string `json:"id" required:"true"`
+}
+
+func showBallot(ctx context.Context, s *Server, req BallotRequest) ballotproto.AdTallyMargin {
+	return ballotapi.Show(ctx, s.setup.Gov, ballotproto.ParseBallotID(req.ID))
+}
+
+func ballotFetchReport(ctx context.Context, s *Server, req BallotRequest) ballotproto.FetchReport {
+	return ballotapi.LoadFetchReport(ctx, s.setup.Gov, ballotproto.ParseBallotID(req.ID))
+}
+
+type VoteRequest struct {
+	ID       string  `json:"id" required:"true"`
+	Choice   string  `json:"choice" required:"true"`
+	Strength float64 `json:"strength" required:"true"`
+}
+
+func voteBallot(ctx context.Context, s *Server, req VoteRequest) Empty {
+	ballotapi.Vote(ctx, s.setup.Member, s.setup.Gov, ballotproto.ParseBallotID(req.ID), ballotproto.OneElection(req.Choice, req.Strength))
+	return Empty{}
+}
+
+type TallyRequest struct {
+	ID          string `json:"id" required:"true"`
+	Parallelism int    `json:"parallelism"`
+}
+
+func tallyBallot(ctx context.Context, s *Server, req TallyRequest) ballotapi.TallyReport {
+	if req.Parallelism <= 0 {
+		req.Parallelism = 1
+	}
+	chg, report := ballotapi.TallyWithReport(ctx, s.setup.Organizer, ballotproto.ParseBallotID(req.ID), req.Parallelism)
+	return ballotapi.TallyReport{Tally: chg.Result, FetchReport: report}
+}
+
+// motions
+
+func listMotions(ctx context.Context, s *Server, _ Empty) motionproto.MotionViews {
+	return motionapi.ListMotionViews(ctx, s.setup.Gov)
+}
+
+type MotionRequest struct {
+	ID motionproto.MotionID `json:"id" required:"true"`
+}
+
+func showMotion(ctx context.Context, s *Server, req MotionRequest) motionproto.MotionView {
+	return motionapi.ShowMotion(ctx, s.setup.Gov, req.ID)
+}
+
+func showMotionThread(ctx context.Context, s *Server, req MotionRequest) string {
+	return motionapi.LoadMotionThread(ctx, s.setup.Gov, req.ID)
+}
+
+type OpenMotionRequest struct {
+	ID          motionproto.MotionID   `json:"id" required:"true"`
+	Type        motionproto.MotionType `json:"type" required:"true"`
+	Policy      motion.PolicyName      `json:"policy" required:"true"`
+	Author      member.User            `json:"author" required:"true"`
+	Title       string                 `json:"title" required:"true"`
+	Description string                 `json:"description"`
+	TrackerURL  string                 `json:"tracker_url"`
+	Labels      []string               `json:"labels"`
+}
+
+func openMotion(ctx context.Context, s *Server, req OpenMotionRequest) motionproto.MotionView {
+	motionapi.OpenMotion(ctx, s.setup.Organizer, req.ID, req.Type, req.Policy, req.Author, req.Title, req.Description, req.TrackerURL, req.Labels)
+	return motionapi.ShowMotion(ctx, s.setup.Gov, req.ID)
+}
+
+type CloseMotionRequest struct {
+	ID     motionproto.MotionID `json:"id" required:"true"`
+	Accept bool                 `json:"accept"`
+}
+
+func closeMotion(ctx context.Context, s *Server, req CloseMotionRequest) motionproto.MotionView {
+	d := motionproto.Reject
+	if req.Accept {
+		d = motionproto.Accept
+	}
+	motionapi.CloseMotion(ctx, s.setup.Organizer, req.ID, d)
+	return motionapi.ShowMotion(ctx, s.setup.Gov, req.ID)
+}
+
+func cancelMotion(ctx context.Context, s *Server, req MotionRequest) motionproto.MotionView {
+	motionapi.CancelMotion(ctx, s.setup.Organizer, req.ID)
+	return motionapi.ShowMotion(ctx, s.setup.Gov, req.ID)
+}
+
+type CommentMotionRequest struct {
+	ID     motionproto.MotionID `json:"id" required:"true"`
+	Author member.User          `json:"author" required:"true"`
+	Body   string               `json:"body" required:"true"`
+}
+
+func commentMotion(ctx context.Context, s *Server, req CommentMotionRequest) string {
+	motionapi.CommentMotion(ctx, s.setup.Organizer, req.ID, req.Author, req.Body)
+	return motionapi.LoadMotionThread(ctx, s.setup.Gov, req.ID)
+}
+
+// accounts
+
+type AccountRequest struct {
+	ID account.AccountID `json:"id" required:"true"`
+}
+
+func getAccount(ctx context.Context, s *Server, req AccountRequest) *account.Account {
+	return account.Get(ctx, s.setup.Gov, req.ID)
+}
+
+type AccountStatementRequest struct {
+	ID   account.AccountID `json:"id" required:"true"`
+	From time.Time         `json:"from"` // if zero, the statement starts with the first entry
+	To   time.Time         `json:"to"`   // if zero, the statement ends with the last entry
+}
+
+func accountStatement(ctx context.Context, s *Server, req AccountStatementRequest) account.AccountStatement {
+	return account.Statement(ctx, s.setup.Gov, req.ID, req.From, req.To)
+}
+
+type IssueRequest struct {
+	To       account.AccountID `json:"to" required:"true"`
+	Asset    account.Asset     `json:"asset" required:"true"`
+	Quantity float64           `json:"quantity" required:"true"`
+	Note     string            `json:"note"`
+}
+
+func issueAccount(ctx context.Context, s *Server, req IssueRequest) *account.Account {
+	account.Issue(ctx, s.setup.Gov, req.To, account.H(req.Asset, req.Quantity), req.Note)
+	return account.Get(ctx, s.setup.Gov, req.To)
+}
+
+type TransferRequest struct {
+	From     account.AccountID `json:"from" required:"true"`
+	To       account.AccountID `json:"to" required:"true"`
+	Asset    account.Asset     `json:"asset" required:"true"`
+	Quantity float64           `json:"quantity" required:"true"`
+	Note     string            `json:"note"`
+}
+
+func transferAccount(ctx context.Context, s *Server, req TransferRequest) *account.Account {
+	account.Transfer(ctx, s.setup.Gov, req.From, req.To, account.H(req.Asset, req.Quantity), req.Note)
+	return account.Get(ctx, s.setup.Gov, req.From)
+}
+
+// members
+
+type ListMembersRequest struct {
+	Group member.Group `json:"group"` // defaults to everybody
+}
+
+func listMembers(ctx context.Context, s *Server, req ListMembersRequest) []member.User {
+	if req.Group == "" {
+		req.Group = member.Everybody
+	}
+	return member.ListGroupUsers(ctx, s.setup.Gov, req.Group)
+}
+
+type MemberRequest struct {
+	User member.User `json:"user" required:"true"`
+}
+
+func getMember(ctx context.Context, s *Server, req MemberRequest) member.UserProfile {
+	return member.GetUser(ctx, s.setup.Gov, req.User)
+}
+
+type AddMemberRequest struct {
+	User         member.User `json:"user" required:"true"`
+	PublicURL    git.URL     `json:"public_url" required:"true"`
+	PublicBranch git.Branch  `json:"public_branch" required:"true"`
+}
+
+func addMember(ctx context.Context, s *Server, req AddMemberRequest) member.UserProfile {
+	member.AddUserByPublicAddress(ctx, s.setup.Gov, req.User, id.PublicAddress{Repo: req.PublicURL, Branch: req.PublicBranch})
+	return member.GetUser(ctx, s.setup.Gov, req.User)
+}
+
+// panorama
+
+func showPanorama(ctx context.Context, s *Server, _ Empty) *panorama.Panoramic {
+	return panorama.Panorama(ctx, s.setup.Gov, s.setup.Member)
+}
+
+// bureau
+
+func bureauStatus(ctx context.Context, s *Server, _ Empty) bureau.RequestStatuses {
+	return bureau.Status(ctx, s.setup.Member.Public, s.setup.Gov)
+}
+
+func listEscrows(ctx context.Context, s *Server, _ Empty) []bureau.EscrowState {
+	return bureau.ListEscrows(ctx, s.setup.Gov)
+}
+
+type BureauTransferRequest struct {
+	To     member.User   `json:"to" required:"true"`
+	Asset  account.Asset `json:"asset"` // defaults to the plural credit
+	Amount float64       `json:"amount" required:"true"`
+}
+
+func bureauTransfer(ctx context.Context, s *Server, req BureauTransferRequest) Empty {
+	bureau.Transfer(ctx, s.setup.Member, s.setup.Gov, "", req.To, req.Asset, req.Amount)
+	return Empty{}
+}
+
+type BureauProcessRequest struct {
+	Group member.Group `json:"group"` // defaults to everybody
+}
+
+func bureauProcess(ctx context.Context, s *Server, req BureauProcessRequest) bureau.ProcessedRequests {
+	if req.Group == "" {
+		req.Group = member.Everybody
+	}
+	return bureau.Process(ctx, s.setup.Organizer, req.Group).Result
+}
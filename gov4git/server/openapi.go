@@ -0,0 +1,195 @@
+package server
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"strings"
+
+	gov4git "github.com/gov4git/gov4git/v2"
+	"github.com/gov4git/gov4git/v2/proto/account"
+)
+
+// OpenAPI returns an OpenAPI 3 description of the endpoints, derived from their Go request and response types.
+func OpenAPI(endpoints []Endpoint) map[string]any {
+
+	g := &schemaGen{schemas: map[string]any{}}
+	paths := map[string]any{}
+	for _, e := range endpoints {
+		op := map[string]any{
+			"summary":     e.Summary,
+			"operationId": operationID(e),
+			"responses": map[string]any{
+				"200": g.response("success", e.Response),
+				"400": g.response("invalid request", nil),
+				"401": g.response("missing or invalid bearer token", nil),
+				"404": g.response("not found", nil),
+				"500": g.response("operation failed", nil),
+			},
+		}
+		if e.IsWrite() {
+			op["requestBody"] = map[string]any{
+				"required": true,
+				"content":  map[string]any{"application/json": map[string]any{"schema": g.schema(e.Request)}},
+			}
+		} else {
+			op["parameters"] = g.parameters(e.Request)
+		}
+		paths[e.Path] = map[string]any{strings.ToLower(e.Method): op}
+	}
+
+	return map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":   "gov4git",
+			"version": gov4git.Short(),
+		},
+		"paths": paths,
+		"components": map[string]any{
+			"schemas":         g.schemas,
+			"securitySchemes": map[string]any{"bearer": map[string]any{"type": "http", "scheme": "bearer"}},
+		},
+		"security": []any{map[string]any{"bearer": []any{}}},
+	}
+}
+
+func operationID(e Endpoint) string {
+	parts := strings.Split(strings.Trim(e.Path, "/"), "/")
+	return strings.Join(parts[1:], "_")
+}
+
+type schemaGen struct {
+	schemas map[string]any
+}
+
+// response describes a result envelope, whose returned value is of type t.
+func (g *schemaGen) response(desc string, t reflect.Type) map[string]any {
+	props := map[string]any{
+		"status": map[string]any{"type": "string"},
+		"msg":    map[string]any{"type": "string"},
+		"error":  map[string]any{},
+	}
+	if t != nil {
+		props["returned"] = g.schema(t)
+	}
+	return map[string]any{
+		"description": desc,
+		"content": map[string]any{
+			"application/json": map[string]any{
+				"schema": map[string]any{"type": "object", "properties": props},
+			},
+		},
+	}
+}
+
+func (g *schemaGen) parameters(t reflect.Type) []any {
+	params := []any{}
+	for _, f := range visibleFields(t) {
+		params = append(params, map[string]any{
+			"name":     jsonName(f),
+			"in":       "query",
+			"required": isRequired(f),
+			"schema":   g.schema(f.Type),
+		})
+	}
+	return params
+}
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	quantityType      = reflect.TypeOf(account.Quantity{})
+)
+
+func (g *schemaGen) schema(t reflect.Type) map[string]any {
+
+	switch {
+	case t == timeType:
+		return map[string]any{"type": "string", "format": "date-time"}
+	case t == quantityType:
+		return map[string]any{"type": "number"}
+	case t.Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(jsonMarshalerType):
+		return map[string]any{} // custom encoding, any value
+	case t.Implements(textMarshalerType):
+		return map[string]any{"type": "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		s := g.schema(t.Elem())
+		s["nullable"] = true
+		return s
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]any{"type": "string", "format": "byte"}
+		}
+		return map[string]any{"type": "array", "items": g.schema(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": g.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.structSchema(t)
+		}
+		name := schemaName(t)
+		if _, ok := g.schemas[name]; !ok {
+			g.schemas[name] = map[string]any{} // placeholder, in case of recursive types
+			g.schemas[name] = g.structSchema(t)
+		}
+		return map[string]any{"$ref": "#/components/schemas/" + name}
+	}
+	return map[string]any{} // interfaces and other kinds can hold any value
+}
+
+func (g *schemaGen) structSchema(t reflect.Type) map[string]any {
+	props := map[string]any{}
+	required := []string{}
+	for _, f := range visibleFields(t) {
+		props[jsonName(f)] = g.schema(f.Type)
+		if isRequired(f) {
+			required = append(required, jsonName(f))
+		}
+	}
+	s := map[string]any{"type": "object", "properties": props}
+	if len(required) > 0 {
+		s["required"] = required
+	}
+	return s
+}
+
+// visibleFields returns the fields of a struct type, which are encoded in JSON.
+func visibleFields(t reflect.Type) []reflect.StructField {
+	fields := []reflect.StructField{}
+	for _, f := range reflect.VisibleFields(t) {
+		if !f.IsExported() || f.Anonymous || jsonName(f) == "" {
+			continue
+		}
+		fields = append(fields, f)
+	}
+	return fields
+}
+
+var schemaNameRegexp = regexp.MustCompile(`[^a-zA-Z0-9\.\-_]+`)
+
+// schemaName returns a component name for a named type, e.g. motionproto.MotionView.
+// Type parameters of generic types are folded into the name.
+func schemaName(t reflect.Type) string {
+	pkg := t.PkgPath()
+	if i := strings.LastIndex(pkg, "/"); i >= 0 {
+		pkg = pkg[i+1:]
+	}
+	name := t.Name()
+	if pkg != "" {
+		name = pkg + "." + name
+	}
+	return strings.Trim(schemaNameRegexp.ReplaceAllString(name, "_"), "_")
+}
@@ -0,0 +1,291 @@
+// Package server exposes the gov4git APIs as a JSON HTTP service.
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gov4git/gov4git/v2/gov4git/api"
+	"github.com/gov4git/gov4git/v2/proto/account"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotproto"
+	"github.com/gov4git/gov4git/v2/proto/motion/motionproto"
+	"github.com/gov4git/lib4git/base"
+	"github.com/gov4git/lib4git/form"
+	"github.com/gov4git/lib4git/must"
+)
+
+// Server serves the endpoints of the gov4git HTTP API.
+// Read endpoints run concurrently, while write endpoints are serialized to avoid conflicting pushes to the community repo.
+// Every endpoint, except for the OpenAPI description, requires the server's token as a bearer token.
+type Server struct {
+	ctx       context.Context // carries the auth, cache and ttl configuration of the process
+	setup     api.Setup
+	token     string
+	endpoints []Endpoint
+	writeLk   sync.Mutex
+}
+
+func NewServer(ctx context.Context, setup api.Setup, token string) *Server {
+	must.Assertf(ctx, token != "", "server token must be specified")
+	return &Server{ctx: ctx, setup: setup, token: token, endpoints: Endpoints}
+}
+
+// Endpoint describes a single API operation.
+type Endpoint struct {
+	Method   string
+	Path     string
+	Summary  string
+	Request  reflect.Type
+	Response reflect.Type
+	serve    func(ctx context.Context, s *Server, req any) any
+}
+
+func (x Endpoint) IsWrite() bool {
+	return x.Method == http.MethodPost
+}
+
+// Read defines an endpoint that only reads from the community repo.
+// Its request fields are passed as URL query parameters.
+func Read[Req any, Resp any](path string, summary string, f func(ctx context.Context, s *Server, req Req) Resp) Endpoint {
+	return newEndpoint(http.MethodGet, path, summary, f)
+}
+
+// Write defines an endpoint that makes changes to the community (or member) repos.
+// Its request is passed as a JSON body.
+func Write[Req any, Resp any](path string, summary string, f func(ctx context.Context, s *Server, req Req) Resp) Endpoint {
+	return newEndpoint(http.MethodPost, path, summary, f)
+}
+
+func newEndpoint[Req any, Resp any](method string, path string, summary string, f func(ctx context.Context, s *Server, req Req) Resp) Endpoint {
+	return Endpoint{
+		Method:   method,
+		Path:     path,
+		Summary:  summary,
+		Request:  reflect.TypeOf((*Req)(nil)).Elem(),
+		Response: reflect.TypeOf((*Resp)(nil)).Elem(),
+		serve: func(ctx context.Context, s *Server, req any) any {
+			return f(ctx, s, *req.(*Req))
+		},
+	}
+}
+
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	for _, e := range s.endpoints {
+		e := e
+		mux.HandleFunc(e.Path, func(w http.ResponseWriter, r *http.Request) {
+			s.serve(w, r, e)
+		})
+	}
+	mux.HandleFunc("/openapi.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(form.SprintJSON(OpenAPI(s.endpoints))))
+	})
+	return mux
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request, e Endpoint) {
+
+	if !s.isAuthorized(r) {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		respond(w, http.StatusUnauthorized, api.Result{Status: api.StatusError, Msg: "missing or invalid bearer token"})
+		return
+	}
+	if r.Method != e.Method {
+		w.Header().Set("Allow", e.Method)
+		respond(w, http.StatusMethodNotAllowed, api.Result{Status: api.StatusError, Msg: fmt.Sprintf("method %s not allowed", r.Method)})
+		return
+	}
+	// requests with other content types can be sent cross-origin without a preflight
+	if e.IsWrite() && !isJSON(r) {
+		respond(w, http.StatusUnsupportedMediaType, api.Result{Status: api.StatusError, Msg: "request body must be application/json"})
+		return
+	}
+
+	req := reflect.New(e.Request)
+	var present func(name string) bool // reports whether a request field was passed
+	var err error
+	if e.IsWrite() {
+		var fields map[string]json.RawMessage
+		fields, err = decodeBody(r.Body, req.Interface())
+		present = func(name string) bool {
+			v, ok := fields[name]
+			return ok && string(v) != "null"
+		}
+	} else {
+		q := r.URL.Query()
+		err = decodeQuery(q, req.Elem())
+		present = q.Has
+	}
+	if err == nil {
+		err = validate(req.Elem(), present)
+	}
+	if err != nil {
+		respond(w, http.StatusBadRequest, api.Result{Status: api.StatusError, Msg: fmt.Sprintf("invalid request (%v)", err)})
+		return
+	}
+
+	if e.IsWrite() {
+		s.writeLk.Lock()
+		defer s.writeLk.Unlock()
+	}
+	base.Infof("serve: %s %s", e.Method, e.Path)
+	result, mustErr := must.Try1Thru[any](func() any { return e.serve(s.ctx, s, req.Interface()) })
+	if mustErr != nil {
+		respond(w, errorStatus(mustErr.Wrapped()), api.NewResult(nil, mustErr))
+		return
+	}
+	respond(w, http.StatusOK, api.NewResult(result, nil))
+}
+
+func (s *Server) isAuthorized(r *http.Request) bool {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return ok && subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) == 1
+}
+
+func isJSON(r *http.Request) bool {
+	mt, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return err == nil && mt == "application/json"
+}
+
+// invalidRequestErrors are raised by operations that do not apply to the current state of the community,
+// e.g. closing a motion twice or voting on a closed ballot.
+var invalidRequestErrors = []error{
+	motionproto.ErrMotionAlreadyExists,
+	motionproto.ErrMotionAlreadyClosed,
+	motionproto.ErrMotionAlreadyCancelled,
+	motionproto.ErrMotionNotClosed,
+	motionproto.ErrMotionAlreadyFrozen,
+	motionproto.ErrMotionNotFrozen,
+	ballotproto.ErrBallotClosed,
+	ballotproto.ErrBallotFrozen,
+	ballotproto.ErrBallotNotOpen,
+	ballotproto.ErrNotAChoice,
+	account.ErrInsufficientFunds,
+}
+
+// errorStatus returns the HTTP status for an error raised by an operation.
+// Missing objects, like unknown motions or ballots, are not found.
+// Requests that do not apply to the state of the community are bad requests.
+// Other errors, e.g. failures to clone or push repos, are internal.
+func errorStatus(err error) int {
+	if errors.Is(err, os.ErrNotExist) {
+		return http.StatusNotFound
+	}
+	for _, e := range invalidRequestErrors {
+		if errors.Is(err, e) {
+			return http.StatusBadRequest
+		}
+	}
+	return http.StatusInternalServerError
+}
+
+func respond(w http.ResponseWriter, status int, result api.Result) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write([]byte(form.SprintJSON(result)))
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// decodeQuery sets the fields of the struct v from the query parameters matching their json names.
+func decodeQuery(q url.Values, v reflect.Value) error {
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Type().Field(i)
+		name := jsonName(f)
+		if name == "" || !q.Has(name) {
+			continue
+		}
+		s := q.Get(name)
+		fv := v.Field(i)
+		if fv.Type() == timeType {
+			t, err := time.Parse(time.RFC3339, s)
+			if err != nil {
+				return fmt.Errorf("parameter %s is not an RFC3339 time", name)
+			}
+			fv.Set(reflect.ValueOf(t))
+			continue
+		}
+		switch fv.Kind() {
+		case reflect.String:
+			fv.SetString(s)
+		case reflect.Bool:
+			b, err := strconv.ParseBool(s)
+			if err != nil {
+				return fmt.Errorf("parameter %s is not a boolean", name)
+			}
+			fv.SetBool(b)
+		case reflect.Int, reflect.Int64:
+			n, err := strconv.ParseInt(s, 10, 64)
+			if err != nil {
+				return fmt.Errorf("parameter %s is not an integer", name)
+			}
+			fv.SetInt(n)
+		case reflect.Float64:
+			x, err := strconv.ParseFloat(s, 64)
+			if err != nil {
+				return fmt.Errorf("parameter %s is not a number", name)
+			}
+			fv.SetFloat(x)
+		default:
+			return fmt.Errorf("parameter %s cannot be passed in a query", name)
+		}
+	}
+	return nil
+}
+
+// decodeBody decodes a JSON request body into the struct pointed to by v, and returns the fields passed in the body.
+func decodeBody(body io.Reader, v any) (map[string]json.RawMessage, error) {
+	buf, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(buf, &fields); err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(buf))
+	dec.DisallowUnknownFields()
+	return fields, dec.Decode(v)
+}
+
+// validate checks that the fields of the struct v, which are tagged as required, were passed.
+// Required fields can be passed with zero values, like 0 or false.
+func validate(v reflect.Value, present func(name string) bool) error {
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Type().Field(i)
+		if isRequired(f) && !present(jsonName(f)) {
+			return fmt.Errorf("%s is required", jsonName(f))
+		}
+	}
+	return nil
+}
+
+func isRequired(f reflect.StructField) bool {
+	return f.Tag.Get("required") == "true"
+}
+
+func jsonName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return f.Name
+	}
+	return name
+}
@@ -78,11 +78,11 @@ func (x AssetHoldings) DepositOverDraft(ctx context.Context, h Holding) {
 func (x AssetHoldings) Deposit(ctx context.Context, h Holding) {
 	if g, ok := x[h.Asset]; ok {
 		d := SumHolding(ctx, g, h)
-		must.Assertf(ctx, d.Quantity >= 0, "insufficient funds")
+		must.Assert(ctx, d.Quantity.Sign() >= 0, ErrInsufficientFunds)
 		x[h.Asset] = d
 	} else {
 		d := h
-		must.Assertf(ctx, d.Quantity >= 0, "no funds")
+		must.Assert(ctx, d.Quantity.Sign() >= 0, ErrInsufficientFunds)
 		x[h.Asset] = d
 	}
 }
@@ -116,10 +116,12 @@ func Create(
 	note string,
 
 ) {
-	cloned := gov.Clone(ctx, addr)
-	Create_StageOnly(ctx, cloned, id, owner, note)
-	proto.Commitf(ctx, cloned, "account_create", "create account %v (%v)", id, note)
-	cloned.Push(ctx)
+	proto.Retry(ctx, func() {
+		cloned := gov.Clone(ctx, addr)
+		Create_StageOnly(ctx, cloned, id, owner, note)
+		proto.Commitf(ctx, cloned, "account_create", "create account %v (%v)", id, note)
+		proto.Push(ctx, cloned)
+	})
 }
 
 func Create_StageOnly(
@@ -186,10 +188,12 @@ func Transfer(
 	note string,
 
 ) {
-	cloned := gov.Clone(ctx, addr)
-	Transfer_StageOnly(ctx, cloned, from, to, amount, note)
-	proto.Commitf(ctx, cloned, "account_transfer", "transfer %v from %v to %v (%v)", amount, from, to, note)
-	cloned.Push(ctx)
+	proto.Retry(ctx, func() {
+		cloned := gov.Clone(ctx, addr)
+		Transfer_StageOnly(ctx, cloned, from, to, amount, note)
+		proto.Commitf(ctx, cloned, "account_transfer", "transfer %v from %v to %v (%v)", amount, from, to, note)
+		proto.Push(ctx, cloned)
+	})
 }
 
 func Transfer_StageOnly(
@@ -202,6 +206,8 @@ func Transfer_StageOnly(
 
 ) {
 
+	VerifyHolding_Local(ctx, cloned, amount)
+
 	from := Get_Local(ctx, cloned, fromID)
 	from.Withdraw(ctx, amount)
 
@@ -249,10 +255,12 @@ func Issue(
 
 ) {
 
-	cloned := gov.Clone(ctx, addr)
-	Issue_StageOnly(ctx, cloned, to, amount, note)
-	proto.Commitf(ctx, cloned, "account_issue", "issue %v to %v (%v)", amount, to, note)
-	cloned.Push(ctx)
+	proto.Retry(ctx, func() {
+		cloned := gov.Clone(ctx, addr)
+		Issue_StageOnly(ctx, cloned, to, amount, note)
+		proto.Commitf(ctx, cloned, "account_issue", "issue %v to %v (%v)", amount, to, note)
+		proto.Push(ctx, cloned)
+	})
 }
 
 func Issue_StageOnly(
@@ -264,23 +272,54 @@ func Issue_StageOnly(
 
 ) {
 
-	TransferOverDraft_StageOnly(
-		metric.Mute(ctx),
+	// the registered issuer of the asset is overdrawn, so that the issued supply of the asset is the negated balance of its issuer
+	issuerID := LookupAsset_Local(ctx, cloned, amount.Asset).Issuer
+	TransferOverDraft_StageOnly(metric.Mute(ctx), cloned, issuerID, toID, amount, note)
+	trace.Log_StageOnly(ctx, cloned, &trace.Event{
+		Op:     "account_issue",
+		Note:   note,
+		Args:   trace.M{"from": issuerID, "to": toID, "amount": amount},
+		Result: nil,
+	})
+	metric.Log_StageOnly(ctx, cloned, &metric.Event{
+		Account: &metric.AccountEvent{
+			Issue: &metric.AccountIssueEvent{
+				Issuer: issuerID.MetricAccountID(),
+				To:     toID.MetricAccountID(),
+				Amount: amount.MetricHolding(),
+			},
+		},
+	})
+}
+
+// Decay_StageOnly moves the demurrage charged on the balance of an account to a destination account.
+func Decay_StageOnly(
+	ctx context.Context,
+	cloned gov.Cloned,
+	fromID AccountID,
+	toID AccountID,
+	amount Holding,
+	note string,
+
+) {
+	Transfer_StageOnly(
+		trace.Mute(metric.Mute(ctx)),
 		cloned,
-		IssueAccountID,
+		fromID,
 		toID,
 		amount,
 		note,
 	)
 	trace.Log_StageOnly(ctx, cloned, &trace.Event{
-		Op:     "account_issue",
+		Op:     "account_decay",
 		Note:   note,
-		Args:   trace.M{"from": IssueAccountID, "to": toID, "amount": amount},
+		Args:   trace.M{"from": fromID, "to": toID, "amount": amount},
 		Result: nil,
 	})
 	metric.Log_StageOnly(ctx, cloned, &metric.Event{
 		Account: &metric.AccountEvent{
-			Issue: &metric.AccountIssueEvent{
+			Decay: &metric.AccountDecayEvent{
+				From:   fromID.MetricAccountID(),
 				To:     toID.MetricAccountID(),
 				Amount: amount.MetricHolding(),
 			},
@@ -297,10 +336,12 @@ func Burn(
 
 ) {
 
-	cloned := gov.Clone(ctx, addr)
-	Burn_StageOnly(ctx, cloned, fromID, amount, note)
-	proto.Commitf(ctx, cloned, "account_burn", "burn %v from %v (%v)", amount, fromID, note)
-	cloned.Push(ctx)
+	proto.Retry(ctx, func() {
+		cloned := gov.Clone(ctx, addr)
+		Burn_StageOnly(ctx, cloned, fromID, amount, note)
+		proto.Commitf(ctx, cloned, "account_burn", "burn %v from %v (%v)", amount, fromID, note)
+		proto.Push(ctx, cloned)
+	})
 }
 
 func Burn_StageOnly(
@@ -372,6 +413,8 @@ func TransferOverDraft_StageOnly(
 
 ) {
 
+	VerifyHolding_Local(ctx, cloned, amount)
+
 	from := Get_Local(ctx, cloned, fromID)
 	from.WithdrawOverDraft(ctx, amount)
 
@@ -0,0 +1,204 @@
+package account
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/history/metric"
+	"github.com/gov4git/gov4git/v2/proto/journal"
+)
+
+// AuditReport compares the recorded account balances against the balances recomputed from the metric history.
+type AuditReport struct {
+	Events        int                `json:"events"` // number of replayed account events
+	Supply        []AssetSupply      `json:"supply"`
+	Discrepancies []AuditDiscrepancy `json:"discrepancies"`
+}
+
+// IsConsistent returns true if the audit found no discrepancies and every asset is conserved.
+func (x AuditReport) IsConsistent() bool {
+	if len(x.Discrepancies) > 0 {
+		return false
+	}
+	for _, s := range x.Supply {
+		if !s.Conserved {
+			return false
+		}
+	}
+	return true
+}
+
+// AssetSupply summarizes the recorded supply of an asset.
+// Every movement of an asset withdraws from one account and deposits into another, so the recorded balances of all accounts sum up to zero.
+// A zero sum alone does not detect balances that were changed in pairs, or events missing from the metric history,
+// so an asset is conserved only if, in addition, the recorded balance of every account agrees with the balance replayed from the metric history.
+type AssetSupply struct {
+	Asset       Asset    `json:"asset"`
+	Issued      Quantity `json:"issued"`      // total issued, according to the metric history
+	Burned      Quantity `json:"burned"`      // recorded balance of the burn account
+	Issuer      Quantity `json:"issuer"`      // recorded balance of the issuer account
+	Circulating Quantity `json:"circulating"` // recorded balances of all other accounts
+	Conserved   bool     `json:"conserved"`
+}
+
+// AuditDiscrepancy records an account balance which differs from the balance recomputed from the metric history.
+type AuditDiscrepancy struct {
+	Account  AccountID `json:"account"`
+	Asset    Asset     `json:"asset"`
+	Recorded Quantity  `json:"recorded"`
+	Replayed Quantity  `json:"replayed"`
+	Commit   string    `json:"commit,omitempty"`  // commit that introduced the discrepancy, if found
+	Message  string    `json:"message,omitempty"` // message of the commit that introduced the discrepancy
+}
+
+func Audit(
+	ctx context.Context,
+	addr gov.Address,
+
+) AuditReport {
+	return Audit_Local(ctx, gov.Clone(ctx, addr))
+}
+
+func Audit_Local(
+	ctx context.Context,
+	cloned gov.Cloned,
+
+) AuditReport {
+
+	ledger, n := ReplayLedger_Local(ctx, cloned)
+	issuer := assetIssuers_Local(ctx, cloned)
+	report := AuditReport{Events: n}
+
+	// compare recorded and replayed balances
+	recorded := map[AccountID]*Account{}
+	for _, id := range List_Local(ctx, cloned) {
+		recorded[id] = Get_Local(ctx, cloned, id)
+	}
+	ids := map[AccountID]bool{}
+	assets := map[Asset]bool{}
+	for id, acc := range recorded {
+		ids[id] = true
+		for a := range acc.Assets {
+			assets[a] = true
+		}
+	}
+	for id, hs := range ledger {
+		ids[id] = true
+		for a := range hs {
+			assets[a] = true
+		}
+	}
+	for _, id := range sortedKeys(ids) {
+		for _, a := range sortedKeys(assets) {
+			rec := ZeroHolding(a)
+			if acc, ok := recorded[id]; ok {
+				rec = acc.Balance(a)
+			}
+			rep := ledger.Balance(id, a)
+			if rec.Quantity != rep.Quantity {
+				report.Discrepancies = append(report.Discrepancies,
+					AuditDiscrepancy{Account: id, Asset: a, Recorded: rec.Quantity, Replayed: rep.Quantity})
+			}
+		}
+	}
+
+	// check conservation
+	issued := map[Asset]Quantity{}
+	for _, e := range metric.List_Local(ctx, cloned) {
+		if e.Payload != nil && e.Payload.Account != nil && e.Payload.Account.Issue != nil {
+			amt := MetricH(ctx, e.Payload.Account.Issue.Amount)
+			issued[amt.Asset] = issued[amt.Asset].Add(amt.Quantity)
+		}
+	}
+	for _, a := range sortedKeys(assets) {
+		s := AssetSupply{Asset: a, Issued: issued[a]}
+		for id, acc := range recorded {
+			q := acc.Balance(a).Quantity
+			switch id {
+			case BurnAccountID:
+				s.Burned = s.Burned.Add(q)
+			case issuer(a):
+				s.Issuer = s.Issuer.Add(q)
+			default:
+				s.Circulating = s.Circulating.Add(q)
+			}
+		}
+		s.Conserved = s.Issuer.Add(s.Burned).Add(s.Circulating).IsZero() && !hasDiscrepancy(report.Discrepancies, a)
+		report.Supply = append(report.Supply, s)
+	}
+
+	if len(report.Discrepancies) > 0 {
+		locateDiscrepancies_Local(ctx, cloned, issuer, report.Discrepancies)
+	}
+	return report
+}
+
+func hasDiscrepancy(ds []AuditDiscrepancy, a Asset) bool {
+	for _, d := range ds {
+		if d.Asset == a {
+			return true
+		}
+	}
+	return false
+}
+
+// locateDiscrepancies_Local walks the history of the community repo and
+// attributes each discrepancy to the last commit that changed the difference between its recorded and replayed balances.
+func locateDiscrepancies_Local(
+	ctx context.Context,
+	cloned gov.Cloned,
+	issuer func(Asset) AccountID,
+	ds []AuditDiscrepancy,
+
+) {
+
+	accountPaths := map[string]AccountID{}
+	for _, d := range ds {
+		accountPaths[accountKV.ValueNS(accountNS, d.Account).GitPath()] = d.Account
+	}
+
+	ledger := Ledger{}
+	recorded := map[AccountID]*Account{}
+	delta := make([]Quantity, len(ds))
+	walkHistory_Local(ctx, cloned, func(c *object.Commit, tree *object.Tree, changes object.Changes) {
+		for _, path := range addedFiles(changes, metric.HistoryNS()) {
+			if e, ok := readTreeFile[journal.Entry[*metric.Event]](ctx, tree, path); ok && e.Payload != nil {
+				ledger.Apply(ctx, e.Payload.Account, issuer)
+			}
+		}
+		for _, ch := range changes {
+			if id, ok := accountPaths[ch.From.Name]; ok && ch.To.Name == "" {
+				delete(recorded, id)
+			}
+			if id, ok := accountPaths[ch.To.Name]; ok {
+				if acc, ok := readTreeFile[*Account](ctx, tree, ch.To.Name); ok {
+					recorded[id] = acc
+				}
+			}
+		}
+		for i, d := range ds {
+			rec := ZeroHolding(d.Asset)
+			if acc, ok := recorded[d.Account]; ok {
+				rec = acc.Balance(d.Asset)
+			}
+			q := rec.Quantity.Sub(ledger.Balance(d.Account, d.Asset).Quantity)
+			if q != delta[i] {
+				delta[i] = q
+				ds[i].Commit = c.Hash.String()
+				ds[i].Message = strings.TrimSpace(c.Message)
+			}
+		}
+	})
+}
+
+func sortedKeys[K ~string](m map[K]bool) []K {
+	ks := make([]K, 0, len(m))
+	for k := range m {
+		ks = append(ks, k)
+	}
+	slices.Sort(ks)
+	return ks
+}
@@ -0,0 +1,7 @@
+package account
+
+import "errors"
+
+var (
+	ErrInsufficientFunds = errors.New("insufficient funds")
+)
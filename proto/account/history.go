@@ -0,0 +1,73 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/lib4git/form"
+	"github.com/gov4git/lib4git/git"
+	"github.com/gov4git/lib4git/must"
+	"github.com/gov4git/lib4git/ns"
+)
+
+// walkHistory_Local visits the first-parent history of the community repo, oldest commit first.
+// For each commit, visit receives the commit tree and the changes relative to the parent commit.
+func walkHistory_Local(
+	ctx context.Context,
+	cloned gov.Cloned,
+	visit func(c *object.Commit, tree *object.Tree, changes object.Changes),
+
+) {
+
+	repo := cloned.Repo()
+	head := git.ResolveBranch(ctx, repo, git.Address(cloned.Address()).Branch)
+
+	commits := []*object.Commit{head}
+	for c := head; c.NumParents() > 0; {
+		p, err := c.Parent(0)
+		must.NoError(ctx, err)
+		commits = append(commits, p)
+		c = p
+	}
+	slices.Reverse(commits)
+
+	var parentTree *object.Tree
+	for _, c := range commits {
+		tree, err := c.Tree()
+		must.NoError(ctx, err)
+		changes, err := object.DiffTree(parentTree, tree)
+		must.NoError(ctx, err)
+		visit(c, tree, changes)
+		parentTree = tree
+	}
+}
+
+// addedFiles returns the paths of files added under the given namespace, in lexicographic order.
+func addedFiles(changes object.Changes, under ns.NS) []string {
+	prefix := under.GitPath() + "/"
+	paths := []string{}
+	for _, ch := range changes {
+		if ch.From.Name == "" && strings.HasPrefix(ch.To.Name, prefix) {
+			paths = append(paths, ch.To.Name)
+		}
+	}
+	slices.Sort(paths)
+	return paths
+}
+
+func readTreeFile[V form.Form](ctx context.Context, tree *object.Tree, path string) (V, bool) {
+	var v V
+	f, err := tree.File(path)
+	if errors.Is(err, object.ErrFileNotFound) {
+		return v, false
+	}
+	must.NoError(ctx, err)
+	content, err := f.Contents()
+	must.NoError(ctx, err)
+	v, err = form.DecodeBytes[V](ctx, []byte(content))
+	return v, err == nil
+}
@@ -9,11 +9,16 @@ import (
 )
 
 type Holding struct {
-	Asset    Asset   `json:"asset"`
-	Quantity float64 `json:"quantity"`
+	Asset    Asset    `json:"asset"`
+	Quantity Quantity `json:"quantity"`
 }
 
+// H returns a holding of the quantity of an asset nearest to q.
 func H(a Asset, q float64) Holding {
+	return Holding{Asset: a, Quantity: Q(q)}
+}
+
+func HQ(a Asset, q Quantity) Holding {
 	return Holding{Asset: a, Quantity: q}
 }
 
@@ -24,21 +29,22 @@ func (h Holding) String() string {
 func (h Holding) MetricHolding() metric.Holding {
 	return metric.Holding{
 		Asset:    metric.Asset(h.Asset),
-		Quantity: h.Quantity,
+		Quantity: h.Quantity.Float64(),
+		Exact:    h.Quantity.String(),
 	}
 }
 
 func ZeroHolding(asset Asset) Holding {
 	return Holding{
 		Asset:    asset,
-		Quantity: 0,
+		Quantity: Quantity{},
 	}
 }
 
 func NegHolding(p Holding) Holding {
 	return Holding{
 		Asset:    p.Asset,
-		Quantity: -p.Quantity,
+		Quantity: p.Quantity.Neg(),
 	}
 }
 
@@ -46,6 +52,6 @@ func SumHolding(ctx context.Context, p, q Holding) Holding {
 	must.Assertf(ctx, p.Asset == q.Asset, "cannot add different assets")
 	return Holding{
 		Asset:    p.Asset,
-		Quantity: p.Quantity + q.Quantity,
+		Quantity: p.Quantity.Add(q.Quantity),
 	}
 }
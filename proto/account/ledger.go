@@ -0,0 +1,99 @@
+package account
+
+import (
+	"context"
+
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/history/metric"
+	"github.com/gov4git/lib4git/must"
+)
+
+// Ledger holds account balances recomputed from the account events in the metric history.
+type Ledger map[AccountID]AssetHoldings
+
+func (x Ledger) Balance(id AccountID, asset Asset) Holding {
+	return x[id].Balance(asset)
+}
+
+func (x Ledger) move(ctx context.Context, from, to AccountID, amount Holding) {
+	for _, id := range []AccountID{from, to} {
+		if x[id] == nil {
+			x[id] = AssetHoldings{}
+		}
+	}
+	x[from].WithdrawOverDraft(ctx, amount)
+	x[to].DepositOverDraft(ctx, amount)
+}
+
+// Apply applies an account event to the ledger.
+// Issued amounts are withdrawn from the issuer recorded in the event, or
+// from the account returned by issuer for events that do not record one.
+func (x Ledger) Apply(ctx context.Context, ev *metric.AccountEvent, issuer func(Asset) AccountID) {
+	if ev == nil {
+		return
+	}
+	if ev.Issue != nil {
+		amount := MetricH(ctx, ev.Issue.Amount)
+		x.move(ctx, issueEventIssuer(ev.Issue, issuer), AccountID(ev.Issue.To), amount)
+	}
+	if ev.Burn != nil {
+		x.move(ctx, AccountID(ev.Burn.From), BurnAccountID, MetricH(ctx, ev.Burn.Amount))
+	}
+	if ev.Transfer != nil {
+		x.move(ctx, AccountID(ev.Transfer.From), AccountID(ev.Transfer.To), MetricH(ctx, ev.Transfer.Amount))
+	}
+	if ev.Decay != nil {
+		x.move(ctx, AccountID(ev.Decay.From), AccountID(ev.Decay.To), MetricH(ctx, ev.Decay.Amount))
+	}
+}
+
+// ReplayLedger_Local recomputes all account balances from the metric history.
+func ReplayLedger_Local(
+	ctx context.Context,
+	cloned gov.Cloned,
+
+) (Ledger, int) {
+
+	ledger := Ledger{}
+	issuer := assetIssuers_Local(ctx, cloned)
+	n := 0
+	for _, e := range metric.List_Local(ctx, cloned) {
+		if e.Payload == nil || e.Payload.Account == nil {
+			continue
+		}
+		ledger.Apply(ctx, e.Payload.Account, issuer)
+		n++
+	}
+	return ledger, n
+}
+
+func assetIssuers_Local(ctx context.Context, cloned gov.Cloned) func(Asset) AccountID {
+	issuers := map[Asset]AccountID{}
+	return func(a Asset) AccountID {
+		if id, ok := issuers[a]; ok {
+			return id
+		}
+		issuers[a] = LookupAsset_Local(ctx, cloned, a).Issuer
+		return issuers[a]
+	}
+}
+
+// issueEventIssuer returns the issuer of an issue event.
+// Events logged before issuers were recorded are attributed to the asset's current issuer.
+func issueEventIssuer(ev *metric.AccountIssueEvent, issuer func(Asset) AccountID) AccountID {
+	if ev.Issuer != "" {
+		return AccountID(ev.Issuer)
+	}
+	return issuer(Asset(ev.Amount.Asset))
+}
+
+// MetricH converts a holding recorded in the metric history to an exact holding.
+// Holdings recorded before quantities were exact are converted from their floating-point quantity.
+func MetricH(ctx context.Context, h metric.Holding) Holding {
+	if h.Exact == "" {
+		return H(Asset(h.Asset), h.Quantity)
+	}
+	q, err := ParseQuantity(h.Exact)
+	must.NoError(ctx, err)
+	return HQ(Asset(h.Asset), q)
+}
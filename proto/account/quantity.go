@@ -0,0 +1,159 @@
+package account
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+
+	"github.com/gov4git/lib4git/must"
+)
+
+// QuantityDecimals is the number of decimal digits after the point that quantities are exact to.
+const QuantityDecimals = 9
+
+const quantityScale = 1_000_000_000 // 10^QuantityDecimals
+
+// Quantity is an exact fixed-point decimal amount of an asset.
+// Sums and differences of quantities are exact, so that account balances do not accumulate rounding errors.
+// Quantities are encoded as JSON numbers and decoded from JSON numbers or strings,
+// which keeps them compatible with holdings recorded as floating-point numbers.
+type Quantity struct {
+	units int64 // in multiples of 10^-QuantityDecimals
+}
+
+// Q returns the quantity nearest to a floating-point number.
+// It panics if the number is not within the range of quantities.
+func Q(f float64) Quantity {
+	u := math.Round(f * quantityScale)
+	// float64(math.MaxInt64) rounds up to 2^63, which is out of range
+	if math.IsNaN(u) || u < math.MinInt64 || u >= math.MaxInt64 {
+		quantityOutOfRange("quantity %v out of range", f)
+	}
+	return Quantity{units: int64(u)}
+}
+
+func quantityOutOfRange(format string, args ...any) {
+	must.Errorf(context.Background(), format, args...)
+}
+
+// ParseQuantity parses a decimal number, optionally in exponential notation.
+// Digits beyond QuantityDecimals after the point are rounded to the nearest quantity.
+func ParseQuantity(s string) (Quantity, error) {
+	r, ok := new(big.Rat).SetString(strings.TrimSpace(s))
+	if !ok {
+		return Quantity{}, fmt.Errorf("invalid quantity %q", s)
+	}
+	r.Mul(r, new(big.Rat).SetInt64(quantityScale))
+	num, den := r.Num(), r.Denom()
+	quo, rem := new(big.Int).QuoRem(num, den, new(big.Int))
+	if new(big.Int).Mul(rem, big.NewInt(2)).CmpAbs(den) >= 0 {
+		quo.Add(quo, big.NewInt(int64(num.Sign())))
+	}
+	if !quo.IsInt64() {
+		return Quantity{}, fmt.Errorf("quantity %q out of range", s)
+	}
+	return Quantity{units: quo.Int64()}, nil
+}
+
+func (x Quantity) Float64() float64 {
+	return float64(x.units) / quantityScale
+}
+
+// Add returns x + y. It panics if the sum is out of range.
+func (x Quantity) Add(y Quantity) Quantity {
+	if (y.units > 0 && x.units > math.MaxInt64-y.units) || (y.units < 0 && x.units < math.MinInt64-y.units) {
+		quantityOutOfRange("sum of %v and %v out of range", x, y)
+	}
+	return Quantity{units: x.units + y.units}
+}
+
+// Sub returns x - y. It panics if the difference is out of range.
+func (x Quantity) Sub(y Quantity) Quantity {
+	if (y.units < 0 && x.units > math.MaxInt64+y.units) || (y.units > 0 && x.units < math.MinInt64+y.units) {
+		quantityOutOfRange("difference of %v and %v out of range", x, y)
+	}
+	return Quantity{units: x.units - y.units}
+}
+
+// Neg returns -x. It panics if x is the smallest quantity, whose negation is out of range.
+func (x Quantity) Neg() Quantity {
+	if x.units == math.MinInt64 {
+		quantityOutOfRange("negation of %v out of range", x)
+	}
+	return Quantity{units: -x.units}
+}
+
+// Cmp returns -1, 0 or +1, when x is less than, equal to, or greater than y.
+func (x Quantity) Cmp(y Quantity) int {
+	switch {
+	case x.units < y.units:
+		return -1
+	case x.units > y.units:
+		return 1
+	}
+	return 0
+}
+
+func (x Quantity) Sign() int {
+	return x.Cmp(Quantity{})
+}
+
+func (x Quantity) IsZero() bool {
+	return x.units == 0
+}
+
+// IsWhole returns true if the quantity has no fractional part.
+func (x Quantity) IsWhole() bool {
+	return x.units%quantityScale == 0
+}
+
+// Trunc returns the quantity without its fractional part.
+func (x Quantity) Trunc() Quantity {
+	return Quantity{units: x.units / quantityScale * quantityScale}
+}
+
+// String returns the shortest decimal representation of the quantity.
+func (x Quantity) String() string {
+	u := x.units
+	sign := ""
+	if u < 0 {
+		sign = "-"
+	}
+	abs := new(big.Int).Abs(big.NewInt(u)).String()
+	if len(abs) <= QuantityDecimals {
+		abs = strings.Repeat("0", QuantityDecimals-len(abs)+1) + abs
+	}
+	whole, frac := abs[:len(abs)-QuantityDecimals], strings.TrimRight(abs[len(abs)-QuantityDecimals:], "0")
+	if frac == "" {
+		return sign + whole
+	}
+	return sign + whole + "." + frac
+}
+
+func (x Quantity) MarshalJSON() ([]byte, error) {
+	return []byte(x.String()), nil
+}
+
+func (x *Quantity) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		*x = Quantity{}
+		return nil
+	}
+	s := string(data)
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+	}
+	q, err := ParseQuantity(s)
+	if err != nil {
+		return err
+	}
+	*x = q
+	return nil
+}
@@ -0,0 +1,117 @@
+package account
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/gov4git/lib4git/must"
+)
+
+func TestQuantityExactSum(t *testing.T) {
+	sum := Quantity{}
+	for i := 0; i < 1000; i++ {
+		sum = sum.Add(Q(0.1))
+	}
+	if sum != Q(100) {
+		t.Errorf("expecting %v, got %v", Q(100), sum)
+	}
+	if d := Q(10).Sub(Q(0.3)).Sub(Q(9.7)); !d.IsZero() {
+		t.Errorf("expecting zero, got %v", d)
+	}
+}
+
+func TestQuantityString(t *testing.T) {
+	cases := map[Quantity]string{
+		Q(0):             "0",
+		Q(3):             "3",
+		Q(-2.5):          "-2.5",
+		Q(0.000000001):   "0.000000001",
+		Q(-0.0000001):    "-0.0000001",
+		Q(1234.56789):    "1234.56789",
+		Q(1e-10):         "0",
+		Q(10.0000000004): "10",
+	}
+	for q, exp := range cases {
+		if got := q.String(); got != exp {
+			t.Errorf("expecting %v, got %v", exp, got)
+		}
+	}
+}
+
+func TestHoldingJSONCompatibility(t *testing.T) {
+	// holdings recorded as floating-point numbers, with rounding drift
+	cases := map[string]Quantity{
+		`{"asset":"plural","quantity":9.999999999999998}`: Q(10),
+		`{"asset":"plural","quantity":1e-7}`:              Q(0.0000001),
+		`{"asset":"plural","quantity":-3.25}`:             Q(-3.25),
+		`{"asset":"plural","quantity":"4.5"}`:             Q(4.5),
+		`{"asset":"plural","quantity":0}`:                 Q(0),
+	}
+	for data, exp := range cases {
+		var h Holding
+		if err := json.Unmarshal([]byte(data), &h); err != nil {
+			t.Fatalf("decoding %v (%v)", data, err)
+		}
+		if h.Quantity != exp {
+			t.Errorf("decoding %v: expecting %v, got %v", data, exp, h.Quantity)
+		}
+	}
+
+	// round trip
+	h := H(PluralAsset, 7.125)
+	data, err := json.Marshal(h)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"asset":"plural","quantity":7.125}` {
+		t.Errorf("unexpected encoding %v", string(data))
+	}
+	var g Holding
+	if err := json.Unmarshal(data, &g); err != nil || g != h {
+		t.Errorf("expecting %v, got %v (%v)", h, g, err)
+	}
+
+	if err := json.Unmarshal([]byte(`{"quantity":"x"}`), &g); err == nil {
+		t.Errorf("expecting invalid quantity to fail")
+	}
+}
+
+func TestQuantityRange(t *testing.T) {
+	max, min := Quantity{units: math.MaxInt64}, Quantity{units: math.MinInt64}
+	unit := Quantity{units: 1}
+
+	// in range
+	if q := max.Sub(unit).Add(unit); q != max {
+		t.Errorf("expecting %v, got %v", max, q)
+	}
+	if q := min.Add(unit).Sub(unit); q != min {
+		t.Errorf("expecting %v, got %v", min, q)
+	}
+	if q := max.Add(min); q != unit.Neg() {
+		t.Errorf("expecting %v, got %v", unit.Neg(), q)
+	}
+	if q := Q(-9.2e9); q.Float64() != -9.2e9 {
+		t.Errorf("expecting %v, got %v", -9.2e9, q)
+	}
+
+	// out of range
+	overflows := map[string]func(){
+		"Q(1e10)":     func() { Q(1e10) },
+		"Q(-1e10)":    func() { Q(-1e10) },
+		"Q(NaN)":      func() { Q(math.NaN()) },
+		"Q(+Inf)":     func() { Q(math.Inf(1)) },
+		"max+unit":    func() { max.Add(unit) },
+		"min-unit":    func() { min.Sub(unit) },
+		"min+(-unit)": func() { min.Add(unit.Neg()) },
+		"max-(-unit)": func() { max.Sub(unit.Neg()) },
+		"neg(min)":    func() { min.Neg() },
+		"max+max":     func() { max.Add(max) },
+		"zero-min":    func() { Quantity{}.Sub(min) },
+	}
+	for name, f := range overflows {
+		if must.Try(f) == nil {
+			t.Errorf("expecting %v to be out of range", name)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package account
+
+import (
+	"context"
+
+	"github.com/gov4git/gov4git/v2/proto"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/history/trace"
+	"github.com/gov4git/gov4git/v2/proto/kv"
+	"github.com/gov4git/lib4git/git"
+	"github.com/gov4git/lib4git/must"
+)
+
+// AssetInfo describes an asset held in community accounts.
+type AssetInfo struct {
+	Asset        Asset     `json:"asset"`
+	Description  string    `json:"description"`
+	Transferable bool      `json:"transferable"` // if false, members cannot transfer the asset to each other
+	Divisible    bool      `json:"divisible"`    // if false, the asset is held and moved only in whole quantities
+	Issuer       AccountID `json:"issuer"`       // account from which the asset is issued; it is overdrawn by the issued supply
+}
+
+var (
+	assetKV = kv.KV[Asset, AssetInfo]{}
+	assetNS = proto.RootNS.Append("asset")
+)
+
+// PluralAssetInfo describes the plural credit, which is available in every community without being defined.
+var PluralAssetInfo = AssetInfo{
+	Asset:        PluralAsset,
+	Description:  "plural voting credit",
+	Transferable: true,
+	Divisible:    true,
+	Issuer:       IssueAccountID,
+}
+
+func DefineAsset(
+	ctx context.Context,
+	addr gov.Address,
+	info AssetInfo,
+
+) {
+	proto.Retry(ctx, func() {
+		cloned := gov.Clone(ctx, addr)
+		DefineAsset_StageOnly(ctx, cloned, info)
+		proto.Commitf(ctx, cloned, "account_define_asset", "define asset %v", info.Asset)
+		proto.Push(ctx, cloned)
+	})
+}
+
+func DefineAsset_StageOnly(
+	ctx context.Context,
+	cloned gov.Cloned,
+	info AssetInfo,
+
+) {
+	must.Assertf(ctx, info.Asset != "", "asset name is empty")
+	must.Assertf(ctx, info.Asset != PluralAsset, "asset %v is predefined", PluralAsset)
+	must.Assertf(ctx, !assetKV.Contains(ctx, assetNS, cloned.Tree(), info.Asset), "asset %v already defined", info.Asset)
+	if info.Issuer == "" {
+		info.Issuer = IssueAccountID
+	}
+	must.Assertf(ctx, Exists_Local(ctx, cloned, info.Issuer), "issuer account %v does not exist", info.Issuer)
+
+	assetKV.Set(ctx, assetNS, cloned.Tree(), info.Asset, info)
+	trace.Log_StageOnly(ctx, cloned, &trace.Event{
+		Op:     "account_define_asset",
+		Args:   trace.M{"asset": info},
+		Result: nil,
+	})
+}
+
+func LookupAsset(
+	ctx context.Context,
+	addr gov.Address,
+	asset Asset,
+
+) AssetInfo {
+	return LookupAsset_Local(ctx, gov.Clone(ctx, addr), asset)
+}
+
+// LookupAsset_Local returns the description of an asset.
+// Assets that have not been defined are treated like the plural credit, for backward compatibility.
+func LookupAsset_Local(
+	ctx context.Context,
+	cloned gov.Cloned,
+	asset Asset,
+
+) AssetInfo {
+	info, err := must.Try1[AssetInfo](
+		func() AssetInfo {
+			return assetKV.Get(ctx, assetNS, cloned.Tree(), asset)
+		},
+	)
+	if git.IsNotExist(err) {
+		undefined := PluralAssetInfo
+		undefined.Asset = asset
+		undefined.Description = ""
+		return undefined
+	}
+	must.NoError(ctx, err)
+	return info
+}
+
+func ListAssets(
+	ctx context.Context,
+	addr gov.Address,
+
+) []AssetInfo {
+	return ListAssets_Local(ctx, gov.Clone(ctx, addr))
+}
+
+func ListAssets_Local(
+	ctx context.Context,
+	cloned gov.Cloned,
+
+) []AssetInfo {
+	infos := []AssetInfo{PluralAssetInfo}
+	if _, err := git.TreeStat(ctx, cloned.Tree(), assetNS); git.IsNotExist(err) {
+		return infos
+	}
+	assets := assetKV.ListKeys(ctx, assetNS, cloned.Tree())
+	return append(infos, assetKV.GetMany(ctx, assetNS, cloned.Tree(), assets)...)
+}
+
+// VerifyHolding_Local checks that the holding respects the divisibility of its asset.
+func VerifyHolding_Local(
+	ctx context.Context,
+	cloned gov.Cloned,
+	h Holding,
+
+) {
+	if h.Asset == PluralAsset {
+		return
+	}
+	info := LookupAsset_Local(ctx, cloned, h.Asset)
+	must.Assertf(ctx, info.Divisible || h.Quantity.IsWhole(), "asset %v is not divisible", h.Asset)
+}
+
+// VerifyTransferable_Local checks that members can transfer the asset to each other.
+func VerifyTransferable_Local(
+	ctx context.Context,
+	cloned gov.Cloned,
+	asset Asset,
+
+) {
+	info := LookupAsset_Local(ctx, cloned, asset)
+	must.Assertf(ctx, info.Transferable, "asset %v is not transferable", asset)
+}
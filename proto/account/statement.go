@@ -0,0 +1,242 @@
+package account
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/history/metric"
+	"github.com/gov4git/gov4git/v2/proto/history/trace"
+	"github.com/gov4git/gov4git/v2/proto/journal"
+)
+
+type StatementOp string
+
+const (
+	StatementIssue    StatementOp = "issue"
+	StatementBurn     StatementOp = "burn"
+	StatementTransfer StatementOp = "transfer"
+	StatementDecay    StatementOp = "decay"
+)
+
+// AccountStatement lists the debits and credits of an account in chronological order.
+type AccountStatement struct {
+	Account AccountID        `json:"account"`
+	From    time.Time        `json:"from"`
+	To      time.Time        `json:"to"`
+	Opening AssetHoldings    `json:"opening"` // balances before the first entry of the statement
+	Closing AssetHoldings    `json:"closing"` // balances after the last entry of the statement
+	Entries []StatementEntry `json:"entries"`
+}
+
+// StatementEntry is one side of a double-entry account movement.
+type StatementEntry struct {
+	Time         time.Time   `json:"time"`
+	Commit       string      `json:"commit"`
+	Op           StatementOp `json:"op"`
+	Counterparty AccountID   `json:"counterparty"`
+	Debit        *Holding    `json:"debit,omitempty"`  // amount withdrawn from the account
+	Credit       *Holding    `json:"credit,omitempty"` // amount deposited to the account
+	Balance      Holding     `json:"balance"`          // balance of the asset after the entry
+	Note         string      `json:"note"`
+}
+
+// Statement returns the account movements of an account between two times.
+// A zero from or to time leaves the statement unbounded on that side.
+func Statement(
+	ctx context.Context,
+	addr gov.Address,
+	id AccountID,
+	from time.Time,
+	to time.Time,
+
+) AccountStatement {
+	return Statement_Local(ctx, gov.Clone(ctx, addr), id, from, to)
+}
+
+// Statement_Local builds a statement by walking the history of the community repo.
+// Amounts and counterparties come from the account events in the metric history,
+// and notes come from the matching events in the trace history, which are logged in the same commit.
+func Statement_Local(
+	ctx context.Context,
+	cloned gov.Cloned,
+	id AccountID,
+	from time.Time,
+	to time.Time,
+
+) AccountStatement {
+
+	issuer := assetIssuers_Local(ctx, cloned)
+	st := AccountStatement{Account: id, From: from, To: to, Opening: AssetHoldings{}, Closing: AssetHoldings{}, Entries: []StatementEntry{}}
+	balance := AssetHoldings{}
+
+	walkHistory_Local(ctx, cloned, func(c *object.Commit, tree *object.Tree, changes object.Changes) {
+
+		// collect notes from the trace events of this commit
+		notes := []traceMovement{}
+		for _, path := range addedFiles(changes, trace.HistoryNS()) {
+			if e, ok := readTreeFile[journal.Entry[*trace.Event]](ctx, tree, path); ok && e.Payload != nil {
+				if m, ok := parseTraceMovement(e.Payload); ok {
+					notes = append(notes, m)
+				}
+			}
+		}
+
+		for _, path := range addedFiles(changes, metric.HistoryNS()) {
+			e, ok := readTreeFile[journal.Entry[*metric.Event]](ctx, tree, path)
+			if !ok || e.Payload == nil || e.Payload.Account == nil {
+				continue
+			}
+			m := metricMovement(ctx, e.Payload.Account, issuer)
+			if m.From != id && m.To != id {
+				continue
+			}
+			m.Note = matchNote(&notes, m)
+
+			switch {
+			case !from.IsZero() && e.Stamp.Before(from):
+				balance.DepositOverDraft(ctx, m.signedFor(ctx, id))
+				st.Opening = cloneHoldings(balance)
+				st.Closing = cloneHoldings(balance)
+				continue
+			case !to.IsZero() && e.Stamp.After(to):
+				continue
+			}
+
+			entry := StatementEntry{Time: e.Stamp, Commit: c.Hash.String(), Op: m.Op, Note: m.Note}
+			if m.From == id {
+				debit := m.Amount
+				entry.Debit = &debit
+				entry.Counterparty = m.To
+			}
+			if m.To == id {
+				credit := m.Amount
+				entry.Credit = &credit
+				entry.Counterparty = m.From
+			}
+			balance.DepositOverDraft(ctx, m.signedFor(ctx, id))
+			entry.Balance = balance.Balance(m.Amount.Asset)
+			st.Entries = append(st.Entries, entry)
+			st.Closing = cloneHoldings(balance)
+		}
+	})
+
+	return st
+}
+
+type traceMovement struct {
+	Op     StatementOp `json:"-"`
+	From   AccountID   `json:"from"`
+	To     AccountID   `json:"to"`
+	Amount Holding     `json:"amount"`
+	Note   string      `json:"-"`
+}
+
+// signedFor returns the change in the balance of an account due to the movement.
+func (m traceMovement) signedFor(ctx context.Context, id AccountID) Holding {
+	h := ZeroHolding(m.Amount.Asset)
+	if m.To == id {
+		h = SumHolding(ctx, h, m.Amount)
+	}
+	if m.From == id {
+		h = SumHolding(ctx, h, NegHolding(m.Amount))
+	}
+	return h
+}
+
+var traceStatementOps = map[string]StatementOp{
+	"account_issue":    StatementIssue,
+	"account_burn":     StatementBurn,
+	"account_transfer": StatementTransfer,
+	"account_decay":    StatementDecay,
+}
+
+func parseTraceMovement(ev *trace.Event) (traceMovement, bool) {
+	op, ok := traceStatementOps[ev.Op]
+	if !ok {
+		return traceMovement{}, false
+	}
+	buf, err := json.Marshal(ev.Args)
+	if err != nil {
+		return traceMovement{}, false
+	}
+	var m traceMovement
+	if json.Unmarshal(buf, &m) != nil {
+		return traceMovement{}, false
+	}
+	m.Op, m.Note = op, ev.Note
+	return m, true
+}
+
+func metricMovement(ctx context.Context, ev *metric.AccountEvent, issuer func(Asset) AccountID) traceMovement {
+	switch {
+	case ev.Issue != nil:
+		amount := MetricH(ctx, ev.Issue.Amount)
+		return traceMovement{Op: StatementIssue, From: issueEventIssuer(ev.Issue, issuer), To: AccountID(ev.Issue.To), Amount: amount}
+	case ev.Burn != nil:
+		return traceMovement{Op: StatementBurn, From: AccountID(ev.Burn.From), To: BurnAccountID, Amount: MetricH(ctx, ev.Burn.Amount)}
+	case ev.Transfer != nil:
+		return traceMovement{Op: StatementTransfer, From: AccountID(ev.Transfer.From), To: AccountID(ev.Transfer.To), Amount: MetricH(ctx, ev.Transfer.Amount)}
+	case ev.Decay != nil:
+		return traceMovement{Op: StatementDecay, From: AccountID(ev.Decay.From), To: AccountID(ev.Decay.To), Amount: MetricH(ctx, ev.Decay.Amount)}
+	}
+	return traceMovement{}
+}
+
+// matchNote finds and consumes the trace event describing the same movement, and returns its note.
+func matchNote(notes *[]traceMovement, m traceMovement) string {
+	for i, n := range *notes {
+		if n.Op == m.Op && n.From == m.From && n.To == m.To && n.Amount == m.Amount {
+			*notes = append((*notes)[:i], (*notes)[i+1:]...)
+			return n.Note
+		}
+	}
+	return ""
+}
+
+func cloneHoldings(x AssetHoldings) AssetHoldings {
+	y := AssetHoldings{}
+	for a, h := range x {
+		y[a] = h
+	}
+	return y
+}
+
+var statementCSVHeader = []string{"time", "commit", "op", "counterparty", "asset", "debit", "credit", "balance", "note"}
+
+// WriteCSV writes the entries of the statement as CSV, with a header row.
+func (x AccountStatement) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(statementCSVHeader); err != nil {
+		return err
+	}
+	for _, e := range x.Entries {
+		debit, credit := "", ""
+		if e.Debit != nil {
+			debit = e.Debit.Quantity.String()
+		}
+		if e.Credit != nil {
+			credit = e.Credit.Quantity.String()
+		}
+		row := []string{
+			e.Time.UTC().Format(time.RFC3339Nano),
+			e.Commit,
+			string(e.Op),
+			string(e.Counterparty),
+			string(e.Balance.Asset),
+			debit,
+			credit,
+			e.Balance.Quantity.String(),
+			e.Note,
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
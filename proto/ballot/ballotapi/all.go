@@ -21,16 +21,18 @@ func TallyAll(
 	maxPar int,
 ) git.Change[form.Map, []ballotproto.Tally] {
 
-	base.Infof("fetching and tallying community votes ...")
+	return proto.Retry1(ctx, func() git.Change[form.Map, []ballotproto.Tally] {
+		base.Infof("fetching and tallying community votes ...")
 
-	govOwner := gov.CloneOwner(ctx, addr)
-	chg := TallyAll_StageOnly(ctx, govOwner, maxPar)
-	if len(chg.Result) == 0 {
+		govOwner := gov.CloneOwner(ctx, addr)
+		chg := TallyAll_StageOnly(ctx, govOwner, maxPar)
+		if len(chg.Result) == 0 {
+			return chg
+		}
+		proto.Commit(ctx, govOwner.Public.Tree(), chg)
+		proto.Push(ctx, govOwner.Public)
 		return chg
-	}
-	proto.Commit(ctx, govOwner.Public.Tree(), chg)
-	govOwner.Public.Push(ctx)
-	return chg
+	})
 }
 
 func TallyAll_StageOnly(
@@ -53,18 +55,18 @@ func TallyAll_StageOnly(
 	}
 
 	// fetch repos of all participating users
-	allVoterClones := clonePar(ctx, allVoters, maxPar)
+	allVoterClones, allUnreachable := clonePar(ctx, allVoters, maxPar)
 
 	// populate participating voter clones
 	for _, pv := range participatingVoters {
-		pv.attachVoterClones(ctx, allVoterClones)
+		pv.attachVoterClones(ctx, allVoterClones, allUnreachable)
 	}
 
 	// perform tallies for all open ballots
 	tallyChanges := []git.Change[map[string]form.Form, ballotproto.Tally]{}
 	tallies := []ballotproto.Tally{}
 	for _, pv := range participatingVoters {
-		if tallyChg, changed := TallyVotersCloned_StageOnly(ctx, cloned, pv.Ad.ID, pv.VoterAccounts, pv.VoterClones); changed {
+		if tallyChg, _, changed := TallyVotersCloned_StageOnly(ctx, cloned, pv.Ad.ID, pv.VoterAccounts, pv.VoterClones, pv.Unreachable); changed {
 			tallyChanges = append(tallyChanges, tallyChg)
 			tallies = append(tallies, tallyChg.Result)
 		}
@@ -79,7 +81,13 @@ func TallyAll_StageOnly(
 	)
 }
 
-func clonePar(ctx context.Context, userAccounts map[member.User]member.UserProfile, maxPar int) map[member.User]git.Cloned {
+// clonePar clones the public repos of the given users in parallel, reusing the clones of the context's public repos cache, if any.
+// It returns the clones, as well as the errors encountered cloning the repos of unreachable users.
+func clonePar(
+	ctx context.Context,
+	userAccounts map[member.User]member.UserProfile,
+	maxPar int,
+) (map[member.User]git.Cloned, map[member.User]error) {
 
 	must.Assertf(ctx, maxPar > 0, "clone parallelism must be greater than zero")
 
@@ -90,15 +98,19 @@ func clonePar(ctx context.Context, userAccounts map[member.User]member.UserProfi
 
 	var allLock sync.Mutex
 	allClones := map[member.User]git.Cloned{}
+	allErrors := map[member.User]error{}
 
 	for u, a := range userAccounts {
 		sem <- true
 		go func(u member.User, a member.UserProfile) {
 
 			base.Infof("cloning voter %v repository %v", u, a.PublicAddress)
-			cloned, err := git.TryCloneOne(ctx, git.Address(a.PublicAddress))
+			cloned, err := member.TryClonePublic(ctx, a.PublicAddress)
 			if err != nil {
 				base.Infof("user %v repository %v unresponsive (%v)", u, a.PublicAddress, err)
+				allLock.Lock()
+				allErrors[u] = err
+				allLock.Unlock()
 			} else {
 				base.Infof("user %v repository %v cloned successfully (%v)", u, a.PublicAddress, err)
 				allLock.Lock()
@@ -113,5 +125,5 @@ func clonePar(ctx context.Context, userAccounts map[member.User]member.UserProfi
 
 	wg.Wait()
 
-	return allClones
+	return allClones, allErrors
 }
@@ -19,11 +19,13 @@ func Cancel(
 	id ballotproto.BallotID,
 ) git.Change[form.Map, ballotproto.Outcome] {
 
-	cloned := gov.CloneOwner(ctx, addr)
-	chg := Cancel_StageOnly(ctx, cloned, id)
-	proto.Commit(ctx, cloned.Public.Tree(), chg)
-	cloned.Public.Push(ctx)
-	return chg
+	return proto.Retry1(ctx, func() git.Change[form.Map, ballotproto.Outcome] {
+		cloned := gov.CloneOwner(ctx, addr)
+		chg := Cancel_StageOnly(ctx, cloned, id)
+		proto.Commit(ctx, cloned.Public.Tree(), chg)
+		proto.Push(ctx, cloned.Public)
+		return chg
+	})
 }
 
 func Cancel_StageOnly(
@@ -42,6 +44,7 @@ func Cancel_StageOnly(
 
 	var chg git.Change[map[string]form.Form, ballotproto.Outcome]
 	chg = policy.Cancel(ctx, cloned, &ad, &tally)
+	settleUnrevealed_StageOnly(ctx, cloned.PublicClone(), ad, tally, &chg.Result, true)
 
 	// write outcome
 	git.ToFileStage(ctx, t, id.OutcomeNS(), chg.Result)
@@ -21,12 +21,14 @@ func Change(
 
 ) git.Change[form.Map, ballotproto.Ad] {
 
-	cloned := gov.CloneOwner(ctx, addr)
-
-	chg := Change_StageOnly(ctx, cloned, id, title, description)
-	proto.Commit(ctx, cloned.Public.Tree(), chg)
-	cloned.Public.Push(ctx)
-	return chg
+	return proto.Retry1(ctx, func() git.Change[form.Map, ballotproto.Ad] {
+		cloned := gov.CloneOwner(ctx, addr)
+
+		chg := Change_StageOnly(ctx, cloned, id, title, description)
+		proto.Commit(ctx, cloned.Public.Tree(), chg)
+		proto.Push(ctx, cloned.Public)
+		return chg
+	})
 }
 
 func Change_StageOnly(
@@ -23,11 +23,13 @@ func Close(
 
 ) git.Change[form.Map, ballotproto.Outcome] {
 
-	cloned := gov.CloneOwner(ctx, addr)
-	chg := Close_StageOnly(ctx, cloned, id, escrowTo)
-	proto.Commit(ctx, cloned.Public.Tree(), chg)
-	cloned.Public.Push(ctx)
-	return chg
+	return proto.Retry1(ctx, func() git.Change[form.Map, ballotproto.Outcome] {
+		cloned := gov.CloneOwner(ctx, addr)
+		chg := Close_StageOnly(ctx, cloned, id, escrowTo)
+		proto.Commit(ctx, cloned.Public.Tree(), chg)
+		proto.Push(ctx, cloned.Public)
+		return chg
+	})
 }
 
 func Close_StageOnly(
@@ -44,10 +46,22 @@ func Close_StageOnly(
 	ad, policy := ballotio.LoadAdPolicy_Local(ctx, t, id)
 	must.Assertf(ctx, !ad.Closed, "ballot already closed")
 
+	// time-dependent tallies may lag behind by up to ReTallyThreshold, so they are brought up to date before closing
+	if ballotproto.IsTimeDependent(policy) {
+		ReTally_StageOnly(ctx, cloned.PublicClone(), id)
+	}
 	tally := loadTally_Local(ctx, t, id)
 
 	var chg git.Change[map[string]form.Form, ballotproto.Outcome]
 	chg = policy.Close(ctx, cloned, &ad, &tally)
+	if ad.DecisionRule != nil {
+		decision := decide(ad, tally)
+		chg.Result.Decision = &decision
+		if !decision.Passed {
+			chg.Result.Summary = string(ballotproto.SummaryRejected)
+		}
+	}
+	settleUnrevealed_StageOnly(ctx, cloned.PublicClone(), ad, tally, &chg.Result, false)
 
 	// write outcome
 	git.ToFileStage(ctx, t, id.OutcomeNS(), chg.Result)
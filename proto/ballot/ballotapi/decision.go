@@ -0,0 +1,44 @@
+package ballotapi
+
+import (
+	"context"
+
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotio"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotproto"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+)
+
+// WouldPass evaluates the decision rule of a ballot against its current tally,
+// reporting whether the ballot would pass if it were closed now.
+// Ballots without a decision rule pass if their leading choice has a positive score.
+// The decision is informational, e.g. for dashboards; it does not affect how the ballot or its motion is closed.
+// Motion views report the decisions of the open ballots of motions.
+func WouldPass(
+	ctx context.Context,
+	addr gov.Address,
+	id ballotproto.BallotID,
+
+) ballotproto.Decision {
+
+	return WouldPass_Local(ctx, gov.Clone(ctx, addr), id)
+}
+
+func WouldPass_Local(
+	ctx context.Context,
+	cloned gov.Cloned,
+	id ballotproto.BallotID,
+
+) ballotproto.Decision {
+
+	ad := ballotio.LoadAd_Local(ctx, cloned.Tree(), id)
+	tally := loadTally_Local(ctx, cloned.Tree(), id)
+	return decide(ad, tally)
+}
+
+func decide(ad ballotproto.Ad, tally ballotproto.Tally) ballotproto.Decision {
+	rule := ballotproto.DecisionRule{}
+	if ad.DecisionRule != nil {
+		rule = *ad.DecisionRule
+	}
+	return rule.Evaluate(tally)
+}
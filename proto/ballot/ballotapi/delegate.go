@@ -0,0 +1,162 @@
+package ballotapi
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotproto"
+	"github.com/gov4git/gov4git/v2/proto/delegation"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/id"
+	"github.com/gov4git/gov4git/v2/proto/member"
+)
+
+// delegateVotes casts votes on behalf of participants who delegated their voting power and did not vote directly.
+// A delegator's votes mirror the direct votes of the delegate at the end of their delegation chain, and are charged to the delegator.
+// Mirrored elections are marked with the delegate, and each of the delegate's elections is mirrored at most once.
+// When a delegator votes directly, the elections mirrored on their behalf are unwound, which refunds their charges,
+// and the delegator is returned with an empty delegate.
+func delegateVotes(
+	ctx context.Context,
+	cloned gov.Cloned,
+	ad ballotproto.Ad,
+	tally *ballotproto.Tally,
+	fetchedVotes FetchedVotes,
+
+) (FetchedVotes, map[member.User]member.User) {
+
+	fetched := fetchedVotesToElections(fetchedVotes)
+	delegated := map[member.User]member.User{}
+
+	// unwind the mirrored elections of delegators who vote directly
+	for u := range fetched {
+		if els := unwindMirroredElections(tally.AcceptedVotes[u]); len(els) > 0 {
+			delegated[u] = ""
+			fetchedVotes = append(
+				FetchedVotes{{
+					Voter:     u,
+					Address:   member.GetUser_Local(ctx, cloned, u).PublicAddress,
+					Elections: els,
+				}},
+				fetchedVotes...,
+			)
+		}
+	}
+
+	delegations := delegation.Index(delegation.List_Local(ctx, cloned))
+	if len(delegations) == 0 {
+		return fetchedVotes, delegated
+	}
+
+	// collect direct votes of all users
+	direct := map[member.User]ballotproto.Elections{}
+	for u, accepted := range tally.AcceptedVotes {
+		for _, acc := range accepted {
+			if acc.Vote.VoteDelegate == "" {
+				direct[u] = append(direct[u], acc.Vote)
+			}
+		}
+	}
+	for u, els := range fetched {
+		direct[u] = append(direct[u], els...)
+	}
+	votedDirectly := func(u member.User) bool {
+		return len(direct[u]) > 0
+	}
+
+	for u := range delegations {
+		if votedDirectly(u) || !member.IsMember_Local(ctx, cloned, u, ad.Participants) {
+			continue
+		}
+		d, ok := delegation.Resolve(ctx, delegations, u, ad.Purpose, votedDirectly)
+		if !ok {
+			continue
+		}
+		delegated[u] = d
+
+		// mirror the delegate's elections that have not been mirrored yet
+		mirrored := map[id.ID]bool{}
+		for _, acc := range tally.AcceptedVotes[u] {
+			mirrored[acc.Vote.VoteID] = true
+		}
+		for _, rej := range tally.RejectedVotes[u] {
+			mirrored[rej.Vote.VoteID] = true
+		}
+		els := ballotproto.Elections{}
+		for _, el := range direct[d] {
+			m := el
+			m.VoteID = delegatedVoteID(el.VoteID, u)
+			m.VoteDelegate = d
+			if !mirrored[m.VoteID] {
+				els = append(els, m)
+			}
+		}
+		if len(els) > 0 {
+			fetchedVotes = append(fetchedVotes,
+				FetchedVote{
+					Voter:     u,
+					Address:   member.GetUser_Local(ctx, cloned, u).PublicAddress,
+					Elections: els,
+				})
+		}
+	}
+
+	return fetchedVotes, delegated
+}
+
+func delegatedVoteID(voteID id.ID, delegator member.User) id.ID {
+	return id.ID(string(voteID) + ":" + string(delegator))
+}
+
+const unwoundVoteIDSuffix = ":unwound"
+
+func unwoundVoteID(voteID id.ID) id.ID {
+	return id.ID(string(voteID) + unwoundVoteIDSuffix)
+}
+
+// unwindMirroredElections returns elections that cancel the strength of the accepted mirrored elections, which have not been unwound yet.
+// Ranked elections are not unwound, since the latest ranking of a voter supersedes earlier ones.
+func unwindMirroredElections(accepted ballotproto.AcceptedElections) ballotproto.Elections {
+	acceptedIDs := map[id.ID]bool{}
+	for _, acc := range accepted {
+		acceptedIDs[acc.Vote.VoteID] = true
+	}
+	els := ballotproto.Elections{}
+	for _, acc := range accepted {
+		m := acc.Vote
+		isMirrored := m.VoteDelegate != "" && !strings.HasSuffix(string(m.VoteID), unwoundVoteIDSuffix)
+		if !isMirrored || len(m.VoteRanking) > 0 || m.VoteStrengthChange == 0 || acceptedIDs[unwoundVoteID(m.VoteID)] {
+			continue
+		}
+		els = append(els, ballotproto.Election{
+			VoteID:             unwoundVoteID(m.VoteID),
+			VoteTime:           time.Now(),
+			VoteChoice:         m.VoteChoice,
+			VoteStrengthChange: -m.VoteStrengthChange,
+			VoteDelegate:       m.VoteDelegate,
+		})
+	}
+	return els
+}
+
+func mergeDelegations(prior, latest map[member.User]member.User) map[member.User]member.User {
+	if len(prior) == 0 && len(latest) == 0 {
+		return nil
+	}
+	r := map[member.User]member.User{}
+	for u, d := range prior {
+		r[u] = d
+	}
+	for u, d := range latest {
+		if d == "" { // the delegator voted directly
+			delete(r, u)
+			continue
+		}
+		r[u] = d
+	}
+	if len(r) == 0 {
+		return nil
+	}
+	return r
+}
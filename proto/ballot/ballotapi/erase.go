@@ -20,11 +20,13 @@ func Erase(
 
 ) git.Change[form.Map, bool] {
 
-	cloned := id.CloneOwner(ctx, id.OwnerAddress(govAddr))
-	chg := Erase_StageOnly(ctx, cloned, ballotID)
-	proto.Commit(ctx, cloned.Public.Tree(), chg)
-	cloned.Public.Push(ctx)
-	return chg
+	return proto.Retry1(ctx, func() git.Change[form.Map, bool] {
+		cloned := id.CloneOwner(ctx, id.OwnerAddress(govAddr))
+		chg := Erase_StageOnly(ctx, cloned, ballotID)
+		proto.Commit(ctx, cloned.Public.Tree(), chg)
+		proto.Push(ctx, cloned.Public)
+		return chg
+	})
 }
 
 func Erase_StageOnly(
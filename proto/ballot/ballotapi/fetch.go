@@ -2,6 +2,7 @@ package ballotapi
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/gov4git/gov4git/v2/proto/ballot/ballotproto"
@@ -14,9 +15,11 @@ import (
 )
 
 type FetchedVote struct {
-	Voter     member.User           `json:"voter_user"`
-	Address   id.PublicAddress      `json:"voter_address"`
-	Elections ballotproto.Elections `json:"voter_elections"`
+	Voter       member.User             `json:"voter_user"`
+	Address     id.PublicAddress        `json:"voter_address"`
+	Elections   ballotproto.Elections   `json:"voter_elections"`
+	Commitments ballotproto.Commitments `json:"voter_commitments,omitempty"` // used by secret ballots
+	Reveals     []ballotproto.Reveal    `json:"voter_reveals,omitempty"`     // used by secret ballots
 }
 
 type FetchedVotes []FetchedVote
@@ -41,7 +44,7 @@ func fetchVotes(
 	account member.UserProfile,
 ) git.Change[form.Map, FetchedVotes] {
 	userCloned := git.CloneOne(ctx, git.Address(account.PublicAddress))
-	return fetchVotesCloned(ctx, cloned, id, user, account, userCloned)
+	return fetchVotesCloned(ctx, cloned, id, user, account, userCloned, nil)
 }
 
 func fetchVotesCloned(
@@ -51,6 +54,7 @@ func fetchVotesCloned(
 	user member.User,
 	account member.UserProfile,
 	userCloned git.Cloned,
+	report *ballotproto.FetchReport, // optional; records invalid vote envelopes
 ) git.Change[form.Map, FetchedVotes] {
 
 	fetched := FetchedVotes{}
@@ -60,27 +64,57 @@ func fetchVotesCloned(
 		req ballotproto.VoteEnvelope,
 	) (resp ballotproto.VoteEnvelope, err error) {
 
-		if !req.VerifyConsistency() {
+		// decrypt sealed envelopes; the response retains the sealed envelope
+		env := req
+		if len(req.Sealed) > 0 {
+			if env, err = unsealVoteEnvelope(ctx, cloned, req); err != nil {
+				return ballotproto.VoteEnvelope{}, fmt.Errorf("vote envelope cannot be decrypted (%w)", err)
+			}
+		}
+
+		if !env.VerifyConsistency() {
 			return ballotproto.VoteEnvelope{}, fmt.Errorf("vote envelope is not valid")
 		}
-		fetched = append(fetched,
-			FetchedVote{
-				Voter:     user,
-				Address:   account.PublicAddress,
-				Elections: req.Elections,
-			})
+		fv := FetchedVote{
+			Voter:     user,
+			Address:   account.PublicAddress,
+			Elections: env.Elections,
+		}
+		if env.Commitment != nil {
+			fv.Commitments = ballotproto.Commitments{*env.Commitment}
+		}
+		if env.Reveal != nil {
+			fv.Reveals = []ballotproto.Reveal{*env.Reveal}
+		}
+		fetched = append(fetched, fv)
 		return req, nil
 	}
 
+	var failed mail.ReceiveFailed
+	if report != nil {
+		failed = func(ctx context.Context, seqNo mail.SeqNo, err error) {
+			kind := ballotproto.InvalidEnvelope
+			if errors.Is(err, mail.ErrSignatureNotValid) {
+				kind = ballotproto.InvalidSignature
+			}
+			r := report.Voter(user)
+			r.Invalid = append(r.Invalid, ballotproto.InvalidVote{SeqNo: int64(seqNo), Kind: kind, Reason: err.Error()})
+		}
+	}
+
 	voterPublicTree := userCloned.Tree()
-	mail.Respond_StageOnly[ballotproto.VoteEnvelope, ballotproto.VoteEnvelope](
+	mail.RespondWithFailures_StageOnly[ballotproto.VoteEnvelope, ballotproto.VoteEnvelope](
 		ctx,
 		cloned.IDOwnerCloned(),
 		account.PublicAddress,
 		voterPublicTree,
 		ballotproto.BallotTopic(id),
 		respond,
+		failed,
 	)
+	if report != nil && len(fetched) > 0 {
+		report.Voter(user).Fetched += len(fetched)
+	}
 
 	return git.NewChange(
 		fmt.Sprintf("Fetched votes from user %v on ballot %v", user, id),
@@ -90,3 +124,12 @@ func fetchVotesCloned(
 		nil,
 	)
 }
+
+func unsealVoteEnvelope(
+	ctx context.Context,
+	cloned gov.OwnerCloned,
+	env ballotproto.VoteEnvelope,
+) (ballotproto.VoteEnvelope, error) {
+
+	return ballotproto.UnsealEnvelope(ctx, id.GetOwnerCredentials(ctx, cloned.IDOwnerCloned()), env)
+}
@@ -20,11 +20,13 @@ func Freeze(
 
 ) git.ChangeNoResult {
 
-	cloned := gov.CloneOwner(ctx, addr)
-	chg := Freeze_StageOnly(ctx, cloned, id)
-	proto.Commit(ctx, cloned.Public.Tree(), chg)
-	cloned.Public.Push(ctx)
-	return chg
+	return proto.Retry1(ctx, func() git.ChangeNoResult {
+		cloned := gov.CloneOwner(ctx, addr)
+		chg := Freeze_StageOnly(ctx, cloned, id)
+		proto.Commit(ctx, cloned.Public.Tree(), chg)
+		proto.Push(ctx, cloned.Public)
+		return chg
+	})
 }
 
 func Freeze_StageOnly(
@@ -38,7 +40,7 @@ func Freeze_StageOnly(
 
 	ad := ballotio.LoadAd_Local(ctx, t, id)
 
-	must.Assertf(ctx, !ad.Closed, "ballot is closed")
+	must.Assert(ctx, !ad.Closed, ballotproto.ErrBallotClosed)
 	must.Assertf(ctx, !ad.Frozen, "ballot already frozen")
 
 	ad.Frozen = true
@@ -0,0 +1,39 @@
+package ballotapi
+
+import (
+	"context"
+
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotproto"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/notice"
+)
+
+func AppendBallotNotices_StageOnly(
+	ctx context.Context,
+	cloned gov.Cloned,
+	id ballotproto.BallotID,
+	notices notice.Notices,
+) {
+
+	queue := notice.LoadNoticeQueue_Local(ctx, cloned, id.NoticesNS())
+	queue.Append(notices...)
+	notice.SaveNoticeQueue_StageOnly(ctx, cloned, id.NoticesNS(), queue)
+}
+
+func LoadBallotNotices(
+	ctx context.Context,
+	addr gov.Address,
+	id ballotproto.BallotID,
+) *notice.NoticeQueue {
+
+	return LoadBallotNotices_Local(ctx, gov.Clone(ctx, addr), id)
+}
+
+func LoadBallotNotices_Local(
+	ctx context.Context,
+	cloned gov.Cloned,
+	id ballotproto.BallotID,
+) *notice.NoticeQueue {
+
+	return notice.LoadNoticeQueue_Local(ctx, cloned, id.NoticesNS())
+}
@@ -3,6 +3,7 @@ package ballotapi
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/gov4git/gov4git/v2/proto"
 	"github.com/gov4git/gov4git/v2/proto/account"
@@ -10,6 +11,7 @@ import (
 	"github.com/gov4git/gov4git/v2/proto/ballot/ballotproto"
 	"github.com/gov4git/gov4git/v2/proto/gov"
 	"github.com/gov4git/gov4git/v2/proto/history/trace"
+	"github.com/gov4git/gov4git/v2/proto/id"
 	"github.com/gov4git/gov4git/v2/proto/member"
 	"github.com/gov4git/gov4git/v2/proto/motion"
 	"github.com/gov4git/gov4git/v2/proto/purpose"
@@ -18,6 +20,60 @@ import (
 	"github.com/gov4git/lib4git/must"
 )
 
+// OpenOption customizes the ad of a ballot being opened.
+type OpenOption func(ctx context.Context, cloned gov.OwnerCloned, ad *ballotproto.Ad)
+
+// WithCommitReveal makes the ballot secret, using commit-reveal voting.
+func WithCommitReveal(cr ballotproto.CommitReveal) OpenOption {
+	return func(ctx context.Context, cloned gov.OwnerCloned, ad *ballotproto.Ad) {
+		must.Assertf(ctx, cr.Deposit >= 0, "commitment deposit must be non-negative")
+		must.Assertf(ctx, cr.Unrevealed.IsValid(), "unknown policy for unrevealed commitments %q", cr.Unrevealed)
+		ad.CommitReveal = &cr
+	}
+}
+
+// WithEncryption requires votes to be encrypted to a ballot key, derived from the community's private credentials.
+// Votes are secret only until they are tallied, as the public tally records the elections of every voter.
+func WithEncryption() OpenOption {
+	return func(ctx context.Context, cloned gov.OwnerCloned, ad *ballotproto.Ad) {
+		cred := id.GetOwnerCredentials(ctx, cloned.IDOwnerCloned())
+		ad.EncryptionKey = id.DeriveBoxPublicKey(ctx, cred, ballotproto.BallotKeyLabel(ad.ID))
+	}
+}
+
+// WithSchedule sets the deadlines of the ballot. Nil deadlines are not set.
+func WithSchedule(opensAt, freezesAt, closesAt *time.Time) OpenOption {
+	return func(ctx context.Context, cloned gov.OwnerCloned, ad *ballotproto.Ad) {
+		if opensAt != nil && freezesAt != nil {
+			must.Assertf(ctx, opensAt.Before(*freezesAt), "ballot must open before it freezes")
+		}
+		if opensAt != nil && closesAt != nil {
+			must.Assertf(ctx, opensAt.Before(*closesAt), "ballot must open before it closes")
+		}
+		if freezesAt != nil && closesAt != nil {
+			must.Assertf(ctx, !closesAt.Before(*freezesAt), "ballot cannot close before it freezes")
+		}
+		ad.OpensAt, ad.FreezesAt, ad.ClosesAt = opensAt, freezesAt, closesAt
+	}
+}
+
+// WithDecisionRule sets the rule that decides whether the ballot passes when it is closed.
+func WithDecisionRule(rule ballotproto.DecisionRule) OpenOption {
+	return func(ctx context.Context, cloned gov.OwnerCloned, ad *ballotproto.Ad) {
+		must.NoError(ctx, rule.Verify())
+		ad.DecisionRule = &rule
+	}
+}
+
+// WithAsset charges voters in the given asset, instead of the asset charged by the ballot policy.
+func WithAsset(asset account.Asset) OpenOption {
+	return func(ctx context.Context, cloned gov.OwnerCloned, ad *ballotproto.Ad) {
+		_, charging := ballotio.LookupPolicy(ctx, ad.Policy).(ballotproto.ChargingPolicy)
+		must.Assertf(ctx, charging, "ballot policy %v does not charge voters", ad.Policy)
+		ad.Asset = asset
+	}
+}
+
 func Open(
 	ctx context.Context,
 	strat ballotproto.PolicyName,
@@ -30,26 +86,30 @@ func Open(
 	description string,
 	choices []string,
 	participants member.Group,
+	opts ...OpenOption,
 
 ) git.Change[form.Map, ballotproto.BallotAddress] {
 
-	cloned := gov.CloneOwner(ctx, addr)
-	chg := Open_StageOnly(
-		ctx,
-		strat,
-		cloned,
-		id,
-		owner,
-		purpose,
-		motionPolicy,
-		title,
-		description,
-		choices,
-		participants,
-	)
-	proto.Commit(ctx, cloned.Public.Tree(), chg)
-	cloned.Public.Push(ctx)
-	return chg
+	return proto.Retry1(ctx, func() git.Change[form.Map, ballotproto.BallotAddress] {
+		cloned := gov.CloneOwner(ctx, addr)
+		chg := Open_StageOnly(
+			ctx,
+			strat,
+			cloned,
+			id,
+			owner,
+			purpose,
+			motionPolicy,
+			title,
+			description,
+			choices,
+			participants,
+			opts...,
+		)
+		proto.Commit(ctx, cloned.Public.Tree(), chg)
+		proto.Push(ctx, cloned.Public)
+		return chg
+	})
 }
 
 func Open_StageOnly(
@@ -64,6 +124,7 @@ func Open_StageOnly(
 	description string,
 	choices []string,
 	participants member.Group,
+	opts ...OpenOption,
 
 ) git.Change[form.Map, ballotproto.BallotAddress] {
 
@@ -109,6 +170,9 @@ func Open_StageOnly(
 		//
 		ParentCommit: git.Head(ctx, cloned.Public.Repo()),
 	}
+	for _, opt := range opts {
+		opt(ctx, cloned, &ad)
+	}
 	git.ToFileStage(ctx, cloned.Public.Tree(), id.AdNS(), ad)
 
 	// initialize tally
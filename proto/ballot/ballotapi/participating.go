@@ -15,6 +15,7 @@ type participatingVoters struct {
 	Voters        []member.User
 	VoterAccounts map[member.User]member.UserProfile
 	VoterClones   map[member.User]git.Cloned
+	Unreachable   map[member.User]error // voters whose repos could not be cloned
 }
 
 func loadParticipatingVoters(ctx context.Context, cloned gov.Cloned, ad ballotproto.Ad) *participatingVoters {
@@ -22,6 +23,7 @@ func loadParticipatingVoters(ctx context.Context, cloned gov.Cloned, ad ballotpr
 	pv.Ad = ad
 	pv.VoterAccounts = map[member.User]member.UserProfile{}
 	pv.VoterClones = map[member.User]git.Cloned{}
+	pv.Unreachable = map[member.User]error{}
 	pv.Voters = member.ListGroupUsers_Local(ctx, cloned, ad.Participants)
 	for _, user := range pv.Voters {
 		account := member.GetUser_Local(ctx, cloned, user)
@@ -31,12 +33,19 @@ func loadParticipatingVoters(ctx context.Context, cloned gov.Cloned, ad ballotpr
 }
 
 // attachVoterClones adds clones for all participating voter accounts.
-// It also removes voter accounts that were not cloned from the structure.
-func (pv *participatingVoters) attachVoterClones(ctx context.Context, votersCloned map[member.User]git.Cloned) {
+// It also removes voter accounts that were not cloned from the structure, and records why their repos were unreachable.
+func (pv *participatingVoters) attachVoterClones(
+	ctx context.Context,
+	votersCloned map[member.User]git.Cloned,
+	votersUnreachable map[member.User]error,
+) {
 	for u := range pv.VoterAccounts {
 		if cloned, clonedOK := votersCloned[u]; clonedOK {
 			pv.VoterClones[u] = cloned
 		} else {
+			if err, ok := votersUnreachable[u]; ok {
+				pv.Unreachable[u] = err
+			}
 			delete(pv.VoterAccounts, u)
 		}
 	}
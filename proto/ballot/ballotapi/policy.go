@@ -42,10 +42,12 @@ func SavePolicyState[PS form.Form](
 
 ) {
 
-	cloned := gov.Clone(ctx, addr)
-	SavePolicyState_StageOnly[PS](ctx, cloned, id, policyState)
-	proto.Commitf(ctx, cloned, "ballot_save_policy_state", "update ballot policy state")
-	cloned.Push(ctx)
+	proto.Retry(ctx, func() {
+		cloned := gov.Clone(ctx, addr)
+		SavePolicyState_StageOnly[PS](ctx, cloned, id, policyState)
+		proto.Commitf(ctx, cloned, "ballot_save_policy_state", "update ballot policy state")
+		proto.Push(ctx, cloned)
+	})
 }
 
 func SavePolicyState_StageOnly[PS form.Form](
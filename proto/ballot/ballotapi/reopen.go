@@ -19,11 +19,13 @@ func Reopen(
 
 ) git.Change[form.Map, form.None] {
 
-	cloned := gov.CloneOwner(ctx, addr)
-	chg := Reopen_StageOnly(ctx, cloned, id)
-	proto.Commit(ctx, cloned.Public.Tree(), chg)
-	cloned.Public.Push(ctx)
-	return chg
+	return proto.Retry1(ctx, func() git.Change[form.Map, form.None] {
+		cloned := gov.CloneOwner(ctx, addr)
+		chg := Reopen_StageOnly(ctx, cloned, id)
+		proto.Commit(ctx, cloned.Public.Tree(), chg)
+		proto.Push(ctx, cloned.Public)
+		return chg
+	})
 }
 
 func Reopen_StageOnly(
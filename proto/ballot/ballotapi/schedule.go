@@ -0,0 +1,110 @@
+package ballotapi
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gov4git/gov4git/v2/proto"
+	"github.com/gov4git/gov4git/v2/proto/account"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotproto"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/history/metric"
+	"github.com/gov4git/gov4git/v2/proto/notice"
+	"github.com/gov4git/lib4git/base"
+	"github.com/gov4git/lib4git/form"
+	"github.com/gov4git/lib4git/git"
+)
+
+// ScheduleResult lists the ballots that were frozen or closed because their deadlines passed.
+type ScheduleResult struct {
+	Frozen []ballotproto.BallotID `json:"frozen"`
+	Closed []ballotproto.BallotID `json:"closed"`
+}
+
+// Schedule freezes and closes all open ballots whose deadlines have passed, after a last tally of their votes.
+func Schedule(
+	ctx context.Context,
+	addr gov.OwnerAddress,
+	now time.Time,
+	maxPar int,
+
+) git.Change[form.Map, ScheduleResult] {
+
+	return proto.Retry1(ctx, func() git.Change[form.Map, ScheduleResult] {
+		cloned := gov.CloneOwner(ctx, addr)
+		chg := Schedule_StageOnly(ctx, cloned, now, maxPar)
+		if len(chg.Result.Frozen) == 0 && len(chg.Result.Closed) == 0 {
+			return chg
+		}
+		proto.Commit(ctx, cloned.Public.Tree(), chg)
+		proto.Push(ctx, cloned.Public)
+		return chg
+	})
+}
+
+func Schedule_StageOnly(
+	ctx context.Context,
+	cloned gov.OwnerCloned,
+	now time.Time,
+	maxPar int, // parallelism for fetching the votes of ballots whose deadlines have passed
+
+) git.Change[form.Map, ScheduleResult] {
+
+	r := ScheduleResult{}
+	tallies := form.Forms{}
+	for _, ad := range ballotproto.FilterOpenClosedAds(false, List_Local(ctx, cloned.PublicClone())) {
+
+		// count the votes cast before the deadline, which may not have been fetched yet
+		if ad.IsCloseDue(now) || ad.IsFreezeDue(now) {
+			tallyChg, _, _ := Tally_StageOnly(ctx, cloned.GovOwnerAddress(), cloned, ad.ID, maxPar)
+			tallies = append(tallies, tallyChg)
+		}
+
+		if ad.IsCloseDue(now) {
+			base.Infof("closing ballot %v, whose deadline %v has passed", ad.ID, *ad.ClosesAt)
+			closeChg := Close_StageOnly(ctx, cloned, ad.ID, account.BurnAccountID)
+			AppendBallotNotices_StageOnly(ctx, cloned.PublicClone(), ad.ID,
+				notice.Noticef(ctx, "This ballot was closed automatically, as its deadline `%v` passed.", ad.ClosesAt.Format(time.RFC3339)),
+			)
+			metric.Log_StageOnly(ctx, cloned.PublicClone(), &metric.Event{
+				Ballot: &metric.BallotEvent{
+					Close: &metric.BallotClose{
+						ID:       metric.BallotID(ad.ID),
+						Policy:   metric.BallotPolicy(ad.Policy),
+						Deadline: *ad.ClosesAt,
+						Receipts: closeChg.Result.RefundedHistoryReceipts(),
+					},
+				},
+			})
+			r.Closed = append(r.Closed, ad.ID)
+			continue
+		}
+
+		if ad.IsFreezeDue(now) {
+			base.Infof("freezing ballot %v, whose deadline %v has passed", ad.ID, *ad.FreezesAt)
+			Freeze_StageOnly(ctx, cloned, ad.ID)
+			AppendBallotNotices_StageOnly(ctx, cloned.PublicClone(), ad.ID,
+				notice.Noticef(ctx, "This ballot was frozen automatically, as its voting deadline `%v` passed.", ad.FreezesAt.Format(time.RFC3339)),
+			)
+			metric.Log_StageOnly(ctx, cloned.PublicClone(), &metric.Event{
+				Ballot: &metric.BallotEvent{
+					Freeze: &metric.BallotFreeze{
+						ID:       metric.BallotID(ad.ID),
+						Policy:   metric.BallotPolicy(ad.Policy),
+						Deadline: *ad.FreezesAt,
+					},
+				},
+			})
+			r.Frozen = append(r.Frozen, ad.ID)
+		}
+	}
+
+	return git.NewChange(
+		fmt.Sprintf("Froze %d and closed %d ballots on schedule", len(r.Frozen), len(r.Closed)),
+		"ballot_schedule",
+		form.Map{"now": now},
+		r,
+		tallies,
+	)
+}
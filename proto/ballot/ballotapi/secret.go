@@ -0,0 +1,302 @@
+package ballotapi
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gov4git/gov4git/v2/proto"
+	"github.com/gov4git/gov4git/v2/proto/account"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotio"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotproto"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/id"
+	"github.com/gov4git/gov4git/v2/proto/mail"
+	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/lib4git/form"
+	"github.com/gov4git/lib4git/git"
+	"github.com/gov4git/lib4git/must"
+	"github.com/gov4git/lib4git/ns"
+)
+
+// commitElections_StageOnly records the secret behind a commitment to the given elections in the voter's private repo,
+// and returns the commitment.
+func commitElections_StageOnly(
+	ctx context.Context,
+	voterOwner id.OwnerCloned,
+	cloned gov.Cloned,
+	ballotID ballotproto.BallotID,
+	elections ballotproto.Elections,
+
+) *ballotproto.Commitment {
+
+	salt := string(id.GenerateRandomID())
+	secret := ballotproto.Secret{
+		Commitment: ballotproto.Commitment{
+			CommitID:   id.GenerateRandomID(),
+			CommitTime: time.Now(),
+			Hash:       ballotproto.CommitElections(salt, elections),
+		},
+		Reveal: ballotproto.Reveal{
+			Salt:      salt,
+			Elections: elections,
+		},
+	}
+	secret.Reveal.CommitID = secret.Commitment.CommitID
+
+	secretLog, secretLogNS := loadSecretVoteLog_Local(ctx, voterOwner, cloned, ballotID)
+	secretLog.Secrets = append(secretLog.Secrets, secret)
+	git.ToFileStage(ctx, voterOwner.Private.Tree(), secretLogNS, secretLog)
+
+	return &secret.Commitment
+}
+
+func loadSecretVoteLog_Local(
+	ctx context.Context,
+	voterOwner id.OwnerCloned,
+	cloned gov.Cloned,
+	ballotID ballotproto.BallotID,
+
+) (ballotproto.SecretVoteLog, ns.NS) {
+
+	govCred := id.GetPublicCredentials(ctx, cloned.Tree())
+	secretLogNS := ballotproto.VoteLogPath(govCred.ID, ballotID)
+	secretLog, err := git.TryFromFile[ballotproto.SecretVoteLog](ctx, voterOwner.Private.Tree(), secretLogNS)
+	if git.IsNotExist(err) {
+		return ballotproto.SecretVoteLog{GovID: govCred.ID, BallotID: ballotID}, secretLogNS
+	}
+	must.NoError(ctx, err)
+	return secretLog, secretLogNS
+}
+
+// Reveal discloses the elections behind all unrevealed commitments of the voter to a frozen secret ballot.
+func Reveal(
+	ctx context.Context,
+	voterAddr id.OwnerAddress,
+	addr gov.Address,
+	ballotID ballotproto.BallotID,
+
+) git.Change[form.Map, []mail.RequestEnvelope[ballotproto.VoteEnvelope]] {
+
+	cloned := gov.Clone(ctx, addr)
+	voterOwner := id.CloneOwner(ctx, voterAddr)
+	chg := Reveal_StageOnly(ctx, voterAddr, voterOwner, cloned, ballotID)
+	proto.Commit(ctx, voterOwner.Private.Tree(), chg)
+	proto.Commit(ctx, voterOwner.Public.Tree(), chg)
+	proto.Push(ctx, voterOwner.Private)
+	proto.Push(ctx, voterOwner.Public)
+
+	return chg
+}
+
+func Reveal_StageOnly(
+	ctx context.Context,
+	voterAddr id.OwnerAddress,
+	voterOwner id.OwnerCloned,
+	cloned gov.Cloned,
+	ballotID ballotproto.BallotID,
+
+) git.Change[form.Map, []mail.RequestEnvelope[ballotproto.VoteEnvelope]] {
+
+	ad := ballotio.LoadAd_Local(ctx, cloned.Tree(), ballotID)
+
+	must.Assertf(ctx, ad.IsSecret(), "ballot is not secret")
+	must.Assert(ctx, !ad.Closed, ballotproto.ErrBallotClosed)
+	must.Assertf(ctx, ad.Frozen, "ballot must be frozen before votes are revealed")
+
+	secretLog, secretLogNS := loadSecretVoteLog_Local(ctx, voterOwner, cloned, ballotID)
+
+	adCommit := git.Head(ctx, cloned.Repo())
+	sent := []mail.RequestEnvelope[ballotproto.VoteEnvelope]{}
+	sendChgs := form.Forms{}
+	for i, secret := range secretLog.Secrets {
+		if secret.Revealed {
+			continue
+		}
+		reveal := secret.Reveal
+		envelope := ballotproto.VoteEnvelope{
+			AdCommit: adCommit,
+			Ad:       ad,
+			Reveal:   &reveal,
+		}
+		sendChg := mail.Request_StageOnly(ctx, voterOwner, cloned.Tree(), ballotproto.BallotTopic(ballotID), envelope)
+		sent = append(sent, sendChg.Result)
+		sendChgs = append(sendChgs, sendChg)
+		secretLog.Secrets[i].Revealed = true
+	}
+	must.Assertf(ctx, len(sent) > 0, "no unrevealed commitments")
+
+	git.ToFileStage(ctx, voterOwner.Private.Tree(), secretLogNS, secretLog)
+
+	return git.NewChange(
+		fmt.Sprintf("Reveal %d committed votes", len(sent)),
+		"ballot_reveal",
+		form.Map{"id": ballotID},
+		sent,
+		sendChgs,
+	)
+}
+
+// commitRevealVotes processes the commitments and reveals fetched from voters of a secret ballot.
+// Commitments are accepted while the ballot is open, and their deposits are escrowed.
+// Reveals are accepted while the ballot is frozen, if they match a prior commitment, in which case the deposit is returned.
+// It returns the revealed elections, and rejects all other elections.
+func commitRevealVotes(
+	ctx context.Context,
+	cloned gov.Cloned,
+	ad ballotproto.Ad,
+	tally *ballotproto.Tally,
+	fetchedVotes FetchedVotes,
+
+) FetchedVotes {
+
+	if tally.Commitments == nil {
+		tally.Commitments = map[member.User]ballotproto.Commitments{}
+	}
+	if tally.RejectedVotes == nil {
+		tally.RejectedVotes = map[member.User]ballotproto.RejectedElections{}
+	}
+	reject := func(u member.User, els ballotproto.Elections, reason string) {
+		for _, el := range els {
+			tally.RejectedVotes[u] = append(
+				tally.RejectedVotes[u],
+				ballotproto.RejectedElection{Time: time.Now(), Vote: el, Reason: reason},
+			)
+		}
+	}
+	// rejected commitments are recorded as elections without a choice, carrying the commitment id
+	rejectCommitment := func(u member.User, c ballotproto.Commitment, reason string) {
+		reject(u, ballotproto.Elections{{VoteID: c.CommitID, VoteTime: c.CommitTime}}, reason)
+	}
+
+	revealed := FetchedVotes{}
+	for _, fv := range fetchedVotes {
+		u := fv.Voter
+		reject(u, fv.Elections, "secret ballot accepts only commitments and reveals")
+
+		for _, c := range fv.Commitments {
+			if ad.Frozen {
+				rejectCommitment(u, c, "ballot is frozen")
+				continue
+			}
+			if err := escrowDeposit(ctx, cloned, ad, u); err != nil {
+				rejectCommitment(u, c, err.Error())
+				continue
+			}
+			tally.Commitments[u] = append(tally.Commitments[u], c)
+		}
+
+		for _, r := range fv.Reveals {
+			if !ad.Frozen {
+				reject(u, r.Elections, "ballot must be frozen before votes are revealed")
+				continue
+			}
+			i := findMatchingCommitment(tally.Commitments[u], r)
+			if i < 0 {
+				reject(u, r.Elections, "reveal does not match a commitment")
+				continue
+			}
+			tally.Commitments[u] = append(tally.Commitments[u][:i:i], tally.Commitments[u][i+1:]...)
+			if len(tally.Commitments[u]) == 0 {
+				delete(tally.Commitments, u)
+			}
+			refundDeposit(ctx, cloned, ad, u, fmt.Sprintf("deposit refund for revealed vote on ballot %v", ad.ID))
+			revealed = append(revealed, FetchedVote{Voter: u, Address: fv.Address, Elections: r.Elections})
+		}
+	}
+
+	return revealed
+}
+
+func findMatchingCommitment(cs ballotproto.Commitments, r ballotproto.Reveal) int {
+	for i, c := range cs {
+		if r.Matches(c) {
+			return i
+		}
+	}
+	return -1
+}
+
+func escrowDeposit(
+	ctx context.Context,
+	cloned gov.Cloned,
+	ad ballotproto.Ad,
+	user member.User,
+
+) error {
+
+	if ad.CommitReveal.Deposit <= 0 {
+		return nil
+	}
+	return account.TryTransfer_StageOnly(
+		ctx,
+		cloned,
+		member.UserAccountID(user),
+		ballotproto.BallotEscrowAccountID(ad.ID),
+		account.H(depositAsset(ctx, ad), ad.CommitReveal.Deposit),
+		fmt.Sprintf("vote commitment deposit for ballot %v", ad.ID),
+	)
+}
+
+func refundDeposit(
+	ctx context.Context,
+	cloned gov.Cloned,
+	ad ballotproto.Ad,
+	user member.User,
+	note string,
+
+) account.Holding {
+
+	deposit := account.H(depositAsset(ctx, ad), ad.CommitReveal.Deposit)
+	if ad.CommitReveal.Deposit <= 0 {
+		return deposit
+	}
+	account.Transfer_StageOnly(
+		ctx,
+		cloned,
+		ballotproto.BallotEscrowAccountID(ad.ID),
+		member.UserAccountID(user),
+		deposit,
+		note,
+	)
+	return deposit
+}
+
+// settleUnrevealed_StageOnly settles the deposits of unrevealed commitments of a secret ballot, when the ballot is closed or cancelled.
+// Deposits are refunded if the ballot is cancelled or its policy is to refund unrevealed commitments.
+// Otherwise, deposits remain in the ballot escrow.
+func settleUnrevealed_StageOnly(
+	ctx context.Context,
+	cloned gov.Cloned,
+	ad ballotproto.Ad,
+	tally ballotproto.Tally,
+	outcome *ballotproto.Outcome,
+	cancelled bool,
+
+) {
+
+	if !ad.IsSecret() || len(tally.Commitments) == 0 {
+		return
+	}
+	outcome.Unrevealed = tally.Commitments
+	if !cancelled && ad.CommitReveal.Unrevealed == ballotproto.UnrevealedPenalize {
+		return
+	}
+	if outcome.Refunded == nil {
+		outcome.Refunded = map[member.User]account.Holding{}
+	}
+	for u, cs := range tally.Commitments {
+		for range cs {
+			deposit := refundDeposit(ctx, cloned, ad, u, fmt.Sprintf("deposit refund for unrevealed vote on ballot %v", ad.ID))
+			if prior, ok := outcome.Refunded[u]; ok {
+				deposit = account.SumHolding(ctx, prior, deposit)
+			}
+			outcome.Refunded[u] = deposit
+		}
+	}
+}
+
+// depositAsset returns the asset in which commitment deposits are made, which is the asset charged to voters on the ballot.
+func depositAsset(ctx context.Context, ad ballotproto.Ad) account.Asset {
+	return ballotproto.BallotChargedAsset(&ad, ballotio.LookupPolicy(ctx, ad.Policy))
+}
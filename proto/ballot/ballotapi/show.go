@@ -6,6 +6,7 @@ import (
 	"github.com/gov4git/gov4git/v2/proto/ballot/ballotio"
 	"github.com/gov4git/gov4git/v2/proto/ballot/ballotproto"
 	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/lib4git/git"
 	"github.com/gov4git/lib4git/must"
 )
 
@@ -40,9 +41,38 @@ func Show_Local(
 		},
 	)
 
+	var decision *ballotproto.Decision
+	if ad.DecisionRule != nil && !ad.Closed {
+		d := decide(ad, tally)
+		decision = &d
+	}
+
 	return ballotproto.AdTallyMargin{
-		Ad:     ad,
-		Tally:  tally,
-		Margin: margin,
+		Ad:       ad,
+		Tally:    tally,
+		Margin:   margin,
+		Decision: decision,
 	}
 }
+
+// LoadFetchReport returns the recorded problems encountered fetching votes on a ballot.
+func LoadFetchReport(
+	ctx context.Context,
+	addr gov.Address,
+	id ballotproto.BallotID,
+
+) ballotproto.FetchReport {
+
+	return LoadFetchReport_Local(ctx, gov.Clone(ctx, addr), id)
+}
+
+func LoadFetchReport_Local(
+	ctx context.Context,
+	cloned gov.Cloned,
+	id ballotproto.BallotID,
+
+) ballotproto.FetchReport {
+
+	report, _ := git.TryFromFile[ballotproto.FetchReport](ctx, cloned.Tree(), id.FetchReportNS())
+	return report
+}
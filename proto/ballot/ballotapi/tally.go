@@ -3,6 +3,7 @@ package ballotapi
 import (
 	"context"
 	"fmt"
+	"math"
 	"time"
 
 	"github.com/gov4git/gov4git/v2/proto"
@@ -17,6 +18,12 @@ import (
 	"github.com/gov4git/lib4git/must"
 )
 
+// TallyReport is a tally, alongside the problems encountered fetching the votes it counted.
+type TallyReport struct {
+	Tally       ballotproto.Tally        `json:"tally"`
+	FetchReport *ballotproto.FetchReport `json:"fetch_report"`
+}
+
 func Tally(
 	ctx context.Context,
 	addr gov.OwnerAddress,
@@ -25,16 +32,32 @@ func Tally(
 
 ) git.Change[form.Map, ballotproto.Tally] {
 
-	cloned := gov.CloneOwner(ctx, addr)
-	chg, changed := Tally_StageOnly(ctx, addr, cloned, id, maxPar)
-	if !changed {
-		return chg
-	}
-	proto.Commit(ctx, cloned.Public.Tree(), chg)
-	cloned.Public.Push(ctx)
+	chg, _ := TallyWithReport(ctx, addr, id, maxPar)
 	return chg
 }
 
+// TallyWithReport is like Tally, and also returns the problems encountered fetching the votes.
+// The report is not part of the tally; it is accumulated into the fetch report recorded with the ballot.
+func TallyWithReport(
+	ctx context.Context,
+	addr gov.OwnerAddress,
+	id ballotproto.BallotID,
+	maxPar int,
+
+) (git.Change[form.Map, ballotproto.Tally], *ballotproto.FetchReport) {
+
+	return proto.Retry2(ctx, func() (git.Change[form.Map, ballotproto.Tally], *ballotproto.FetchReport) {
+		cloned := gov.CloneOwner(ctx, addr)
+		chg, report, changed := Tally_StageOnly(ctx, addr, cloned, id, maxPar)
+		if !changed {
+			return chg, report
+		}
+		proto.Commit(ctx, cloned.Public.Tree(), chg)
+		proto.Push(ctx, cloned.Public)
+		return chg, report
+	})
+}
+
 func Tally_StageOnly(
 	ctx context.Context,
 	addr gov.OwnerAddress,
@@ -42,16 +65,16 @@ func Tally_StageOnly(
 	id ballotproto.BallotID,
 	maxPar int,
 
-) (git.Change[form.Map, ballotproto.Tally], bool) {
+) (git.Change[form.Map, ballotproto.Tally], *ballotproto.FetchReport, bool) {
 
 	t := cloned.Public.Tree()
 	ad := ballotio.LoadAd_Local(ctx, t, id)
 
 	pv := loadParticipatingVoters(ctx, cloned.PublicClone(), ad)
-	votersCloned := clonePar(ctx, pv.VoterAccounts, maxPar)
-	pv.attachVoterClones(ctx, votersCloned)
+	votersCloned, votersUnreachable := clonePar(ctx, pv.VoterAccounts, maxPar)
+	pv.attachVoterClones(ctx, votersCloned, votersUnreachable)
 
-	return TallyVotersCloned_StageOnly(ctx, cloned, id, pv.VoterAccounts, pv.VoterClones)
+	return TallyVotersCloned_StageOnly(ctx, cloned, id, pv.VoterAccounts, pv.VoterClones, pv.Unreachable)
 }
 
 func TallyVoterCloned_StageOnly(
@@ -64,13 +87,15 @@ func TallyVoterCloned_StageOnly(
 
 ) (git.Change[form.Map, ballotproto.Tally], bool) {
 
-	return TallyVotersCloned_StageOnly(
+	chg, _, changed := TallyVotersCloned_StageOnly(
 		ctx,
 		cloned,
 		id,
 		map[member.User]member.UserProfile{voterUser: voterProfile},
 		map[member.User]git.Cloned{voterUser: voterClone},
+		nil,
 	)
+	return chg, changed
 }
 
 func TallyVotersCloned_StageOnly(
@@ -79,21 +104,29 @@ func TallyVotersCloned_StageOnly(
 	id ballotproto.BallotID,
 	voterAccounts map[member.User]member.UserProfile,
 	votersCloned map[member.User]git.Cloned,
+	votersUnreachable map[member.User]error, // participating voters whose repos could not be cloned
 
-) (git.Change[form.Map, ballotproto.Tally], bool) {
+) (git.Change[form.Map, ballotproto.Tally], *ballotproto.FetchReport, bool) {
+
+	report := ballotproto.NewFetchReport()
+	for user, err := range votersUnreachable {
+		report.Voter(user).Unreachable = err.Error()
+	}
 
 	var fetchedVotes FetchedVotes
 	for user, account := range voterAccounts {
-		chg := fetchVotesCloned(ctx, cloned, id, user, account, votersCloned[user])
+		chg := fetchVotesCloned(ctx, cloned, id, user, account, votersCloned[user], report)
 		fetchedVotes = append(fetchedVotes, chg.Result...)
 	}
 
-	return TallyFetchedVotes_StageOnly(
+	chg, changed := tallyFetchedVotes_StageOnly(
 		ctx,
 		cloned.PublicClone(),
 		id,
 		fetchedVotes,
+		report,
 	)
+	return chg, report, changed
 }
 
 func TallyFetchedVotes_StageOnly(
@@ -102,45 +135,86 @@ func TallyFetchedVotes_StageOnly(
 	id ballotproto.BallotID,
 	fetchedVotes FetchedVotes,
 
+) (git.Change[form.Map, ballotproto.Tally], bool) {
+
+	return tallyFetchedVotes_StageOnly(ctx, cloned, id, fetchedVotes, nil)
+}
+
+func tallyFetchedVotes_StageOnly(
+	ctx context.Context,
+	cloned gov.Cloned,
+	id ballotproto.BallotID,
+	fetchedVotes FetchedVotes,
+	report *ballotproto.FetchReport, // optional; if given, rejected elections are added to it and it is recorded in the ballot directory
+
 ) (git.Change[form.Map, ballotproto.Tally], bool) {
 
 	t := cloned.Tree()
 	ad, policy := ballotio.LoadAdPolicy_Local(ctx, t, id)
-	must.Assertf(ctx, !ad.Closed, "ballot is closed")
+	must.Assert(ctx, !ad.Closed, ballotproto.ErrBallotClosed)
 
 	currentTally := loadTally_Local(ctx, t, id)
+	priorRejected := numRejectedVotes(currentTally)
 
-	// if no votes are received, no change in tally occurs
-	if len(fetchedVotes) == 0 {
-		return git.NewChange(
-			"No new votes",
-			"ballot_tally",
-			form.Map{"id": id},
-			currentTally,
-			nil,
-		), false
+	received := len(fetchedVotes) > 0
+
+	// votes cast before the ballot opens, or after its deadlines, are rejected, even if the schedule has not frozen or closed the ballot yet.
+	// votes are judged by the time they were cast, so that votes cast on time are counted by tallies that run after a deadline.
+	now := time.Now()
+	fetchedVotes = rejectOutOfScheduleVotes(ad, fetchedVotes, &currentTally, now)
+
+	// in secret ballots, process commitments and reveals
+	if ad.IsSecret() {
+		fetchedVotes = commitRevealVotes(ctx, cloned, ad, &currentTally, fetchedVotes)
 	}
 
-	// if the ballot is frozen, consume and reject pending votes
-	if ad.Frozen {
-		rejectFetchedVotes(fetchedVotes, currentTally.RejectedVotes)
+	// cast votes on behalf of delegators
+	var delegated map[member.User]member.User
+	if ad.AcceptsElections() {
+		fetchedVotes, delegated = delegateVotes(ctx, cloned, ad, &currentTally, fetchedVotes)
+		received = received || len(fetchedVotes) > 0
+	}
 
-		// write updated tally
-		git.ToFileStage(ctx, t, id.TallyNS(), currentTally)
+	// if no votes are received, no change in tally occurs, unless the tally depends on time
+	timeDependent := ballotproto.IsTimeDependent(policy)
+	if !received && !timeDependent {
+		return noNewVotes_StageOnly(ctx, t, id, report, priorRejected, currentTally)
+	}
 
-		return git.NewChange(
-			"Ballot is frozen, discarding pending votes",
-			"ballot_tally",
-			form.Map{"id": id},
-			currentTally,
-			nil,
-		), true
+	// if the ballot is frozen, consume and reject pending votes
+	if !ad.AcceptsElections() && len(fetchedVotes) > 0 {
+		rejectFetchedVotes(fetchedVotes, currentTally.RejectedVotes, "ballot is frozen")
+		fetchedVotes = nil
+
+		if !timeDependent {
+			// write updated tally
+			git.ToFileStage(ctx, t, id.TallyNS(), currentTally)
+			recordFetchReport_StageOnly(ctx, t, id, report, priorRejected, currentTally)
+
+			return git.NewChange(
+				"Ballot is frozen, discarding pending votes",
+				"ballot_tally",
+				form.Map{"id": id},
+				currentTally,
+				nil,
+			), true
+		}
 	}
 
 	updatedTally := policy.Tally(ctx, cloned, &ad, &currentTally, fetchedVotesToElections(fetchedVotes)).Result
+	updatedTally.Delegations = mergeDelegations(currentTally.Delegations, delegated)
+	updatedTally.Commitments = currentTally.Commitments
+	updatedTally.RejectedVotes = keepRejectedVotes(currentTally.RejectedVotes, updatedTally.RejectedVotes)
+
+	// time-dependent tallies are not re-committed, unless their scores changed significantly,
+	// or this is the last tally before the schedule freezes or closes the ballot
+	if !received && !scoresChanged(currentTally, updatedTally) && !ad.IsFreezeDue(now) && !ad.IsCloseDue(now) {
+		return noNewVotes_StageOnly(ctx, t, id, report, priorRejected, currentTally)
+	}
 
 	// write updated tally
 	git.ToFileStage(ctx, t, id.TallyNS(), updatedTally)
+	recordFetchReport_StageOnly(ctx, t, id, report, priorRejected, updatedTally)
 
 	// log
 	trace.Log_StageOnly(ctx, cloned, &trace.Event{
@@ -158,6 +232,80 @@ func TallyFetchedVotes_StageOnly(
 	), true
 }
 
+// noNewVotes_StageOnly leaves the tally unchanged.
+// Problems fetching votes are recorded, even if no votes are received.
+func noNewVotes_StageOnly(
+	ctx context.Context,
+	t *git.Tree,
+	id ballotproto.BallotID,
+	report *ballotproto.FetchReport,
+	priorRejected map[member.User]int,
+	currentTally ballotproto.Tally,
+
+) (git.Change[form.Map, ballotproto.Tally], bool) {
+
+	if recordFetchReport_StageOnly(ctx, t, id, report, priorRejected, currentTally) {
+		return git.NewChange(
+			fmt.Sprintf("Record vote fetch problems on ballot %v", id),
+			"ballot_tally",
+			form.Map{"id": id},
+			currentTally,
+			nil,
+		), true
+	}
+	return git.NewChange(
+		"No new votes",
+		"ballot_tally",
+		form.Map{"id": id},
+		currentTally,
+		nil,
+	), false
+}
+
+// ReTallyThreshold is the relative change of a score, above which the tally of a time-dependent ballot is re-committed,
+// even though no new votes were received.
+// Smaller changes are not committed, so that open time-dependent ballots do not cause a commit on every tally.
+var ReTallyThreshold = 0.01
+
+// scoresChanged reports whether the scores of a time-dependent tally changed by more than ReTallyThreshold.
+func scoresChanged(prior, updated ballotproto.Tally) bool {
+	if form.SprintJSON(prior.Ad) != form.SprintJSON(updated.Ad) || len(prior.ScoresByUser) != len(updated.ScoresByUser) {
+		return true
+	}
+	if scoreMapChanged(prior.Scores, updated.Scores, func(s float64) float64 { return s }) {
+		return true
+	}
+	for u, ss := range updated.ScoresByUser {
+		priorSS, ok := prior.ScoresByUser[u]
+		if !ok || scoreMapChanged(priorSS, ss, func(s ballotproto.StrengthAndScore) float64 { return s.Score }) {
+			return true
+		}
+		for c, s := range ss {
+			if priorSS[c].Strength != s.Strength {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func scoreMapChanged[S any](prior, updated map[string]S, score func(S) float64) bool {
+	if len(prior) != len(updated) {
+		return true
+	}
+	for c, s := range updated {
+		p, ok := prior[c]
+		if !ok {
+			return true
+		}
+		ps, us := score(p), score(s)
+		if math.Abs(us-ps) > ReTallyThreshold*math.Max(math.Abs(ps), math.Abs(us)) {
+			return true
+		}
+	}
+	return false
+}
+
 func ReTally_StageOnly(
 	ctx context.Context,
 	cloned gov.Cloned,
@@ -166,25 +314,147 @@ func ReTally_StageOnly(
 ) {
 	t := cloned.Tree()
 	ad, policy := ballotio.LoadAdPolicy_Local(ctx, t, id)
-	must.Assertf(ctx, !ad.Closed, "ballot is closed")
+	must.Assert(ctx, !ad.Closed, ballotproto.ErrBallotClosed)
 	currentTally := loadTally_Local(ctx, t, id)
 
 	updatedTally := policy.Tally(ctx, cloned, &ad, &currentTally, nil).Result
+	updatedTally.Delegations = currentTally.Delegations
+	updatedTally.Commitments = currentTally.Commitments
+	updatedTally.RejectedVotes = keepRejectedVotes(currentTally.RejectedVotes, updatedTally.RejectedVotes)
 
 	git.ToFileStage(ctx, t, id.TallyNS(), updatedTally)
 }
 
-func rejectFetchedVotes(fv FetchedVotes, rej map[member.User]ballotproto.RejectedElections) {
-	for _, fv := range fv {
+// outOfScheduleReason returns the reason why votes cast at the given time are not accepted by the ballot's schedule,
+// or the empty string if they are accepted.
+func outOfScheduleReason(ad ballotproto.Ad, castAt time.Time) string {
+	switch {
+	case !ad.HasOpened(castAt):
+		return "ballot is not open yet"
+	case ad.IsCloseDue(castAt):
+		return fmt.Sprintf("ballot closed at %v", ad.ClosesAt.Format(time.RFC3339))
+	case ad.IsFreezeDue(castAt):
+		return fmt.Sprintf("ballot froze at %v", ad.FreezesAt.Format(time.RFC3339))
+	}
+	return ""
+}
+
+// rejectOutOfScheduleVotes rejects the elections and commitments that were cast outside of the ballot's schedule,
+// and returns the remaining votes.
+// Elections are judged by their vote time, and commitments by their commit time; votes without a time are judged by the time of the tally.
+// Reveals are accepted until the ballot is closed.
+func rejectOutOfScheduleVotes(ad ballotproto.Ad, fetched FetchedVotes, tally *ballotproto.Tally, now time.Time) FetchedVotes {
+
+	if tally.RejectedVotes == nil {
+		tally.RejectedVotes = map[member.User]ballotproto.RejectedElections{}
+	}
+	castAt := func(t time.Time) time.Time {
+		if t.IsZero() {
+			return now
+		}
+		return t
+	}
+
+	onTime := FetchedVotes{}
+	for _, fv := range fetched {
+		kept := FetchedVote{Voter: fv.Voter, Address: fv.Address, Reveals: fv.Reveals}
 		for _, el := range fv.Elections {
+			if reason := outOfScheduleReason(ad, castAt(el.VoteTime)); reason != "" {
+				rejectFetchedVotes(FetchedVotes{{Voter: fv.Voter, Elections: ballotproto.Elections{el}}}, tally.RejectedVotes, reason)
+				continue
+			}
+			kept.Elections = append(kept.Elections, el)
+		}
+		for _, c := range fv.Commitments {
+			if reason := outOfScheduleReason(ad, castAt(c.CommitTime)); reason != "" {
+				rejectFetchedVotes(FetchedVotes{{Voter: fv.Voter, Commitments: ballotproto.Commitments{c}}}, tally.RejectedVotes, reason)
+				continue
+			}
+			kept.Commitments = append(kept.Commitments, c)
+		}
+		if len(kept.Elections) > 0 || len(kept.Commitments) > 0 || len(kept.Reveals) > 0 {
+			onTime = append(onTime, kept)
+		}
+	}
+	return onTime
+}
+
+// rejectFetchedVotes rejects fetched elections, commitments and reveals.
+// Rejected commitments are recorded as elections without a choice, carrying the commitment id.
+func rejectFetchedVotes(fv FetchedVotes, rej map[member.User]ballotproto.RejectedElections, reason string) {
+	for _, fv := range fv {
+		els := append(ballotproto.Elections{}, fv.Elections...)
+		for _, c := range fv.Commitments {
+			els = append(els, ballotproto.Election{VoteID: c.CommitID, VoteTime: c.CommitTime})
+		}
+		for _, r := range fv.Reveals {
+			els = append(els, r.Elections...)
+		}
+		for _, el := range els {
 			rej[fv.Voter] = append(
 				rej[fv.Voter],
-				ballotproto.RejectedElection{Time: time.Now(), Vote: el, Reason: "ballot is frozen"},
+				ballotproto.RejectedElection{Time: time.Now(), Vote: el, Reason: reason},
 			)
 		}
 	}
 }
 
+// keepRejectedVotes returns the rejections of the updated tally, along with the prior rejections of users that policies do not tally,
+// e.g. users whose only votes were rejected before the policy was consulted.
+func keepRejectedVotes(prior, updated map[member.User]ballotproto.RejectedElections) map[member.User]ballotproto.RejectedElections {
+	if updated == nil {
+		updated = map[member.User]ballotproto.RejectedElections{}
+	}
+	for u, rej := range prior {
+		if _, ok := updated[u]; !ok {
+			updated[u] = rej
+		}
+	}
+	return updated
+}
+
+func numRejectedVotes(tally ballotproto.Tally) map[member.User]int {
+	n := map[member.User]int{}
+	for u, rej := range tally.RejectedVotes {
+		n[u] = len(rej)
+	}
+	return n
+}
+
+// recordFetchReport_StageOnly adds the elections rejected by the tally to the fetch report,
+// and accumulates the report into the one recorded in the ballot directory.
+// It returns true if the recorded report changed.
+func recordFetchReport_StageOnly(
+	ctx context.Context,
+	t *git.Tree,
+	id ballotproto.BallotID,
+	report *ballotproto.FetchReport,
+	priorRejected map[member.User]int,
+	tally ballotproto.Tally,
+
+) bool {
+
+	if report == nil {
+		return false
+	}
+	for u, rej := range tally.RejectedVotes {
+		if n := priorRejected[u]; len(rej) > n {
+			report.Voter(u).Rejected = rej[n:]
+		}
+	}
+
+	prior, _ := git.TryFromFile[ballotproto.FetchReport](ctx, t, id.FetchReportNS())
+	recorded := report.Accumulate(prior)
+	if len(prior.Voters) == 0 && len(recorded.Voters) == 0 {
+		return false
+	}
+	if form.SprintJSON(prior.Voters) == form.SprintJSON(recorded.Voters) {
+		return false
+	}
+	git.ToFileStage(ctx, t, id.FetchReportNS(), recorded)
+	return true
+}
+
 func loadTally_Local(
 	ctx context.Context,
 	t *git.Tree,
@@ -72,7 +72,7 @@ func Track_StageOnly(
 	// calculate pending votes
 	pendingVotes := map[id.ID]bool{}
 	for _, env := range voteLog.VoteEnvelopes {
-		for _, el := range env.Elections {
+		for _, el := range env.AllElections() {
 			pendingVotes[el.VoteID] = true
 		}
 	}
@@ -86,7 +86,7 @@ func Track_StageOnly(
 	// collect votes in order of execution
 	pending := ballotproto.Elections{}
 	for _, env := range voteLog.VoteEnvelopes {
-		for _, el := range env.Elections {
+		for _, el := range env.AllElections() {
 			if pendingVotes[el.VoteID] {
 				pending = append(pending, el)
 			}
@@ -20,11 +20,13 @@ func Unfreeze(
 
 ) git.ChangeNoResult {
 
-	cloned := gov.CloneOwner(ctx, addr)
-	chg := Unfreeze_StageOnly(ctx, cloned, id)
-	proto.Commit(ctx, cloned.Public.Tree(), chg)
-	cloned.Public.Push(ctx)
-	return chg
+	return proto.Retry1(ctx, func() git.ChangeNoResult {
+		cloned := gov.CloneOwner(ctx, addr)
+		chg := Unfreeze_StageOnly(ctx, cloned, id)
+		proto.Commit(ctx, cloned.Public.Tree(), chg)
+		proto.Push(ctx, cloned.Public)
+		return chg
+	})
 }
 
 func Unfreeze_StageOnly(
@@ -39,7 +41,7 @@ func Unfreeze_StageOnly(
 	// verify ad is present
 	ad := ballotio.LoadAd_Local(ctx, t, id)
 
-	must.Assertf(ctx, !ad.Closed, "ballot is closed")
+	must.Assert(ctx, !ad.Closed, ballotproto.ErrBallotClosed)
 	must.Assertf(ctx, ad.Frozen, "ballot is not frozen")
 
 	ad.Frozen = false
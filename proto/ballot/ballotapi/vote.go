@@ -2,6 +2,8 @@ package ballotapi
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/gov4git/gov4git/v2/proto"
 	"github.com/gov4git/gov4git/v2/proto/ballot/ballotio"
@@ -26,8 +28,13 @@ func Vote(
 	cloned := gov.Clone(ctx, addr)
 	voterOwner := id.CloneOwner(ctx, voterAddr)
 	chg := Vote_StageOnly(ctx, voterAddr, voterOwner, cloned, ballotID, elections)
+	if chg.Result.Request.Ad.IsSecret() {
+		// secret ballots record the committed elections in the voter's private repo
+		proto.Commit(ctx, voterOwner.Private.Tree(), chg)
+		proto.Push(ctx, voterOwner.Private)
+	}
 	proto.Commit(ctx, voterOwner.Public.Tree(), chg)
-	voterOwner.Public.Push(ctx)
+	proto.Push(ctx, voterOwner.Public)
 
 	return chg
 }
@@ -44,8 +51,11 @@ func Vote_StageOnly(
 
 	ad, policy := ballotio.LoadAdPolicy_Local(ctx, cloned.Tree(), ballotID)
 
-	must.Assertf(ctx, !ad.Closed, "ballot is closed")
-	must.Assertf(ctx, !ad.Frozen, "ballot is frozen")
+	must.Assert(ctx, !ad.Closed, ballotproto.ErrBallotClosed)
+	must.Assert(ctx, !ad.Frozen, ballotproto.ErrBallotFrozen)
+	if !ad.HasOpened(time.Now()) {
+		must.Panic(ctx, fmt.Errorf("%w (opens at %v)", ballotproto.ErrBallotNotOpen, ad.OpensAt))
+	}
 
 	verifyElections(ctx, policy, voterAddr, cloned.Address(), voterOwner, cloned, ad, elections)
 	envelope := ballotproto.VoteEnvelope{
@@ -54,6 +64,17 @@ func Vote_StageOnly(
 		Elections: elections,
 	}
 
+	// in secret ballots, publish a commitment in place of the elections
+	if ad.IsSecret() {
+		envelope.Elections = nil
+		envelope.Commitment = commitElections_StageOnly(ctx, voterOwner, cloned, ballotID, elections)
+	}
+
+	// in encrypted ballots, seal the envelope contents to the ballot's key
+	if ad.IsEncrypted() {
+		envelope = ballotproto.SealEnvelope(ctx, envelope)
+	}
+
 	// record vote in voter's repo
 	voterTree := voterOwner.Public.Tree()
 	govCred := id.GetPublicCredentials(ctx, cloned.Tree())
@@ -78,10 +99,18 @@ func Vote_StageOnly(
 
 	// send vote to community by mail
 	sendChg := mail.Request_StageOnly(ctx, voterOwner, cloned.Tree(), ballotproto.BallotTopic(ballotID), envelope)
+
+	// the change is recorded in the commit message in the voter's public repo,
+	// so the elections of secret and encrypted ballots are left out of it
+	query := form.Map{"id": ballotID, "elections": elections}
+	if ad.IsSecret() || ad.IsEncrypted() {
+		delete(query, "elections")
+	}
+
 	return git.NewChange(
 		"Cast vote",
 		"ballot_vote",
-		form.Map{"id": ballotID, "elections": elections},
+		query,
 		sendChg.Result,
 		form.Forms{sendChg},
 	)
@@ -103,7 +132,7 @@ func verifyElections(
 	if len(ad.Choices) > 0 {
 		for _, e := range elections {
 			if !stringIsIn(e.VoteChoice, ad.Choices) {
-				must.Errorf(ctx, "election %v is not an available choice", e.VoteChoice)
+				must.Panic(ctx, fmt.Errorf("election %v is %w", e.VoteChoice, ballotproto.ErrNotAChoice))
 			}
 		}
 	}
@@ -2,7 +2,9 @@ package ballotio
 
 import (
 	"context"
+	"time"
 
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotpolicies/rc"
 	"github.com/gov4git/gov4git/v2/proto/ballot/ballotpolicies/sv"
 	"github.com/gov4git/gov4git/v2/proto/ballot/ballotproto"
 	"github.com/gov4git/gov4git/v2/proto/mod"
@@ -13,9 +15,17 @@ import (
 var policyRegistry = mod.NewModuleRegistry[ballotproto.PolicyName, ballotproto.Policy]()
 
 const (
-	QVPolicyName ballotproto.PolicyName = "qv"
+	QVPolicyName         ballotproto.PolicyName = "qv"
+	LinearPolicyName     ballotproto.PolicyName = "linear"
+	ApprovalPolicyName   ballotproto.PolicyName = "approval"
+	ScorePolicyName      ballotproto.PolicyName = "score"
+	ConvictionPolicyName ballotproto.PolicyName = "conviction"
+	IRVPolicyName        ballotproto.PolicyName = "irv"
+	SchulzePolicyName    ballotproto.PolicyName = "schulze"
 )
 
+const DefaultConvictionHalfLife = 7 * 24 * time.Hour
+
 func init() {
 	ctx := context.Background()
 	Install(
@@ -25,6 +35,48 @@ func init() {
 			Kernel: sv.MakeQVScoreKernel(ctx, 1.0),
 		},
 	)
+	Install(
+		ctx,
+		LinearPolicyName,
+		sv.SV{
+			Kernel: sv.MakeLinearScoreKernel(ctx),
+		},
+	)
+	Install(
+		ctx,
+		ApprovalPolicyName,
+		sv.SV{
+			Kernel: sv.MakeApprovalScoreKernel(ctx, 1.0),
+		},
+	)
+	Install(
+		ctx,
+		ScorePolicyName,
+		sv.SV{
+			Kernel: sv.MakeBoundedScoreKernel(ctx, 10.0, 1.0),
+		},
+	)
+	Install(
+		ctx,
+		ConvictionPolicyName,
+		sv.SV{
+			Kernel: sv.MakeConvictionScoreKernel(ctx, DefaultConvictionHalfLife, sv.MakeLinearScoreKernel(ctx)),
+		},
+	)
+	Install(
+		ctx,
+		IRVPolicyName,
+		rc.RC{
+			Kernel: rc.IRVKernel{},
+		},
+	)
+	Install(
+		ctx,
+		SchulzePolicyName,
+		rc.RC{
+			Kernel: rc.SchulzeKernel{},
+		},
+	)
 }
 
 func Install(ctx context.Context, name ballotproto.PolicyName, policy ballotproto.Policy) {
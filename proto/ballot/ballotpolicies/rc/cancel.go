@@ -0,0 +1,33 @@
+package rc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotproto"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/lib4git/form"
+	"github.com/gov4git/lib4git/git"
+)
+
+func (x RC) Cancel(
+	ctx context.Context,
+	govOwner gov.OwnerCloned,
+	ad *ballotproto.Ad,
+	tally *ballotproto.Tally,
+) git.Change[form.Map, ballotproto.Outcome] {
+
+	// ranked-choice ballots do not charge voters, so there is nothing to refund
+	return git.NewChange(
+		fmt.Sprintf("cancelled ballot %v", ad.ID),
+		"ballot_rc_cancel",
+		form.Map{"id": ad.ID},
+		ballotproto.Outcome{
+			Summary:      "cancelled",
+			Scores:       tally.Scores,
+			ScoresByUser: tally.ScoresByUser,
+			Rounds:       tally.Rounds,
+		},
+		nil,
+	)
+}
@@ -0,0 +1,33 @@
+package rc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotproto"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/lib4git/form"
+	"github.com/gov4git/lib4git/git"
+)
+
+func (x RC) Close(
+	ctx context.Context,
+	govOwner gov.OwnerCloned,
+	ad *ballotproto.Ad,
+	tally *ballotproto.Tally,
+) git.Change[form.Map, ballotproto.Outcome] {
+
+	return git.NewChange(
+		fmt.Sprintf("closed ballot %v", ad.ID),
+		"ballot_rc_close",
+		form.Map{"id": ad.ID},
+		ballotproto.Outcome{
+			Summary:      string(summarize(tally.Rounds)),
+			Scores:       tally.Scores,
+			ScoresByUser: tally.ScoresByUser,
+			Refunded:     nil,
+			Rounds:       tally.Rounds,
+		},
+		nil,
+	)
+}
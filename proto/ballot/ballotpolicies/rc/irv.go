@@ -0,0 +1,121 @@
+package rc
+
+import (
+	"context"
+	"slices"
+
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotproto"
+	"github.com/gov4git/gov4git/v2/proto/member"
+)
+
+// IRVKernel implements instant-runoff voting.
+// In every round, each ranking counts towards its most preferred choice that has not been eliminated.
+// A choice backed by a strict majority of the non-exhausted rankings is elected.
+// Otherwise, the choices with the fewest votes are eliminated and counting proceeds to the next round.
+// If all remaining choices are tied, they are all elected.
+type IRVKernel struct{}
+
+func (k IRVKernel) Help() string {
+	return "Instant-runoff voting: in each round, every ballot counts towards its highest-ranked remaining choice. " +
+		"A choice with a majority of the remaining ballots wins; otherwise the last-placed choice is eliminated."
+}
+
+func (k IRVKernel) Count(
+	ctx context.Context,
+	choices []string,
+	rankings map[member.User]ballotproto.Ranking,
+
+) (map[string]float64, ballotproto.RankingRounds) {
+
+	remaining := slices.Clone(choices)
+	slices.Sort(remaining)
+
+	scores := map[string]float64{}
+	for _, choice := range choices {
+		scores[choice] = 0.0
+	}
+
+	rounds := ballotproto.RankingRounds{}
+	if len(rankings) == 0 || len(remaining) == 0 {
+		return scores, rounds
+	}
+
+	for round := 1; len(remaining) > 0; round++ {
+
+		// count top remaining preferences
+		counts := map[string]float64{}
+		for _, choice := range remaining {
+			counts[choice] = 0.0
+		}
+		exhausted := 0
+		for _, ranking := range rankings {
+			if top, ok := topRemaining(ranking, remaining); ok {
+				counts[top]++
+			} else {
+				exhausted++
+			}
+		}
+		active := float64(len(rankings) - exhausted)
+
+		// the latest round determines the scores
+		for choice := range scores {
+			scores[choice] = counts[choice]
+		}
+
+		r := ballotproto.RankingRound{
+			Round:      round,
+			Counts:     counts,
+			Exhausted:  exhausted,
+			Eliminated: []string{},
+			Elected:    []string{},
+		}
+
+		// check for a majority
+		for _, choice := range remaining {
+			if counts[choice] > active/2 {
+				r.Elected = []string{choice}
+			}
+		}
+		if len(r.Elected) > 0 {
+			rounds = append(rounds, r)
+			break
+		}
+
+		// eliminate the choices with the fewest votes
+		least := counts[remaining[0]]
+		for _, choice := range remaining {
+			least = min(least, counts[choice])
+		}
+		survivors := []string{}
+		for _, choice := range remaining {
+			if counts[choice] == least {
+				r.Eliminated = append(r.Eliminated, choice)
+			} else {
+				survivors = append(survivors, choice)
+			}
+		}
+
+		// if every remaining choice is tied, they are all elected
+		if len(survivors) == 0 {
+			if active > 0 {
+				r.Elected, r.Eliminated = r.Eliminated, []string{}
+			}
+			rounds = append(rounds, r)
+			break
+		}
+
+		rounds = append(rounds, r)
+		remaining = survivors
+	}
+
+	return scores, rounds
+}
+
+func topRemaining(ranking ballotproto.Ranking, remaining []string) (string, bool) {
+	for _, choice := range ranking {
+		if slices.Contains(remaining, choice) {
+			return choice, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,35 @@
+package rc
+
+import (
+	"context"
+
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotproto"
+	"github.com/gov4git/gov4git/v2/proto/member"
+)
+
+// RC is a ranked-choice ballot policy.
+// Voters submit ordered preference lists, and the ranking kernel determines the standings.
+// Ranked-choice ballots do not charge voting credits.
+type RC struct {
+	Kernel RankingKernel
+}
+
+type RankingKernel interface {
+	// Count computes the score of each choice and the round-by-round standings.
+	Count(
+		ctx context.Context,
+		choices []string,
+		rankings map[member.User]ballotproto.Ranking,
+
+	) (scores map[string]float64, rounds ballotproto.RankingRounds)
+
+	// Help returns a human-readable description of the counting method.
+	Help() string
+}
+
+func (x RC) GetKernel(ctx context.Context) RankingKernel {
+	if x.Kernel == nil {
+		return IRVKernel{}
+	}
+	return x.Kernel
+}
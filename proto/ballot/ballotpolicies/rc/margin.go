@@ -0,0 +1,63 @@
+package rc
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotproto"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+)
+
+func (x RC) Margin(
+	ctx context.Context,
+	cloned gov.Cloned,
+	ad *ballotproto.Ad,
+	tally *ballotproto.Tally,
+
+) *ballotproto.Margin {
+
+	return &ballotproto.Margin{
+		Help: &ballotproto.MarginCalculator{
+			Label:       "Help",
+			Description: "Description of ballot and current standings",
+			FnJS: fmt.Sprintf(
+				`function() { return %q }`,
+				x.GetKernel(ctx).Help()+"\n\n"+standings(tally),
+			),
+		},
+	}
+}
+
+func standings(tally *ballotproto.Tally) string {
+
+	if len(tally.Rounds) == 0 {
+		return "No rankings have been counted yet."
+	}
+
+	var w strings.Builder
+	for _, r := range tally.Rounds {
+		choices := []string{}
+		for choice := range r.Counts {
+			choices = append(choices, choice)
+		}
+		slices.Sort(choices)
+		counts := make([]string, len(choices))
+		for i, choice := range choices {
+			counts[i] = fmt.Sprintf("%v: %v", choice, r.Counts[choice])
+		}
+		fmt.Fprintf(&w, "Round %d: %s.", r.Round, strings.Join(counts, ", "))
+		if r.Exhausted > 0 {
+			fmt.Fprintf(&w, " Exhausted ballots: %d.", r.Exhausted)
+		}
+		if len(r.Eliminated) > 0 {
+			fmt.Fprintf(&w, " Eliminated: %s.", strings.Join(r.Eliminated, ", "))
+		}
+		if len(r.Elected) > 0 {
+			fmt.Fprintf(&w, " Leading: %s.", strings.Join(r.Elected, ", "))
+		}
+		w.WriteString("\n")
+	}
+	return w.String()
+}
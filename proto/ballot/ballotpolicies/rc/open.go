@@ -0,0 +1,31 @@
+package rc
+
+import (
+	"context"
+
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotproto"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/member"
+)
+
+func (x RC) Open(
+	ctx context.Context,
+	owner gov.OwnerCloned,
+	ad *ballotproto.Ad,
+
+) *ballotproto.Tally {
+
+	scores := map[string]float64{}
+	for _, choice := range ad.Choices {
+		scores[choice] = 0.0
+	}
+	return &ballotproto.Tally{
+		Ad:            *ad,
+		Scores:        scores,
+		ScoresByUser:  map[member.User]map[string]ballotproto.StrengthAndScore{},
+		AcceptedVotes: map[member.User]ballotproto.AcceptedElections{},
+		RejectedVotes: map[member.User]ballotproto.RejectedElections{},
+		Charges:       map[member.User]float64{},
+		Rounds:        ballotproto.RankingRounds{},
+	}
+}
@@ -0,0 +1,22 @@
+package rc
+
+import (
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotproto"
+)
+
+const (
+	SummaryElected ballotproto.Summary = "elected"
+	SummaryTied    ballotproto.Summary = "tied"
+	SummaryNone    ballotproto.Summary = "none"
+)
+
+func summarize(rounds ballotproto.RankingRounds) ballotproto.Summary {
+	switch len(rounds.Elected()) {
+	case 0:
+		return SummaryNone
+	case 1:
+		return SummaryElected
+	default:
+		return SummaryTied
+	}
+}
@@ -0,0 +1,128 @@
+package rc
+
+import (
+	"context"
+	"slices"
+
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotproto"
+	"github.com/gov4git/gov4git/v2/proto/member"
+)
+
+// SchulzeKernel implements the Schulze (beatpath) method.
+// The score of a choice is the number of other choices it defeats by strongest path.
+// Choices that are not defeated by any other choice are elected.
+//
+// The Schulze method is not round-based.
+// The tally records a single round whose counts are the scores.
+type SchulzeKernel struct{}
+
+func (k SchulzeKernel) Help() string {
+	return "Schulze method: choices are compared pairwise across all ballots. " +
+		"A choice's score is the number of other choices it beats by strongest path; unbeaten choices win."
+}
+
+func (k SchulzeKernel) Count(
+	ctx context.Context,
+	choices []string,
+	rankings map[member.User]ballotproto.Ranking,
+
+) (map[string]float64, ballotproto.RankingRounds) {
+
+	cs := slices.Clone(choices)
+	slices.Sort(cs)
+	n := len(cs)
+
+	scores := map[string]float64{}
+	for _, choice := range cs {
+		scores[choice] = 0.0
+	}
+	if len(rankings) == 0 || n == 0 {
+		return scores, ballotproto.RankingRounds{}
+	}
+
+	// d[i][j] is the number of voters who prefer choice i over choice j
+	d := make([][]int, n)
+	for i := range d {
+		d[i] = make([]int, n)
+	}
+	for _, ranking := range rankings {
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				if i != j && prefers(ranking, cs[i], cs[j]) {
+					d[i][j]++
+				}
+			}
+		}
+	}
+
+	// p[i][j] is the strength of the strongest path from choice i to choice j
+	p := make([][]int, n)
+	for i := range p {
+		p[i] = make([]int, n)
+		for j := 0; j < n; j++ {
+			if i != j && d[i][j] > d[j][i] {
+				p[i][j] = d[i][j]
+			}
+		}
+	}
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if i == j {
+				continue
+			}
+			for k := 0; k < n; k++ {
+				if i != k && j != k {
+					p[j][k] = max(p[j][k], min(p[j][i], p[i][k]))
+				}
+			}
+		}
+	}
+
+	// score choices and find the unbeaten ones
+	elected := []string{}
+	for i := 0; i < n; i++ {
+		unbeaten := true
+		for j := 0; j < n; j++ {
+			if i == j {
+				continue
+			}
+			if p[i][j] > p[j][i] {
+				scores[cs[i]]++
+			}
+			if p[j][i] > p[i][j] {
+				unbeaten = false
+			}
+		}
+		if unbeaten {
+			elected = append(elected, cs[i])
+		}
+	}
+
+	counts := map[string]float64{}
+	for choice, score := range scores {
+		counts[choice] = score
+	}
+	return scores, ballotproto.RankingRounds{
+		{
+			Round:      1,
+			Counts:     counts,
+			Exhausted:  0,
+			Eliminated: []string{},
+			Elected:    elected,
+		},
+	}
+}
+
+// prefers returns true if the ranking places choice a above choice b.
+// Ranked choices are preferred over unranked ones; unranked choices are equally (not) preferred.
+func prefers(ranking ballotproto.Ranking, a, b string) bool {
+	ra, rb := ranking.Rank(a), ranking.Rank(b)
+	switch {
+	case ra == 0:
+		return false
+	case rb == 0:
+		return true
+	default:
+		return ra < rb
+	}
+}
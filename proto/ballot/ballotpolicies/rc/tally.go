@@ -0,0 +1,110 @@
+package rc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotproto"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/history/metric"
+	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/lib4git/form"
+	"github.com/gov4git/lib4git/git"
+)
+
+func (x RC) Tally(
+	ctx context.Context,
+	cloned gov.Cloned,
+	ad *ballotproto.Ad,
+	prior *ballotproto.Tally,
+	fetched map[member.User]ballotproto.Elections, // newly fetched votes from participating users
+
+) git.Change[form.Map, ballotproto.Tally] {
+
+	// compute set of all users
+	users := map[member.User]bool{}
+	for u := range prior.AcceptedVotes {
+		users[u] = true
+	}
+	for u := range fetched {
+		users[u] = true
+	}
+
+	acceptedVotes := map[member.User]ballotproto.AcceptedElections{}
+	rejectedVotes := map[member.User]ballotproto.RejectedElections{}
+	charges := map[member.User]float64{}
+	rankings := map[member.User]ballotproto.Ranking{}
+
+	for u := range users {
+		accepted, rejected := prior.AcceptedVotes[u], prior.RejectedVotes[u]
+		for _, el := range fetched[u] {
+			if err := verifyElection(ad, el); err != nil {
+				rejected = append(rejected, ballotproto.RejectedElection{Time: time.Now(), Vote: el, Reason: err.Error()})
+				continue
+			}
+			accepted = append(accepted, ballotproto.AcceptedElection{Time: time.Now(), Vote: el})
+
+			// metrics
+			metric.Log_StageOnly(
+				ctx,
+				cloned,
+				&metric.Event{
+					Vote: &metric.VoteEvent{
+						By:           u.MetricUser(),
+						Purpose:      ad.Purpose.MetricVotePurpose(),
+						MotionPolicy: metric.MotionPolicy(ad.MotionPolicy),
+						BallotPolicy: metric.BallotPolicy(ad.Policy),
+					},
+				},
+			)
+		}
+		acceptedVotes[u] = accepted
+		rejectedVotes[u] = rejected
+		charges[u] = 0
+		// the latest accepted ranking of a user supersedes their earlier rankings
+		if len(accepted) > 0 {
+			rankings[u] = accepted[len(accepted)-1].Vote.VoteRanking
+		}
+	}
+
+	scores, rounds := x.GetKernel(ctx).Count(ctx, ad.Choices, rankings)
+
+	tally := ballotproto.Tally{
+		Ad:            *ad,
+		Scores:        scores,
+		ScoresByUser:  scoresByUser(ad.Choices, rankings),
+		AcceptedVotes: acceptedVotes,
+		RejectedVotes: rejectedVotes,
+		Charges:       charges,
+		Rounds:        rounds,
+	}
+	return git.NewChange(
+		fmt.Sprintf("Tallied ranked-choice standings for ballot %v", ad.ID),
+		"ballot_rc_tally",
+		form.Map{"id": ad.ID},
+		tally,
+		nil,
+	)
+}
+
+// scoresByUser records, for each user and ranked choice, the rank as strength and the Borda count as score.
+// The Borda count of a choice is the number of choices ranked below it.
+func scoresByUser(
+	choices []string,
+	rankings map[member.User]ballotproto.Ranking,
+) map[member.User]map[string]ballotproto.StrengthAndScore {
+
+	r := map[member.User]map[string]ballotproto.StrengthAndScore{}
+	for u, ranking := range rankings {
+		ss := map[string]ballotproto.StrengthAndScore{}
+		for i, choice := range ranking {
+			ss[choice] = ballotproto.StrengthAndScore{
+				Strength: float64(i + 1),
+				Score:    float64(len(choices) - i - 1),
+			}
+		}
+		r[u] = ss
+	}
+	return r
+}
@@ -0,0 +1,37 @@
+package rc
+
+import (
+	"context"
+
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotproto"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/id"
+	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/lib4git/must"
+)
+
+func (x RC) VerifyElections(
+	ctx context.Context,
+	voterAddr id.OwnerAddress,
+	govAddr gov.Address,
+	voterCloned id.OwnerCloned,
+	govCloned gov.Cloned,
+	ad *ballotproto.Ad,
+	prior *ballotproto.Tally,
+	elections ballotproto.Elections,
+) {
+
+	voterCred := id.GetPublicCredentials(ctx, voterCloned.Public.Tree())
+	user := member.LookupUserByID_Local(ctx, govCloned, voterCred.ID)
+	if len(user) == 0 {
+		must.Errorf(ctx, "cannot find user with id %v in the community", voterCred.ID)
+	}
+
+	for _, el := range elections {
+		must.NoError(ctx, verifyElection(ad, el))
+	}
+}
+
+func verifyElection(ad *ballotproto.Ad, el ballotproto.Election) error {
+	return el.VoteRanking.Verify(ad.Choices)
+}
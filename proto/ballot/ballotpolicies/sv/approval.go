@@ -0,0 +1,104 @@
+package sv
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotproto"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/lib4git/form"
+)
+
+// ApprovalScoreKernel implements approval voting.
+// A choice is approved by a user if the user's aggregate voting strength on the choice is positive.
+// Each approved choice contributes a score of one and costs a fixed number of credits.
+// Withdrawing an approval (bringing the aggregate strength to zero or below) refunds its cost.
+type ApprovalScoreKernel struct {
+	ApprovalCost float64 `json:"approval_cost"`
+}
+
+func MakeApprovalScoreKernel(ctx context.Context, approvalCost float64) ApprovalScoreKernel {
+	return ApprovalScoreKernel{
+		ApprovalCost: max(0, approvalCost),
+	}
+}
+
+func (k ApprovalScoreKernel) Score(
+	ctx context.Context,
+	cloned gov.Cloned,
+	ad *ballotproto.Ad,
+	el ballotproto.AcceptedElections,
+
+) ScoredVotes {
+
+	score := aggregateStrength(el)
+	cost := 0.0
+	for choice, ss := range score {
+		approval := 0.0
+		if ss.Strength > 0 {
+			approval = 1.0
+		}
+		score[choice] = ballotproto.StrengthAndScore{
+			Strength: ss.Strength,
+			Score:    approval,
+		}
+		cost += approval * k.ApprovalCost
+	}
+	return ScoredVotes{Votes: el, Score: score, Cost: cost}
+}
+
+func (k ApprovalScoreKernel) CalcJS(
+	ctx context.Context,
+	cloned gov.Cloned,
+	ad *ballotproto.Ad,
+	tally *ballotproto.Tally,
+
+) *ballotproto.Margin {
+
+	return &ballotproto.Margin{
+		Help: &ballotproto.MarginCalculator{
+			Label:       "Help",
+			Description: "Description of ballot",
+			FnJS: fmt.Sprintf(
+				`function() { return %q }`,
+				fmt.Sprintf("Approving a choice costs `%0.6f` credits and adds `1` to its score. Withdrawing an approval refunds its cost.", k.ApprovalCost),
+			),
+		},
+		Cost: &ballotproto.MarginCalculator{
+			Label:       "Cost",
+			Description: "Additional cost to reach a desired total impact",
+			FnJS:        fmt.Sprintf(approvalCostJSFmt, k.ApprovalCost, form.SprintJSON(tally), currentVoteJS),
+		},
+		Impact: &ballotproto.MarginCalculator{
+			Label:       "Impact",
+			Description: "Additional impact to reach a desired total cost",
+			FnJS:        fmt.Sprintf(approvalImpactJSFmt, k.ApprovalCost, form.SprintJSON(tally), currentVoteJS),
+		},
+		Reward: noRewardCalculator(),
+	}
+}
+
+const (
+	// Additional cost to reach a desired total impact
+	approvalCostJSFmt = `
+	function(voteUser, voteChoice, voteImpact) {
+		let approvalCost = %f;
+		let tally = %s;
+		%s
+		var currentApprovalCost = currentVoteImpact > 0 ? approvalCost : 0.0;
+		var voteCost = voteImpact > 0 ? approvalCost : 0.0;
+		return voteCost - currentApprovalCost;
+	}
+	`
+
+	// Additional impact to reach a desired total cost
+	approvalImpactJSFmt = `
+	function(voteUser, voteChoice, voteCost) {
+		let approvalCost = %f;
+		let tally = %s;
+		%s
+		var voteImpact = voteCost >= approvalCost ? 1.0 : 0.0;
+		return [voteImpact-currentVoteImpact, -currentVoteImpact];
+	}
+	`
+)
@@ -22,7 +22,7 @@ func (qv SV) Cancel(
 	// refund users
 	refunded := map[member.User]account.Holding{}
 	for user, spent := range tally.Charges {
-		refund := account.H(account.PluralAsset, spent)
+		refund := account.H(ballotproto.BallotChargedAsset(ad, qv), spent)
 		account.Transfer_StageOnly(
 			ctx,
 			govOwner.PublicClone(),
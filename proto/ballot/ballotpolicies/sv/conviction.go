@@ -0,0 +1,142 @@
+package sv
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotproto"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/lib4git/form"
+)
+
+// ConvictionScoreKernel implements conviction voting on top of another score kernel.
+// Credits committed to a choice accrue effective strength over time, approaching the committed amount with the given half-life:
+//
+//	conviction(t) = SUM_i strength_i * (1 - 2^(-(t - t_i) / halfLife))
+//
+// where strength_i is the strength change of the i-th accepted election and t_i is the time it was accepted.
+// Withdrawn credits lose conviction at the same rate.
+// The conviction on each choice is scored by the inner kernel, while charges are based on the committed (not accrued) credits.
+type ConvictionScoreKernel struct {
+	HalfLife time.Duration `json:"half_life"`
+	Kernel   ScoreKernel   `json:"-"`
+}
+
+func MakeConvictionScoreKernel(ctx context.Context, halfLife time.Duration, kernel ScoreKernel) ConvictionScoreKernel {
+	if kernel == nil {
+		kernel = MakeLinearScoreKernel(ctx)
+	}
+	return ConvictionScoreKernel{
+		HalfLife: max(time.Second, halfLife),
+		Kernel:   kernel,
+	}
+}
+
+// IsTimeDependent indicates that conviction tallies change with time, even if no new votes are cast.
+func (k ConvictionScoreKernel) IsTimeDependent() bool {
+	return true
+}
+
+// Accrual returns the fraction of committed credits that have turned into conviction after the given duration.
+func (k ConvictionScoreKernel) Accrual(d time.Duration) float64 {
+	if d <= 0 {
+		return 0
+	}
+	return 1 - math.Exp2(-d.Seconds()/k.HalfLife.Seconds())
+}
+
+func (k ConvictionScoreKernel) Score(
+	ctx context.Context,
+	cloned gov.Cloned,
+	ad *ballotproto.Ad,
+	el ballotproto.AcceptedElections,
+
+) ScoredVotes {
+
+	// charge for the committed credits
+	committed := k.Kernel.Score(ctx, cloned, ad, el)
+
+	// score the accrued conviction; conviction stops accruing when the ballot freezes
+	now := time.Now()
+	if ad.FreezesAt != nil && ad.FreezesAt.Before(now) {
+		now = *ad.FreezesAt
+	}
+	accrued := make(ballotproto.AcceptedElections, len(el))
+	for i, e := range el {
+		accrued[i] = e
+		accrued[i].Vote.VoteStrengthChange = e.Vote.VoteStrengthChange * k.Accrual(now.Sub(e.Time))
+	}
+	conviction := k.Kernel.Score(ctx, cloned, ad, accrued)
+
+	score := map[string]ballotproto.StrengthAndScore{}
+	for choice, ss := range committed.Score {
+		score[choice] = ballotproto.StrengthAndScore{
+			Strength: ss.Strength,
+			Score:    conviction.Score[choice].Score,
+		}
+	}
+	return ScoredVotes{Votes: el, Score: score, Cost: committed.Cost}
+}
+
+func (k ConvictionScoreKernel) CalcJS(
+	ctx context.Context,
+	cloned gov.Cloned,
+	ad *ballotproto.Ad,
+	tally *ballotproto.Tally,
+
+) *ballotproto.Margin {
+
+	margin := k.Kernel.CalcJS(ctx, cloned, ad, tally)
+	help := fmt.Sprintf(
+		"Committed credits accrue conviction over time with a half-life of `%v`. "+
+			"Impacts are reached once conviction has fully accrued.",
+		k.HalfLife,
+	)
+	if margin.Help != nil {
+		margin.Help.FnJS = fmt.Sprintf(`function() { return (%s)() + " " + %q }`, margin.Help.FnJS, help)
+	} else {
+		margin.Help = &ballotproto.MarginCalculator{
+			Label:       "Help",
+			Description: "Description of ballot",
+			FnJS:        fmt.Sprintf(`function() { return %q }`, help),
+		}
+	}
+	margin.Forecast = &ballotproto.MarginCalculator{
+		Label:       "Forecast",
+		Description: "Conviction of the voter at a future time, assuming no further votes",
+		FnJS:        fmt.Sprintf(convictionForecastJSFmt, k.HalfLife.Seconds(), form.SprintJSON(tally)),
+	}
+	return margin
+}
+
+const (
+	// Conviction at a future time
+	convictionForecastJSFmt = `
+	function(voteUser, voteChoice, forecastTime) {
+		let halfLifeSeconds = %f;
+		let tally = %s;
+		var at = new Date(forecastTime).getTime();
+		var freezesAt = tally.advertisement.freezes_at;
+		if (freezesAt !== undefined && Date.parse(freezesAt) < at) {
+			at = Date.parse(freezesAt);
+		}
+		var conviction = 0.0;
+		var accepted = tally.accepted_votes[voteUser];
+		if (accepted !== undefined) {
+			for (var i = 0; i < accepted.length; i++) {
+				var vote = accepted[i].accepted_vote;
+				if (vote.vote_choice !== voteChoice) {
+					continue;
+				}
+				var elapsedSeconds = (at - Date.parse(accepted[i].accepted_time)) / 1000.0;
+				if (elapsedSeconds > 0) {
+					conviction += vote.vote_strength_change * (1 - Math.pow(2, -elapsedSeconds / halfLifeSeconds));
+				}
+			}
+		}
+		return conviction;
+	}
+	`
+)
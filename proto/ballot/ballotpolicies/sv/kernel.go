@@ -3,12 +3,22 @@ package sv
 import (
 	"context"
 
+	"github.com/gov4git/gov4git/v2/proto/account"
 	"github.com/gov4git/gov4git/v2/proto/ballot/ballotproto"
 	"github.com/gov4git/gov4git/v2/proto/gov"
 )
 
 type SV struct {
 	Kernel ScoreKernel
+	Asset  account.Asset // asset charged to voters; the plural credit, if empty
+}
+
+// ChargedAsset implements ballotproto.ChargingPolicy.
+func (x SV) ChargedAsset() account.Asset {
+	if x.Asset == "" {
+		return account.PluralAsset
+	}
+	return x.Asset
 }
 
 type ScoreKernel interface {
@@ -41,3 +51,8 @@ func (x SV) GetScorer(ctx context.Context) ScoreKernel {
 	}
 	return x.Kernel
 }
+
+// IsTimeDependent reports whether the score kernel is time-dependent.
+func (x SV) IsTimeDependent() bool {
+	return ballotproto.IsTimeDependent(x.Kernel)
+}
@@ -0,0 +1,86 @@
+package sv
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotproto"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/lib4git/form"
+)
+
+// LinearScoreKernel scores votes linearly: one credit buys one vote.
+type LinearScoreKernel struct{}
+
+func MakeLinearScoreKernel(ctx context.Context) LinearScoreKernel {
+	return LinearScoreKernel{}
+}
+
+func (k LinearScoreKernel) Score(
+	ctx context.Context,
+	cloned gov.Cloned,
+	ad *ballotproto.Ad,
+	el ballotproto.AcceptedElections,
+
+) ScoredVotes {
+
+	score := aggregateStrength(el)
+	cost := 0.0
+	for choice, ss := range score {
+		score[choice] = ballotproto.StrengthAndScore{
+			Strength: ss.Strength,
+			Score:    ss.Strength,
+		}
+		cost += math.Abs(ss.Strength)
+	}
+	return ScoredVotes{Votes: el, Score: score, Cost: cost}
+}
+
+func (k LinearScoreKernel) CalcJS(
+	ctx context.Context,
+	cloned gov.Cloned,
+	ad *ballotproto.Ad,
+	tally *ballotproto.Tally,
+
+) *ballotproto.Margin {
+
+	return &ballotproto.Margin{
+		Help: &ballotproto.MarginCalculator{
+			Label:       "Help",
+			Description: "Description of ballot",
+			FnJS:        fmt.Sprintf(`function() { return %q }`, "The vote impact of `P` credits is `P`."),
+		},
+		Cost: &ballotproto.MarginCalculator{
+			Label:       "Cost",
+			Description: "Additional cost to reach a desired total impact",
+			FnJS:        fmt.Sprintf(linearCostJSFmt, form.SprintJSON(tally), currentVoteJS),
+		},
+		Impact: &ballotproto.MarginCalculator{
+			Label:       "Impact",
+			Description: "Additional impact to reach a desired total cost",
+			FnJS:        fmt.Sprintf(linearImpactJSFmt, form.SprintJSON(tally), currentVoteJS),
+		},
+		Reward: noRewardCalculator(),
+	}
+}
+
+const (
+	// Additional cost to reach a desired total impact
+	linearCostJSFmt = `
+	function(voteUser, voteChoice, voteImpact) {
+		let tally = %s;
+		%s
+		return Math.abs(voteImpact) - currentVoteCost;
+	}
+	`
+
+	// Additional impact to reach a desired total cost
+	linearImpactJSFmt = `
+	function(voteUser, voteChoice, voteCost) {
+		let tally = %s;
+		%s
+		return [voteCost-currentVoteImpact, -voteCost-currentVoteImpact];
+	}
+	`
+)
@@ -17,3 +17,34 @@ func (qv SV) Margin(
 
 	return qv.Kernel.CalcJS(ctx, cloned, ad, tally)
 }
+
+// noRewardCalculator is the reward margin of ballots that do not reward voters.
+func noRewardCalculator() *ballotproto.MarginCalculator {
+	return &ballotproto.MarginCalculator{
+		Label:       "Reward",
+		Description: "Potential reward to the voter, assuming a favorable outcome",
+		FnJS:        noRewardJS,
+	}
+}
+
+const (
+	noRewardJS = `
+	function(voteUser, voteChoice, voteImpact) {
+		return 0;
+	}
+	`
+
+	// currentVoteJS is a preamble for margin functions, which loads the current impact and cost of the user's vote.
+	currentVoteJS = `
+		var currentVoteImpact = 0.0;
+		var currentVoteCost = 0.0;
+		var currentScoresByUser = tally.scores_by_user[voteUser];
+		if (currentScoresByUser !== undefined) {
+			var currentChoiceByUser = currentScoresByUser[voteChoice];
+			if (currentChoiceByUser !== undefined) {
+				currentVoteImpact = currentChoiceByUser.score;
+				currentVoteCost = Math.abs(currentChoiceByUser.strength);
+			}
+		}
+	`
+)
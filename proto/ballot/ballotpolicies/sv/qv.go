@@ -29,12 +29,7 @@ func (k QVScoreKernel) Score(
 ) ScoredVotes {
 
 	// aggregate voting strength on each choice
-	score := map[string]ballotproto.StrengthAndScore{}
-	for _, el := range el {
-		x := score[el.Vote.VoteChoice]
-		x.Strength += el.Vote.VoteStrengthChange
-		score[el.Vote.VoteChoice] = x
-	}
+	score := aggregateStrength(el)
 	// compute score per choice
 	for choice, ss := range score {
 		score[choice] = ballotproto.StrengthAndScore{
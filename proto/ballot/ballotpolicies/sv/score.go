@@ -0,0 +1,104 @@
+package sv
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotproto"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/lib4git/form"
+)
+
+// BoundedScoreKernel implements score (range) voting.
+// A user's score for a choice is their aggregate voting strength on the choice, clamped to the range [0, MaxScore].
+// Each point of score costs CostPerPoint credits.
+// Strength outside the range has no effect and is not charged.
+type BoundedScoreKernel struct {
+	MaxScore     float64 `json:"max_score"`
+	CostPerPoint float64 `json:"cost_per_point"`
+}
+
+func MakeBoundedScoreKernel(ctx context.Context, maxScore float64, costPerPoint float64) BoundedScoreKernel {
+	return BoundedScoreKernel{
+		MaxScore:     max(0, maxScore),
+		CostPerPoint: max(0, costPerPoint),
+	}
+}
+
+func (k BoundedScoreKernel) Score(
+	ctx context.Context,
+	cloned gov.Cloned,
+	ad *ballotproto.Ad,
+	el ballotproto.AcceptedElections,
+
+) ScoredVotes {
+
+	score := aggregateStrength(el)
+	cost := 0.0
+	for choice, ss := range score {
+		s := min(max(ss.Strength, 0), k.MaxScore)
+		score[choice] = ballotproto.StrengthAndScore{
+			Strength: ss.Strength,
+			Score:    s,
+		}
+		cost += s * k.CostPerPoint
+	}
+	return ScoredVotes{Votes: el, Score: score, Cost: cost}
+}
+
+func (k BoundedScoreKernel) CalcJS(
+	ctx context.Context,
+	cloned gov.Cloned,
+	ad *ballotproto.Ad,
+	tally *ballotproto.Tally,
+
+) *ballotproto.Margin {
+
+	return &ballotproto.Margin{
+		Help: &ballotproto.MarginCalculator{
+			Label:       "Help",
+			Description: "Description of ballot",
+			FnJS: fmt.Sprintf(
+				`function() { return %q }`,
+				fmt.Sprintf("Each choice can be scored from `0` to `%0.6f`. Every point of score costs `%0.6f` credits.", k.MaxScore, k.CostPerPoint),
+			),
+		},
+		Cost: &ballotproto.MarginCalculator{
+			Label:       "Cost",
+			Description: "Additional cost to reach a desired total impact",
+			FnJS:        fmt.Sprintf(boundedScoreCostJSFmt, k.MaxScore, k.CostPerPoint, form.SprintJSON(tally), currentVoteJS),
+		},
+		Impact: &ballotproto.MarginCalculator{
+			Label:       "Impact",
+			Description: "Additional impact to reach a desired total cost",
+			FnJS:        fmt.Sprintf(boundedScoreImpactJSFmt, k.MaxScore, k.CostPerPoint, form.SprintJSON(tally), currentVoteJS),
+		},
+		Reward: noRewardCalculator(),
+	}
+}
+
+const (
+	// Additional cost to reach a desired total impact
+	boundedScoreCostJSFmt = `
+	function(voteUser, voteChoice, voteImpact) {
+		let maxScore = %f;
+		let costPerPoint = %f;
+		let tally = %s;
+		%s
+		var voteScore = Math.min(Math.max(voteImpact, 0), maxScore);
+		return voteScore*costPerPoint - currentVoteImpact*costPerPoint;
+	}
+	`
+
+	// Additional impact to reach a desired total cost
+	boundedScoreImpactJSFmt = `
+	function(voteUser, voteChoice, voteCost) {
+		let maxScore = %f;
+		let costPerPoint = %f;
+		let tally = %s;
+		%s
+		var voteScore = costPerPoint > 0 ? Math.min(voteCost / costPerPoint, maxScore) : maxScore;
+		return [voteScore-currentVoteImpact, -currentVoteImpact];
+	}
+	`
+)
@@ -58,8 +58,18 @@ func (qv SV) tally(
 		oldScore, augmentedScore := augmentAndScoreUserVotes(ctx, cloned, ad, qv.GetScorer(ctx), oldVotes, newVotes)
 		costDiff := augmentedScore.Cost - oldScore.Cost
 
+		// users without new votes are re-scored, but not charged
+		if len(newVotes) == 0 {
+			acceptedVotes[u] = oldVotes
+			rejectedVotes[u] = prior.RejectedVotes[u]
+			charges[u] = prior.Charges[u]
+			votesByUser[u] = oldScore.Score
+			continue
+		}
+
 		// try charging the user for the new votes
-		err := chargeUser(ctx, cloned, ad.ID, u, costDiff, fmt.Sprintf("vote charge for ballot %v", ad.ID))
+		charge := account.H(ballotproto.BallotChargedAsset(ad, qv), costDiff)
+		err := chargeUser(ctx, cloned, ad.ID, u, charge, fmt.Sprintf("vote charge for ballot %v", ad.ID))
 		if strict {
 			must.NoError(ctx, err)
 		}
@@ -71,7 +81,7 @@ func (qv SV) tally(
 		} else {
 			acceptedVotes[u] = augmentedScore.Votes
 			rejectedVotes[u] = prior.RejectedVotes[u]
-			charges[u] = prior.Charges[u] + costDiff
+			charges[u] = account.Q(prior.Charges[u]).Add(charge.Quantity).Float64() // keep charges consistent with the escrowed quantities
 			votesByUser[u] = augmentedScore.Score
 
 			// metrics
@@ -87,7 +97,7 @@ func (qv SV) tally(
 						Receipts: metric.OneReceipt(
 							u.MetricAccountID(),
 							metric.ReceiptTypeCharge,
-							account.H(account.PluralAsset, costDiff).MetricHolding(),
+							charge.MetricHolding(),
 						),
 					},
 				},
@@ -117,7 +127,7 @@ func chargeUser(
 	govCloned gov.Cloned,
 	ballotName ballotproto.BallotID,
 	user member.User,
-	charge float64,
+	charge account.Holding,
 	note string,
 ) error {
 
@@ -126,7 +136,7 @@ func chargeUser(
 		govCloned,
 		member.UserAccountID(user),
 		ballotproto.BallotEscrowAccountID(ballotName),
-		account.H(account.PluralAsset, charge),
+		charge,
 		note,
 	)
 }
@@ -52,3 +52,14 @@ func totalScore(choices []string, votesByUser map[member.User]map[string]ballotp
 	}
 	return scores
 }
+
+// aggregateStrength sums the voting strength of the elections on each choice.
+func aggregateStrength(el ballotproto.AcceptedElections) map[string]ballotproto.StrengthAndScore {
+	score := map[string]ballotproto.StrengthAndScore{}
+	for _, el := range el {
+		x := score[el.Vote.VoteChoice]
+		x.Strength += el.Vote.VoteStrengthChange
+		score[el.Vote.VoteChoice] = x
+	}
+	return score
+}
@@ -2,9 +2,11 @@ package ballotproto
 
 import (
 	"sort"
+	"time"
 
 	"github.com/gov4git/gov4git/v2/proto/account"
 	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/id"
 	"github.com/gov4git/gov4git/v2/proto/member"
 	"github.com/gov4git/gov4git/v2/proto/motion"
 	"github.com/gov4git/gov4git/v2/proto/purpose"
@@ -21,17 +23,62 @@ type Ad struct {
 	Title       string `json:"title"`
 	Description string `json:"description"`
 	//
-	Choices      []string     `json:"choices"`
-	Policy       PolicyName   `json:"policy"`
-	Participants member.Group `json:"participants_group"`
+	Choices       []string               `json:"choices"`
+	Policy        PolicyName             `json:"policy"`
+	Participants  member.Group           `json:"participants_group"`
+	CommitReveal  *CommitReveal          `json:"commit_reveal,omitempty"`  // if set, the ballot is secret
+	EncryptionKey id.Curve25519PublicKey `json:"encryption_key,omitempty"` // if set, votes are encrypted to this key
+	DecisionRule  *DecisionRule          `json:"decision_rule,omitempty"`  // if set, evaluated when the ballot is closed
+	Asset         account.Asset          `json:"asset,omitempty"`          // if set, voters are charged in this asset instead of the policy's
 	//
 	Frozen    bool `json:"frozen"` // if frozen, the ballot is not accepting votes
 	Closed    bool `json:"closed"` // closed ballots cannot be re-opened
 	Cancelled bool `json:"cancelled"`
 	//
+	OpensAt   *time.Time `json:"opens_at,omitempty"`   // if set, votes are accepted only after this time
+	FreezesAt *time.Time `json:"freezes_at,omitempty"` // if set, the ballot is frozen automatically after this time
+	ClosesAt  *time.Time `json:"closes_at,omitempty"`  // if set, the ballot is closed automatically after this time
+	//
 	ParentCommit git.CommitHash `json:"parent_commit"`
 }
 
+// IsSecret returns true if votes are cast using commit-reveal.
+func (x Ad) IsSecret() bool {
+	return x.CommitReveal != nil
+}
+
+// IsEncrypted returns true if votes are encrypted to the community's key for the ballot.
+// Encryption keeps elections out of the voters' public repos, but not out of the public tally:
+// once votes are tallied, the tally records the elections and scores of every voter, so their secrecy ends at tally time.
+func (x Ad) IsEncrypted() bool {
+	return len(x.EncryptionKey) > 0
+}
+
+// AcceptsElections returns true if the ballot accepts elections from voters.
+// Open ballots accept elections, unless they are secret, in which case they accept commitments.
+// Frozen secret ballots accept elections, in the form of reveals of prior commitments.
+func (x Ad) AcceptsElections() bool {
+	if x.Closed {
+		return false
+	}
+	return x.Frozen == x.IsSecret()
+}
+
+// HasOpened returns true if the ballot has no opening time, or its opening time has passed.
+func (x Ad) HasOpened(now time.Time) bool {
+	return x.OpensAt == nil || !now.Before(*x.OpensAt)
+}
+
+// IsFreezeDue returns true if the ballot is scheduled to be frozen by the given time, and is not frozen yet.
+func (x Ad) IsFreezeDue(now time.Time) bool {
+	return !x.Closed && !x.Frozen && x.FreezesAt != nil && !now.Before(*x.FreezesAt)
+}
+
+// IsCloseDue returns true if the ballot is scheduled to be closed by the given time, and is not closed yet.
+func (x Ad) IsCloseDue(now time.Time) bool {
+	return !x.Closed && x.ClosesAt != nil && !now.Before(*x.ClosesAt)
+}
+
 type Advertisements []Ad
 
 func (x Advertisements) Len() int {
@@ -0,0 +1,106 @@
+package ballotproto
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/gov4git/gov4git/v2/proto/member"
+)
+
+// DecisionRule specifies the conditions for a ballot to pass.
+// The rule is evaluated against the tally when the ballot is closed, and the decision is recorded in the outcome,
+// whose summary is SummaryRejected if the ballot failed to pass.
+// Closing a ballot settles its escrow regardless of the decision.
+// Motion views report whether the ballots of open motions would pass, if closed now.
+type DecisionRule struct {
+	MinVoters         int     `json:"min_voters,omitempty"`         // quorum: minimum number of voters
+	MinCapitalization float64 `json:"min_capitalization,omitempty"` // minimum total credits spent by voters
+	Supermajority     float64 `json:"supermajority,omitempty"`      // minimum fraction of support for the leading choice, between 0 and 1
+}
+
+func (x DecisionRule) Verify() error {
+	if x.MinVoters < 0 {
+		return fmt.Errorf("minimum number of voters must be non-negative")
+	}
+	if x.MinCapitalization < 0 {
+		return fmt.Errorf("minimum capitalization must be non-negative")
+	}
+	if x.Supermajority < 0 || x.Supermajority > 1 {
+		return fmt.Errorf("supermajority must be between 0 and 1")
+	}
+	return nil
+}
+
+// SummaryRejected is the summary of the outcome of a ballot, which failed to pass its decision rule.
+const SummaryRejected Summary = "rejected"
+
+// Decision is the result of evaluating a decision rule against a tally.
+type Decision struct {
+	Passed  bool     `json:"passed"`
+	Choice  string   `json:"choice,omitempty"`  // the leading choice
+	Support float64  `json:"support"`           // fraction of support for the leading choice
+	Reasons []string `json:"reasons,omitempty"` // reasons for failing to pass
+}
+
+// Evaluate decides whether the ballot passes, given its tally.
+// The leading choice is the one with the highest score, which must be positive.
+// Support for the leading choice is the fraction of positive user scores for it, relative to
+// all user scores against it and all positive user scores for other choices.
+func (x DecisionRule) Evaluate(tally Tally) Decision {
+
+	d := Decision{}
+	if n := tally.NumVoters(); n < x.MinVoters {
+		d.Reasons = append(d.Reasons, fmt.Sprintf("quorum not reached: %d voters, %d required", n, x.MinVoters))
+	}
+	if c := tally.Capitalization(); c < x.MinCapitalization {
+		d.Reasons = append(d.Reasons, fmt.Sprintf("insufficient capitalization: %v credits, %v required", c, x.MinCapitalization))
+	}
+
+	d.Choice = leadingChoice(tally.Scores)
+	if d.Choice == "" || tally.Scores[d.Choice] <= 0 {
+		d.Reasons = append(d.Reasons, "no choice has a positive score")
+	} else {
+		d.Support = support(tally.ScoresByUser, d.Choice)
+		if d.Support < x.Supermajority {
+			d.Reasons = append(d.Reasons, fmt.Sprintf("supermajority not reached: %0.4f support, %0.4f required", d.Support, x.Supermajority))
+		}
+	}
+
+	d.Passed = len(d.Reasons) == 0
+	return d
+}
+
+func leadingChoice(scores map[string]float64) string {
+	choices := make([]string, 0, len(scores))
+	for c := range scores {
+		choices = append(choices, c)
+	}
+	sort.Strings(choices)
+	lead := ""
+	for _, c := range choices {
+		if lead == "" || scores[c] > scores[lead] {
+			lead = c
+		}
+	}
+	return lead
+}
+
+func support(scoresByUser map[member.User]map[string]StrengthAndScore, choice string) float64 {
+	var pro, con float64
+	for _, ss := range scoresByUser {
+		for c, s := range ss {
+			switch {
+			case c == choice && s.Score > 0:
+				pro += s.Score
+			case c == choice:
+				con -= s.Score
+			case s.Score > 0:
+				con += s.Score
+			}
+		}
+	}
+	if pro+con == 0 {
+		return 0
+	}
+	return pro / (pro + con)
+}
@@ -0,0 +1,35 @@
+package ballotproto
+
+import (
+	"testing"
+
+	"github.com/gov4git/gov4git/v2/proto/member"
+)
+
+func TestDecisionRule(t *testing.T) {
+	tally := Tally{
+		Scores: map[string]float64{"x": 2, "y": 1},
+		ScoresByUser: map[member.User]map[string]StrengthAndScore{
+			"u0": {"x": {Score: 3}},
+			"u1": {"x": {Score: -1}},
+			"u2": {"y": {Score: 1}},
+		},
+		AcceptedVotes: map[member.User]AcceptedElections{"u0": nil, "u1": nil, "u2": nil},
+		Charges:       map[member.User]float64{"u0": 9, "u1": 1, "u2": 1},
+	}
+
+	d := DecisionRule{MinVoters: 3, MinCapitalization: 10, Supermajority: 0.6}.Evaluate(tally)
+	if !d.Passed || d.Choice != "x" || d.Support != 0.6 {
+		t.Errorf("expecting x to pass with support 0.6, got %v", d)
+	}
+
+	d = DecisionRule{MinVoters: 4, MinCapitalization: 12, Supermajority: 2.0 / 3.0}.Evaluate(tally)
+	if d.Passed || len(d.Reasons) != 3 {
+		t.Errorf("expecting 3 reasons for failure, got %v", d)
+	}
+
+	d = DecisionRule{}.Evaluate(Tally{})
+	if d.Passed {
+		t.Errorf("expecting empty tally to fail")
+	}
+}
@@ -0,0 +1,10 @@
+package ballotproto
+
+import "errors"
+
+var (
+	ErrBallotClosed  = errors.New("ballot is closed")
+	ErrBallotFrozen  = errors.New("ballot is frozen")
+	ErrBallotNotOpen = errors.New("ballot is not open yet")
+	ErrNotAChoice    = errors.New("not an available choice")
+)
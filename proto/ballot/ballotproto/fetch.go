@@ -0,0 +1,106 @@
+package ballotproto
+
+import (
+	"time"
+
+	"github.com/gov4git/gov4git/v2/proto/member"
+)
+
+// FetchReport explains, for each voter, why votes on a ballot were not received or not counted.
+// The report recorded in the ballot directory accumulates invalid and rejected votes across tallies,
+// while unreachable repos reflect the latest fetch.
+type FetchReport struct {
+	Time   time.Time                         `json:"time"`
+	Voters map[member.User]*VoterFetchReport `json:"voters"`
+}
+
+type VoterFetchReport struct {
+	Unreachable string            `json:"unreachable,omitempty"` // error encountered cloning the voter's public repo
+	Fetched     int               `json:"fetched"`               // number of vote envelopes received
+	Invalid     []InvalidVote     `json:"invalid,omitempty"`     // vote envelopes that could not be received
+	Rejected    RejectedElections `json:"rejected,omitempty"`    // received elections that were not counted
+}
+
+func (x *VoterFetchReport) IsEmpty() bool {
+	return x.Unreachable == "" && x.Fetched == 0 && len(x.Invalid) == 0 && len(x.Rejected) == 0
+}
+
+func (x *VoterFetchReport) hasInvalid(seqNo int64) bool {
+	for _, iv := range x.Invalid {
+		if iv.SeqNo == seqNo {
+			return true
+		}
+	}
+	return false
+}
+
+type InvalidVoteKind string
+
+const (
+	InvalidSignature InvalidVoteKind = "invalid_signature"
+	InvalidEnvelope  InvalidVoteKind = "invalid_envelope"
+)
+
+type InvalidVote struct {
+	SeqNo  int64           `json:"seqno"` // sequence number of the vote envelope in the voter's mailbox
+	Kind   InvalidVoteKind `json:"kind"`
+	Reason string          `json:"reason"`
+}
+
+func NewFetchReport() *FetchReport {
+	return &FetchReport{Time: time.Now(), Voters: map[member.User]*VoterFetchReport{}}
+}
+
+// Voter returns the report for a voter, creating it if necessary.
+func (x *FetchReport) Voter(u member.User) *VoterFetchReport {
+	if x.Voters == nil {
+		x.Voters = map[member.User]*VoterFetchReport{}
+	}
+	r, ok := x.Voters[u]
+	if !ok {
+		r = &VoterFetchReport{}
+		x.Voters[u] = r
+	}
+	return r
+}
+
+func (x *FetchReport) NumProblems() int {
+	n := 0
+	for _, r := range x.Voters {
+		if r.Unreachable != "" {
+			n++
+		}
+		n += len(r.Invalid) + len(r.Rejected)
+	}
+	return n
+}
+
+// Accumulate returns the report to be recorded, after merging the report of the latest fetch into the prior recorded one.
+// Invalid votes already recorded, e.g. trailing messages that are received again by every fetch, are not repeated.
+func (x *FetchReport) Accumulate(prior FetchReport) *FetchReport {
+	acc := &FetchReport{Time: x.Time, Voters: map[member.User]*VoterFetchReport{}}
+	for u, r := range prior.Voters {
+		acc.Voters[u] = &VoterFetchReport{
+			Fetched:  r.Fetched,
+			Invalid:  append([]InvalidVote{}, r.Invalid...),
+			Rejected: append(RejectedElections{}, r.Rejected...),
+		}
+	}
+	for u, r := range x.Voters {
+		a := acc.Voter(u)
+		a.Unreachable = r.Unreachable
+		a.Fetched += r.Fetched
+		for _, iv := range r.Invalid {
+			if !a.hasInvalid(iv.SeqNo) {
+				a.Invalid = append(a.Invalid, iv)
+			}
+		}
+		a.Rejected = append(a.Rejected, r.Rejected...)
+	}
+	for u, r := range acc.Voters {
+		if r.IsEmpty() {
+			delete(acc.Voters, u)
+		}
+	}
+	return acc
+}
@@ -36,6 +36,14 @@ func (x BallotID) PolicyNS() ns.NS {
 	return x.GitNS().Append(PolicyFilebase)
 }
 
+func (x BallotID) NoticesNS() ns.NS {
+	return x.GitNS().Append(NoticesFilebase)
+}
+
+func (x BallotID) FetchReportNS() ns.NS {
+	return x.GitNS().Append(FetchFilebase)
+}
+
 func (x BallotID) GitNS() ns.NS {
 	return BallotKV.KeyNS(BallotNS, x)
 }
@@ -42,11 +42,19 @@ package ballotproto
 //		- voteImpact: total vote impact, desired by the voter
 //	Result of the "reward" function:
 //		- the _potential_ reward to the voter, assuming a favorable outcome
+//
+//	Arguments of the "forecast" function:
+//		- voteUser: user name of the voter
+//		- voteChoice: ballot choice
+//		- forecastTime: a future time (a JS Date, or milliseconds since the epoch)
+//	Result of the "forecast" function:
+//		- the conviction of the voter on the choice at the given time, assuming no further votes
 type Margin struct {
-	Help   *MarginCalculator `json:"help,omitempty"`
-	Cost   *MarginCalculator `json:"cost,omitempty"`
-	Impact *MarginCalculator `json:"impact,omitempty"`
-	Reward *MarginCalculator `json:"reward,omitempty"`
+	Help     *MarginCalculator `json:"help,omitempty"`
+	Cost     *MarginCalculator `json:"cost,omitempty"`
+	Impact   *MarginCalculator `json:"impact,omitempty"`
+	Reward   *MarginCalculator `json:"reward,omitempty"`
+	Forecast *MarginCalculator `json:"forecast,omitempty"`
 }
 
 type MarginCalculator struct {
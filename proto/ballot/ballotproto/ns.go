@@ -15,6 +15,8 @@ var (
 	TallyFilebase   = "ballot_tally.json"
 	OutcomeFilebase = "ballot_outcome.json"
 	PolicyFilebase  = "ballot_policy.json" // policy instance state
+	NoticesFilebase = "ballot_notices.json"
+	FetchFilebase   = "ballot_fetch_report.json"
 )
 
 var (
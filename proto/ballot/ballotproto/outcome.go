@@ -13,6 +13,9 @@ type Outcome struct {
 	Scores       map[string]float64                          `json:"scores"`
 	ScoresByUser map[member.User]map[string]StrengthAndScore `json:"scores_by_user"`
 	Refunded     map[member.User]account.Holding             `json:"refunded"`
+	Rounds       RankingRounds                               `json:"rounds,omitempty"`     // used by ranked-choice policies
+	Unrevealed   map[member.User]Commitments                 `json:"unrevealed,omitempty"` // used by secret ballots; commitments that were never revealed
+	Decision     *Decision                                   `json:"decision,omitempty"`   // result of the ballot's decision rule, if any
 }
 
 func (o Outcome) RefundedHistoryReceipts() metric.Receipts {
@@ -3,6 +3,7 @@ package ballotproto
 import (
 	"context"
 
+	"github.com/gov4git/gov4git/v2/proto/account"
 	"github.com/gov4git/gov4git/v2/proto/gov"
 	"github.com/gov4git/gov4git/v2/proto/id"
 	"github.com/gov4git/gov4git/v2/proto/member"
@@ -76,3 +77,39 @@ type Policy interface {
 
 	) git.Change[form.Map, form.None]
 }
+
+// TimeDependentPolicy is implemented by policies whose tally changes with time, even if no new votes are cast.
+// Open ballots with time-dependent policies are re-tallied on every tally,
+// but the re-tally is committed only if scores changed significantly (see ballotapi.ReTallyThreshold).
+type TimeDependentPolicy interface {
+	IsTimeDependent() bool
+}
+
+func IsTimeDependent(p any) bool {
+	td, ok := p.(TimeDependentPolicy)
+	return ok && td.IsTimeDependent()
+}
+
+// ChargingPolicy is implemented by policies that charge voters in an asset other than the plural credit.
+type ChargingPolicy interface {
+	ChargedAsset() account.Asset
+}
+
+// ChargedAsset returns the asset in which a policy charges voters, defaulting to the plural credit.
+func ChargedAsset(p any) account.Asset {
+	if cp, ok := p.(ChargingPolicy); ok {
+		if a := cp.ChargedAsset(); a != "" {
+			return a
+		}
+	}
+	return account.PluralAsset
+}
+
+// BallotChargedAsset returns the asset in which voters on a ballot are charged:
+// the asset set in the ballot's ad, if any, or the asset charged by its policy.
+func BallotChargedAsset(ad *Ad, p any) account.Asset {
+	if ad.Asset != "" {
+		return ad.Asset
+	}
+	return ChargedAsset(p)
+}
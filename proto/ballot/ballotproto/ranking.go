@@ -0,0 +1,58 @@
+package ballotproto
+
+import (
+	"fmt"
+
+	"github.com/gov4git/lib4git/util"
+)
+
+// Ranking is an ordered list of ballot choices, from most to least preferred.
+// Choices that are not listed are considered less preferred than all listed choices.
+type Ranking []string
+
+// Verify checks that the ranking is non-empty, uses only the given choices, and has no repetitions.
+func (x Ranking) Verify(choices []string) error {
+	if len(x) == 0 {
+		return fmt.Errorf("ranking is empty")
+	}
+	seen := map[string]bool{}
+	for _, c := range x {
+		if !util.IsIn(c, choices...) {
+			return fmt.Errorf("ranked choice %v is not an available choice", c)
+		}
+		if seen[c] {
+			return fmt.Errorf("choice %v is ranked more than once", c)
+		}
+		seen[c] = true
+	}
+	return nil
+}
+
+// Rank returns the 1-based position of the choice in the ranking, or zero if the choice is not ranked.
+func (x Ranking) Rank(choice string) int {
+	for i, c := range x {
+		if c == choice {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// RankingRound records the standings of a ranked-choice ballot after one round of counting.
+type RankingRound struct {
+	Round      int                `json:"round"`
+	Counts     map[string]float64 `json:"counts"`     // choice -> number of votes (or pairwise wins) in this round
+	Exhausted  int                `json:"exhausted"`  // number of ballots with no remaining ranked choices
+	Eliminated []string           `json:"eliminated"` // choices eliminated at the end of this round
+	Elected    []string           `json:"elected"`    // choices elected in this round (more than one indicates a tie)
+}
+
+type RankingRounds []RankingRound
+
+// Elected returns the choices elected in the last round, if any.
+func (x RankingRounds) Elected() []string {
+	if len(x) == 0 {
+		return nil
+	}
+	return x[len(x)-1].Elected
+}
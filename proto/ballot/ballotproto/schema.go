@@ -21,7 +21,8 @@ type BallotAddress struct {
 }
 
 type AdTallyMargin struct {
-	Ad     Ad      `json:"ballot_advertisement"`
-	Tally  Tally   `json:"ballot_tally"`
-	Margin *Margin `json:"ballot_margin,omitempty"`
+	Ad       Ad        `json:"ballot_advertisement"`
+	Tally    Tally     `json:"ballot_tally"`
+	Margin   *Margin   `json:"ballot_margin,omitempty"`
+	Decision *Decision `json:"ballot_decision,omitempty"` // whether the ballot would pass, if closed now
 }
@@ -0,0 +1,48 @@
+package ballotproto
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gov4git/gov4git/v2/proto/id"
+	"github.com/gov4git/lib4git/form"
+	"github.com/gov4git/lib4git/must"
+)
+
+// BallotKeyLabel is the label used to derive the encryption key of a ballot from the community's private credentials.
+func BallotKeyLabel(ballotID BallotID) string {
+	return "ballot:" + ballotID.String()
+}
+
+// SealEnvelope encrypts the contents of a vote envelope to the encryption key of its ballot.
+// The sealed envelope discloses only the ballot ad.
+func SealEnvelope(ctx context.Context, env VoteEnvelope) VoteEnvelope {
+	must.Assertf(ctx, env.Ad.IsEncrypted(), "ballot does not accept encrypted votes")
+	data, err := form.EncodeBytes(ctx, env)
+	must.NoError(ctx, err)
+	return VoteEnvelope{
+		AdCommit: env.AdCommit,
+		Ad:       env.Ad,
+		Sealed:   id.SealAnonymous(ctx, env.Ad.EncryptionKey, data),
+	}
+}
+
+// UnsealEnvelope decrypts a sealed vote envelope, using the community's private credentials.
+// Envelopes that are not sealed are returned unchanged.
+func UnsealEnvelope(ctx context.Context, priv id.PrivateCredentials, env VoteEnvelope) (VoteEnvelope, error) {
+	if len(env.Sealed) == 0 {
+		return env, nil
+	}
+	data, err := id.OpenAnonymous(ctx, priv, BallotKeyLabel(env.Ad.ID), env.Sealed)
+	if err != nil {
+		return VoteEnvelope{}, err
+	}
+	var unsealed VoteEnvelope
+	if err := form.DecodeBytesInto(ctx, data, &unsealed); err != nil {
+		return VoteEnvelope{}, err
+	}
+	if unsealed.Ad.ID != env.Ad.ID || len(unsealed.Sealed) > 0 {
+		return VoteEnvelope{}, fmt.Errorf("sealed envelope does not match its ballot")
+	}
+	return unsealed, nil
+}
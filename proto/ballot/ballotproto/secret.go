@@ -0,0 +1,75 @@
+package ballotproto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/gov4git/gov4git/v2/proto/id"
+	"github.com/gov4git/lib4git/form"
+)
+
+// CommitReveal configures a secret ballot.
+// While the ballot is open, voters publish only a commitment (a salted hash) of their elections.
+// After the ballot is frozen, voters reveal their elections, and only reveals that match a commitment are tallied.
+type CommitReveal struct {
+	Deposit    float64          `json:"deposit"`    // credits escrowed with each commitment, returned when the commitment is revealed
+	Unrevealed UnrevealedPolicy `json:"unrevealed"` // what happens to the deposits of commitments that are not revealed by closing time
+}
+
+type UnrevealedPolicy string
+
+const (
+	UnrevealedRefund   UnrevealedPolicy = "refund"   // deposits of unrevealed commitments are returned to the voter
+	UnrevealedPenalize UnrevealedPolicy = "penalize" // deposits of unrevealed commitments are forfeited along with the ballot escrow
+)
+
+func (x UnrevealedPolicy) IsValid() bool {
+	return x == UnrevealedRefund || x == UnrevealedPenalize
+}
+
+// Commitment is published by a voter in place of their elections, while a secret ballot is open.
+type Commitment struct {
+	CommitID   id.ID     `json:"commit_id"`
+	CommitTime time.Time `json:"commit_time"`
+	Hash       string    `json:"commit_hash"`
+}
+
+type Commitments []Commitment
+
+// Reveal discloses the elections behind a commitment, after a secret ballot is frozen.
+type Reveal struct {
+	CommitID  id.ID     `json:"commit_id"`
+	Salt      string    `json:"salt"`
+	Elections Elections `json:"elections"`
+}
+
+// Matches returns true if the reveal discloses the elections behind the given commitment.
+func (x Reveal) Matches(c Commitment) bool {
+	return x.CommitID == c.CommitID && CommitElections(x.Salt, x.Elections) == c.Hash
+}
+
+// CommitElections returns the commitment hash of a salted list of elections.
+func CommitElections(salt string, elections Elections) string {
+	buf, err := json.Marshal(struct {
+		Salt      string    `json:"salt"`
+		Elections Elections `json:"elections"`
+	}{salt, elections})
+	if err != nil {
+		panic(err)
+	}
+	return form.BytesHashForFilename(buf)
+}
+
+// SecretVoteLog records the secrets of a user's commitments to a ballot within a given governance.
+// It is kept in the voter's private repo, at the same path as the vote log in the voter's public repo.
+type SecretVoteLog struct {
+	GovID    id.ID    `json:"governance_id"`
+	BallotID BallotID `json:"ballot_id"`
+	Secrets  []Secret `json:"secrets"` // in the order in which they were committed
+}
+
+type Secret struct {
+	Commitment Commitment `json:"commitment"`
+	Reveal     Reveal     `json:"reveal"`
+	Revealed   bool       `json:"revealed"`
+}
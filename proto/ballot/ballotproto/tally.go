@@ -14,6 +14,9 @@ type Tally struct {
 	AcceptedVotes map[member.User]AcceptedElections           `json:"accepted_votes"`
 	RejectedVotes map[member.User]RejectedElections           `json:"rejected_votes"`
 	Charges       map[member.User]float64                     `json:"charges"`
+	Rounds        RankingRounds                               `json:"rounds,omitempty"`      // used by ranked-choice policies
+	Delegations   map[member.User]member.User                 `json:"delegations,omitempty"` // delegator -> delegate who last voted on the delegator's behalf
+	Commitments   map[member.User]Commitments                 `json:"commitments,omitempty"` // used by secret ballots; commitments that have not been revealed yet
 }
 
 func (x Tally) NumVoters() int {
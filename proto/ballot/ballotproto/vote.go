@@ -5,6 +5,7 @@ import (
 
 	"github.com/gov4git/gov4git/v2/proto/gov"
 	"github.com/gov4git/gov4git/v2/proto/id"
+	"github.com/gov4git/gov4git/v2/proto/member"
 	"github.com/gov4git/lib4git/form"
 	"github.com/gov4git/lib4git/git"
 	"github.com/gov4git/lib4git/ns"
@@ -37,10 +38,12 @@ type VoterStatus struct {
 }
 
 type Election struct {
-	VoteID             id.ID     `json:"vote_id"`
-	VoteTime           time.Time `json:"vote_time"`
-	VoteChoice         string    `json:"vote_choice"`
-	VoteStrengthChange float64   `json:"vote_strength_change"` // this is the voter's payment with a sign to indicate direction of vote
+	VoteID             id.ID       `json:"vote_id"`
+	VoteTime           time.Time   `json:"vote_time"`
+	VoteChoice         string      `json:"vote_choice"`
+	VoteStrengthChange float64     `json:"vote_strength_change"`    // this is the voter's payment with a sign to indicate direction of vote
+	VoteRanking        Ranking     `json:"vote_ranking,omitempty"`  // used by ranked-choice policies; the first choice is also recorded in VoteChoice
+	VoteDelegate       member.User `json:"vote_delegate,omitempty"` // if set, the election was cast on the voter's behalf by this delegate
 }
 
 func NewElection(choice string, strength float64) Election {
@@ -52,6 +55,16 @@ func NewElection(choice string, strength float64) Election {
 	}
 }
 
+// NewRankedElection creates an election that expresses an ordered preference over choices.
+func NewRankedElection(ranking Ranking) Election {
+	e := NewElection("", 0)
+	if len(ranking) > 0 {
+		e.VoteChoice = ranking[0]
+	}
+	e.VoteRanking = ranking
+	return e
+}
+
 type Elections []Election
 
 func OneElection(choice string, strength float64) Elections {
@@ -59,19 +72,38 @@ func OneElection(choice string, strength float64) Elections {
 }
 
 type VoteEnvelope struct {
-	AdCommit  git.CommitHash `json:"ballot_ad_commit"`
-	Ad        Ad             `json:"ballot_ad"`
-	Elections Elections      `json:"ballot_elections"`
+	AdCommit   git.CommitHash `json:"ballot_ad_commit"`
+	Ad         Ad             `json:"ballot_ad"`
+	Elections  Elections      `json:"ballot_elections"`
+	Commitment *Commitment    `json:"ballot_commitment,omitempty"` // used by secret ballots, in place of elections
+	Reveal     *Reveal        `json:"ballot_reveal,omitempty"`     // used by secret ballots, to disclose a prior commitment
+	Sealed     form.Bytes     `json:"ballot_sealed,omitempty"`     // used by encrypted ballots, in place of all of the above
 }
 
 type VoteEnvelopes []VoteEnvelope
 
+// AllElections returns the elections in the envelope, including revealed ones.
+func (x VoteEnvelope) AllElections() Elections {
+	if x.Reveal == nil {
+		return x.Elections
+	}
+	return append(append(Elections{}, x.Elections...), x.Reveal.Elections...)
+}
+
 // Verify verifies that elections are consistent with the ballot ad.
 func (x VoteEnvelope) VerifyConsistency() bool {
-	for _, v := range x.Elections {
+	if x.Commitment != nil && x.Reveal != nil {
+		return false
+	}
+	for _, v := range x.AllElections() {
 		if !util.IsIn(v.VoteChoice, x.Ad.Choices...) {
 			return false
 		}
+		for _, c := range v.VoteRanking {
+			if !util.IsIn(c, x.Ad.Choices...) {
+				return false
+			}
+		}
 	}
 	return true
 }
@@ -20,8 +20,8 @@ func Boot(
 
 	ownerCloned := gov.CloneOwner(ctx, ownerAddr)
 	privChg := Boot_Local(ctx, ownerCloned)
-	ownerCloned.Public.Push(ctx)
-	ownerCloned.Private.Push(ctx)
+	proto.Push(ctx, ownerCloned.Public)
+	proto.Push(ctx, ownerCloned.Private)
 	return privChg
 }
 
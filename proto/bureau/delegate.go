@@ -0,0 +1,100 @@
+package bureau
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gov4git/gov4git/v2/proto"
+	"github.com/gov4git/gov4git/v2/proto/delegation"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/id"
+	"github.com/gov4git/gov4git/v2/proto/mail"
+	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/gov4git/v2/proto/purpose"
+	"github.com/gov4git/lib4git/base"
+	"github.com/gov4git/lib4git/form"
+	"github.com/gov4git/lib4git/git"
+	"github.com/gov4git/lib4git/must"
+)
+
+func Delegate(
+	ctx context.Context,
+	userAddr id.OwnerAddress,
+	govAddr gov.Address,
+	fromUserOpt member.User, // optional, if empty string, a lookup for the user is performed
+	toUser member.User,
+	purpose purpose.Purpose,
+	revoke bool,
+) git.Change[form.Map, mail.RequestEnvelope[Request]] {
+
+	govCloned := gov.Clone(ctx, govAddr)
+	userOwner := id.CloneOwner(ctx, userAddr)
+	chg := Delegate_StageOnly(ctx, userAddr, userOwner, govCloned, fromUserOpt, toUser, purpose, revoke)
+	proto.Commit(ctx, userOwner.Public.Tree(), chg)
+	proto.Push(ctx, userOwner.Public)
+	return chg
+}
+
+func Delegate_StageOnly(
+	ctx context.Context,
+	userAddr id.OwnerAddress,
+	userOwner id.OwnerCloned,
+	govCloned gov.Cloned,
+	fromUserOpt member.User,
+	toUser member.User,
+	purpose purpose.Purpose,
+	revoke bool,
+) git.Change[form.Map, mail.RequestEnvelope[Request]] {
+
+	if fromUserOpt == "" {
+		fromUserOpt = member.FindClonedUser_Local(ctx, govCloned, userOwner)
+	}
+	must.Assertf(ctx, revoke || toUser != "", "delegate must be specified")
+
+	request := Request{
+		Delegate: &DelegateRequest{
+			FromUser: fromUserOpt,
+			ToUser:   toUser,
+			Purpose:  purpose,
+			Revoke:   revoke,
+		},
+	}
+
+	sendOnly := mail.Request_StageOnly(ctx, userOwner, govCloned.Tree(), BureauTopic, request)
+	return git.NewChange(
+		"Delegate voting power.",
+		"bureau_delegate",
+		form.Map{
+			"from_user": fromUserOpt,
+			"to_user":   toUser,
+			"purpose":   purpose,
+			"revoke":    revoke,
+		},
+		sendOnly.Result,
+		form.Forms{sendOnly},
+	)
+}
+
+func processDelegate_StageOnly(
+	ctx context.Context,
+	govOwner gov.OwnerCloned,
+	requester member.User,
+	req *DelegateRequest,
+) (Response, error) {
+
+	if req.FromUser != requester {
+		return Response{}, requestErrorf(ErrorUnauthorized, "invalid delegation request from user %v; delegator is not the requesting user", requester)
+	}
+	err := must.Try(func() {
+		if req.Revoke {
+			delegation.Undelegate_StageOnly(ctx, govOwner.PublicClone(), req.FromUser, req.Purpose)
+		} else {
+			delegation.Delegate_StageOnly(ctx, govOwner.PublicClone(), req.FromUser, req.ToUser, req.Purpose)
+		}
+	})
+	if err != nil {
+		return Response{}, fmt.Errorf("delegation error (%w)", err)
+	}
+	base.Infof("bureau: user %v delegation for purpose %q set to %v (revoke=%v)", req.FromUser, req.Purpose, req.ToUser, req.Revoke)
+	return Response{}, nil
+}
@@ -0,0 +1,408 @@
+package bureau
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gov4git/gov4git/v2/proto"
+	"github.com/gov4git/gov4git/v2/proto/account"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/history/trace"
+	"github.com/gov4git/gov4git/v2/proto/id"
+	"github.com/gov4git/gov4git/v2/proto/kv"
+	"github.com/gov4git/gov4git/v2/proto/mail"
+	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/gov4git/v2/proto/motion/motionproto"
+	"github.com/gov4git/lib4git/base"
+	"github.com/gov4git/lib4git/form"
+	"github.com/gov4git/lib4git/git"
+	"github.com/gov4git/lib4git/must"
+)
+
+type EscrowID string
+
+func (x EscrowID) String() string {
+	return string(x)
+}
+
+func GenerateRandomEscrowID() EscrowID {
+	const w = 512 / 8 // 512 bits, measured in bytes
+	buf := make([]byte, w)
+	rand.Read(buf)
+	return EscrowID(strings.ToLower(form.BytesHashForFilename(buf)[:12]))
+}
+
+func EscrowAccountID(id EscrowID) account.AccountID {
+	return account.AccountIDFromLine(account.Pair("bureau_escrow", id.String()))
+}
+
+type EscrowStatus string
+
+const (
+	EscrowHeld     EscrowStatus = "held"
+	EscrowReleased EscrowStatus = "released"
+	EscrowRefunded EscrowStatus = "refunded"
+)
+
+// EscrowState records funds held in escrow by the bureau, on behalf of a user.
+type EscrowState struct {
+	ID        EscrowID             `json:"id"`
+	FromUser  member.User          `json:"from_user"`
+	ToUser    member.User          `json:"to_user"`
+	Holding   account.Holding      `json:"holding"`
+	ReleaseAt time.Time            `json:"release_at"`
+	Motion    motionproto.MotionID `json:"motion,omitempty"`
+	Revocable bool                 `json:"revocable"`
+	Status    EscrowStatus         `json:"status"`
+	CreatedAt time.Time            `json:"created_at"`
+	SettledAt time.Time            `json:"settled_at"`
+}
+
+func (x EscrowState) IsHeld() bool {
+	return x.Status == EscrowHeld
+}
+
+var (
+	escrowNS = proto.RootNS.Append("bureau", "escrow")
+	escrowKV = kv.KV[EscrowID, EscrowState]{}
+)
+
+func Escrow(
+	ctx context.Context,
+	userAddr id.OwnerAddress,
+	govAddr gov.Address,
+	req EscrowRequest, // if FromUser is empty, a lookup for the user is performed; if ID is empty, a random one is generated
+) git.Change[form.Map, mail.RequestEnvelope[Request]] {
+
+	govCloned := gov.Clone(ctx, govAddr)
+	userOwner := id.CloneOwner(ctx, userAddr)
+	chg := Escrow_StageOnly(ctx, userOwner, govCloned, req)
+	proto.Commit(ctx, userOwner.Public.Tree(), chg)
+	proto.Push(ctx, userOwner.Public)
+	return chg
+}
+
+func Escrow_StageOnly(
+	ctx context.Context,
+	userOwner id.OwnerCloned,
+	govCloned gov.Cloned,
+	req EscrowRequest,
+) git.Change[form.Map, mail.RequestEnvelope[Request]] {
+
+	if req.FromUser == "" {
+		req.FromUser = member.FindClonedUser_Local(ctx, govCloned, userOwner)
+	}
+	if req.ID == "" {
+		req.ID = GenerateRandomEscrowID()
+	}
+	must.Assertf(ctx, req.ToUser != "", "escrow recipient must be specified")
+	must.Assertf(ctx, req.Amount > 0, "escrow amount must be positive")
+
+	request := Request{Escrow: &req}
+	sendOnly := mail.Request_StageOnly(ctx, userOwner, govCloned.Tree(), BureauTopic, request)
+	return git.NewChange(
+		"Escrow account tokens.",
+		"bureau_escrow",
+		form.Map{
+			"id":         req.ID,
+			"from_user":  req.FromUser,
+			"to_user":    req.ToUser,
+			"asset":      req.Asset,
+			"amount":     req.Amount,
+			"release_at": req.ReleaseAt,
+			"motion":     req.Motion,
+			"revocable":  req.Revocable,
+		},
+		sendOnly.Result,
+		form.Forms{sendOnly},
+	)
+}
+
+func Settle(
+	ctx context.Context,
+	userAddr id.OwnerAddress,
+	govAddr gov.Address,
+	fromUserOpt member.User, // optional, if empty string, a lookup for the user is performed
+	escrowID EscrowID,
+	revoke bool,
+) git.Change[form.Map, mail.RequestEnvelope[Request]] {
+
+	govCloned := gov.Clone(ctx, govAddr)
+	userOwner := id.CloneOwner(ctx, userAddr)
+	chg := Settle_StageOnly(ctx, userOwner, govCloned, fromUserOpt, escrowID, revoke)
+	proto.Commit(ctx, userOwner.Public.Tree(), chg)
+	proto.Push(ctx, userOwner.Public)
+	return chg
+}
+
+func Settle_StageOnly(
+	ctx context.Context,
+	userOwner id.OwnerCloned,
+	govCloned gov.Cloned,
+	fromUserOpt member.User,
+	escrowID EscrowID,
+	revoke bool,
+) git.Change[form.Map, mail.RequestEnvelope[Request]] {
+
+	if fromUserOpt == "" {
+		fromUserOpt = member.FindClonedUser_Local(ctx, govCloned, userOwner)
+	}
+	must.Assertf(ctx, escrowID != "", "escrow id must be specified")
+
+	request := Request{
+		Settle: &SettleRequest{
+			FromUser: fromUserOpt,
+			ID:       escrowID,
+			Revoke:   revoke,
+		},
+	}
+
+	sendOnly := mail.Request_StageOnly(ctx, userOwner, govCloned.Tree(), BureauTopic, request)
+	return git.NewChange(
+		"Settle escrow.",
+		"bureau_settle",
+		form.Map{
+			"from_user": fromUserOpt,
+			"id":        escrowID,
+			"revoke":    revoke,
+		},
+		sendOnly.Result,
+		form.Forms{sendOnly},
+	)
+}
+
+func GetEscrow(
+	ctx context.Context,
+	addr gov.Address,
+	escrowID EscrowID,
+) EscrowState {
+
+	return GetEscrow_Local(ctx, gov.Clone(ctx, addr), escrowID)
+}
+
+func GetEscrow_Local(
+	ctx context.Context,
+	cloned gov.Cloned,
+	escrowID EscrowID,
+) EscrowState {
+
+	return escrowKV.Get(ctx, escrowNS, cloned.Tree(), escrowID)
+}
+
+func ListEscrows(
+	ctx context.Context,
+	addr gov.Address,
+) []EscrowState {
+
+	return ListEscrows_Local(ctx, gov.Clone(ctx, addr))
+}
+
+func ListEscrows_Local(
+	ctx context.Context,
+	cloned gov.Cloned,
+) []EscrowState {
+
+	if _, err := git.TreeStat(ctx, cloned.Tree(), escrowNS); git.IsNotExist(err) {
+		return nil
+	}
+	ids := escrowKV.ListKeys(ctx, escrowNS, cloned.Tree())
+	slices.Sort(ids)
+	return escrowKV.GetMany(ctx, escrowNS, cloned.Tree(), ids)
+}
+
+func processEscrow_StageOnly(
+	ctx context.Context,
+	govOwner gov.OwnerCloned,
+	requester member.User,
+	req *EscrowRequest,
+) (Response, error) {
+
+	if req.FromUser != requester {
+		return Response{}, requestErrorf(ErrorUnauthorized, "invalid escrow request from user %v; origin of escrow is not the requesting user", requester)
+	}
+	if req.ID == "" {
+		return Response{}, requestErrorf(ErrorInvalid, "escrow id missing")
+	}
+	cloned := govOwner.PublicClone()
+	if escrowKV.Contains(ctx, escrowNS, cloned.Tree(), req.ID) {
+		return Response{}, requestErrorf(ErrorInvalid, "escrow %v already exists", req.ID)
+	}
+	if !member.IsUser_Local(ctx, cloned, req.ToUser) {
+		return Response{}, requestErrorf(ErrorNotFound, "escrow recipient %v is not a user", req.ToUser)
+	}
+	if req.Motion != "" {
+		if !motionproto.MotionKV.Contains(ctx, motionproto.MotionNS, cloned.Tree(), req.Motion) {
+			return Response{}, requestErrorf(ErrorNotFound, "motion %v not found", req.Motion)
+		}
+		if motionproto.MotionKV.Get(ctx, motionproto.MotionNS, cloned.Tree(), req.Motion).Closed {
+			return Response{}, requestErrorf(ErrorInvalid, "motion %v is already closed", req.Motion)
+		}
+	}
+
+	asset := req.GetAsset()
+	e := EscrowState{
+		ID:        req.ID,
+		FromUser:  req.FromUser,
+		ToUser:    req.ToUser,
+		Holding:   account.H(asset, req.Amount),
+		ReleaseAt: req.ReleaseAt,
+		Motion:    req.Motion,
+		Revocable: req.Revocable,
+		Status:    EscrowHeld,
+		CreatedAt: time.Now(),
+	}
+	if err := checkFunds_Local(ctx, cloned, e.FromUser, e.Holding); err != nil {
+		return Response{}, err
+	}
+	err := must.Try(func() {
+		account.VerifyTransferable_Local(ctx, cloned, asset)
+		account.Create_StageOnly(
+			ctx,
+			cloned,
+			EscrowAccountID(e.ID),
+			account.NobodyAccountID,
+			fmt.Sprintf("bureau escrow %v", e.ID),
+		)
+		account.Transfer_StageOnly(
+			ctx,
+			cloned,
+			member.UserAccountID(e.FromUser),
+			EscrowAccountID(e.ID),
+			e.Holding,
+			fmt.Sprintf("bureau escrow %v", e.ID),
+		)
+		escrowKV.Set(ctx, escrowNS, cloned.Tree(), e.ID, e)
+	})
+	if err != nil {
+		return Response{}, fmt.Errorf("escrow error (%w)", err)
+	}
+	base.Infof("bureau: escrowed %v from user %v to user %v as %v", e.Holding, e.FromUser, e.ToUser, e.ID)
+	return Response{Balance: userBalance_Local(ctx, cloned, e.FromUser, asset), Escrow: &e}, nil
+}
+
+func processSettle_StageOnly(
+	ctx context.Context,
+	govOwner gov.OwnerCloned,
+	requester member.User,
+	req *SettleRequest,
+) (Response, error) {
+
+	if req.FromUser != requester {
+		return Response{}, requestErrorf(ErrorUnauthorized, "invalid settle request from user %v; origin of escrow is not the requesting user", requester)
+	}
+	cloned := govOwner.PublicClone()
+	if !escrowKV.Contains(ctx, escrowNS, cloned.Tree(), req.ID) {
+		return Response{}, requestErrorf(ErrorNotFound, "escrow %v not found", req.ID)
+	}
+	e := escrowKV.Get(ctx, escrowNS, cloned.Tree(), req.ID)
+	if e.FromUser != requester {
+		return Response{}, requestErrorf(ErrorUnauthorized, "escrow %v does not belong to user %v", req.ID, requester)
+	}
+	if !e.IsHeld() {
+		return Response{}, requestErrorf(ErrorInvalid, "escrow %v is already %v", req.ID, e.Status)
+	}
+	if req.Revoke && !e.Revocable {
+		return Response{}, requestErrorf(ErrorUnauthorized, "escrow %v is not revocable", req.ID)
+	}
+
+	status := EscrowReleased
+	if req.Revoke {
+		status = EscrowRefunded
+	}
+	if err := must.Try(func() { e = settleEscrow_StageOnly(ctx, cloned, e, status, time.Now()) }); err != nil {
+		return Response{}, fmt.Errorf("settle error (%w)", err)
+	}
+	return Response{Balance: userBalance_Local(ctx, cloned, e.FromUser, e.Holding.Asset), Escrow: &e}, nil
+}
+
+// settleDueEscrows_StageOnly releases held escrows whose conditions are met, and refunds those whose motion did not pass.
+func settleDueEscrows_StageOnly(
+	ctx context.Context,
+	cloned gov.Cloned,
+	now time.Time,
+) (numSettled int) {
+
+	for _, e := range ListEscrows_Local(ctx, cloned) {
+		if !e.IsHeld() {
+			continue
+		}
+		status, due := escrowOutcome_Local(ctx, cloned, e, now)
+		if !due {
+			continue
+		}
+		if err := must.Try(func() { settleEscrow_StageOnly(ctx, cloned, e, status, now) }); err != nil {
+			base.Infof("bureau: settling escrow %v failed (%v)", e.ID, err)
+			continue
+		}
+		numSettled++
+	}
+	return
+}
+
+func escrowOutcome_Local(
+	ctx context.Context,
+	cloned gov.Cloned,
+	e EscrowState,
+	now time.Time,
+) (status EscrowStatus, due bool) {
+
+	if e.Motion != "" {
+		if !motionproto.MotionKV.Contains(ctx, motionproto.MotionNS, cloned.Tree(), e.Motion) {
+			return EscrowRefunded, true
+		}
+		m := motionproto.MotionKV.Get(ctx, motionproto.MotionNS, cloned.Tree(), e.Motion)
+		if !m.Closed {
+			return "", false
+		}
+		if m.Cancelled || !m.Decision.IsAccept() {
+			return EscrowRefunded, true
+		}
+	}
+	if !e.ReleaseAt.IsZero() && now.Before(e.ReleaseAt) {
+		return "", false
+	}
+	if e.Motion == "" && e.ReleaseAt.IsZero() {
+		// without release conditions, escrows are held until settled by their owner
+		return "", false
+	}
+	return EscrowReleased, true
+}
+
+func settleEscrow_StageOnly(
+	ctx context.Context,
+	cloned gov.Cloned,
+	e EscrowState,
+	status EscrowStatus,
+	now time.Time,
+) EscrowState {
+
+	to := e.ToUser
+	if status == EscrowRefunded {
+		to = e.FromUser
+	}
+	account.Transfer_StageOnly(
+		ctx,
+		cloned,
+		EscrowAccountID(e.ID),
+		member.UserAccountID(to),
+		e.Holding,
+		fmt.Sprintf("bureau escrow %v %v", e.ID, status),
+	)
+
+	e.Status = status
+	e.SettledAt = now
+	escrowKV.Set(ctx, escrowNS, cloned.Tree(), e.ID, e)
+
+	trace.Log_StageOnly(ctx, cloned, &trace.Event{
+		Op:     "bureau_escrow_settle",
+		Args:   trace.M{"id": e.ID},
+		Result: trace.M{"escrow": e},
+	})
+
+	base.Infof("bureau: escrow %v %v to user %v", e.ID, status, to)
+	return e
+}
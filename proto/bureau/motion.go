@@ -0,0 +1,246 @@
+package bureau
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"strings"
+
+	"github.com/gov4git/gov4git/v2/proto"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/id"
+	"github.com/gov4git/gov4git/v2/proto/mail"
+	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/gov4git/v2/proto/motion/motionapi"
+	"github.com/gov4git/gov4git/v2/proto/motion/motionproto"
+	"github.com/gov4git/lib4git/base"
+	"github.com/gov4git/lib4git/form"
+	"github.com/gov4git/lib4git/git"
+	"github.com/gov4git/lib4git/must"
+)
+
+// MotionIDPrefix prefixes the ids of motions opened through the bureau.
+// It keeps them apart from the issue numbers used as ids of motions tracked on GitHub and Gitea,
+// so that users cannot open motions under the ids of future issues.
+const MotionIDPrefix = "b"
+
+// IsMotionID returns true if id is a valid id for a motion opened through the bureau.
+func IsMotionID(id motionproto.MotionID) bool {
+	return strings.HasPrefix(id.String(), MotionIDPrefix) && len(id) > len(MotionIDPrefix)
+}
+
+// GenerateRandomMotionID returns a motion id for motions opened through the bureau.
+func GenerateRandomMotionID() motionproto.MotionID {
+	const w = 512 / 8 // 512 bits, measured in bytes
+	buf := make([]byte, w)
+	rand.Read(buf)
+	return motionproto.MotionID(MotionIDPrefix + strings.ToLower(form.BytesHashForFilename(buf)[:8]))
+}
+
+func Open(
+	ctx context.Context,
+	userAddr id.OwnerAddress,
+	govAddr gov.Address,
+	req OpenRequest, // if Author is empty, a lookup for the user is performed; if ID is empty, a random one is generated
+) git.Change[form.Map, mail.RequestEnvelope[Request]] {
+
+	govCloned := gov.Clone(ctx, govAddr)
+	userOwner := id.CloneOwner(ctx, userAddr)
+	chg := Open_StageOnly(ctx, userOwner, govCloned, req)
+	proto.Commit(ctx, userOwner.Public.Tree(), chg)
+	proto.Push(ctx, userOwner.Public)
+	return chg
+}
+
+func Open_StageOnly(
+	ctx context.Context,
+	userOwner id.OwnerCloned,
+	govCloned gov.Cloned,
+	req OpenRequest,
+) git.Change[form.Map, mail.RequestEnvelope[Request]] {
+
+	if req.Author == "" {
+		req.Author = member.FindClonedUser_Local(ctx, govCloned, userOwner)
+	}
+	if req.ID == "" {
+		req.ID = GenerateRandomMotionID()
+	}
+	must.Assertf(ctx, IsMotionID(req.ID), "motion id must start with %q", MotionIDPrefix)
+	must.Assertf(ctx, req.Title != "", "motion title must be specified")
+
+	request := Request{Open: &req}
+	sendOnly := mail.Request_StageOnly(ctx, userOwner, govCloned.Tree(), BureauTopic, request)
+	return git.NewChange(
+		"Open motion.",
+		"bureau_open",
+		form.Map{
+			"author": req.Author,
+			"id":     req.ID,
+			"type":   req.Type,
+			"policy": req.Policy,
+			"title":  req.Title,
+		},
+		sendOnly.Result,
+		form.Forms{sendOnly},
+	)
+}
+
+func Ref(
+	ctx context.Context,
+	userAddr id.OwnerAddress,
+	govAddr gov.Address,
+	fromUserOpt member.User, // optional, if empty string, a lookup for the user is performed
+	from motionproto.MotionID,
+	to motionproto.MotionID,
+	typ motionproto.RefType,
+	remove bool,
+) git.Change[form.Map, mail.RequestEnvelope[Request]] {
+
+	govCloned := gov.Clone(ctx, govAddr)
+	userOwner := id.CloneOwner(ctx, userAddr)
+	chg := Ref_StageOnly(ctx, userOwner, govCloned, fromUserOpt, from, to, typ, remove)
+	proto.Commit(ctx, userOwner.Public.Tree(), chg)
+	proto.Push(ctx, userOwner.Public)
+	return chg
+}
+
+func Ref_StageOnly(
+	ctx context.Context,
+	userOwner id.OwnerCloned,
+	govCloned gov.Cloned,
+	fromUserOpt member.User,
+	from motionproto.MotionID,
+	to motionproto.MotionID,
+	typ motionproto.RefType,
+	remove bool,
+) git.Change[form.Map, mail.RequestEnvelope[Request]] {
+
+	if fromUserOpt == "" {
+		fromUserOpt = member.FindClonedUser_Local(ctx, govCloned, userOwner)
+	}
+	must.Assertf(ctx, from != "" && to != "", "referring and referenced motions must be specified")
+	must.Assertf(ctx, typ != "", "reference type must be specified")
+
+	request := Request{
+		Ref: &RefRequest{
+			FromUser: fromUserOpt,
+			From:     from,
+			To:       to,
+			Type:     typ,
+			Remove:   remove,
+		},
+	}
+
+	sendOnly := mail.Request_StageOnly(ctx, userOwner, govCloned.Tree(), BureauTopic, request)
+	return git.NewChange(
+		"Change motion reference.",
+		"bureau_ref",
+		form.Map{
+			"from_user": fromUserOpt,
+			"from":      from,
+			"to":        to,
+			"type":      typ,
+			"remove":    remove,
+		},
+		sendOnly.Result,
+		form.Forms{sendOnly},
+	)
+}
+
+func processOpen_StageOnly(
+	ctx context.Context,
+	govOwner gov.OwnerCloned,
+	requester member.User,
+	req *OpenRequest,
+) (Response, error) {
+
+	if req.Author != requester {
+		return Response{}, requestErrorf(ErrorUnauthorized, "invalid open request from user %v; motion author is not the requesting user", requester)
+	}
+	if req.ID == "" || req.Title == "" {
+		return Response{}, requestErrorf(ErrorInvalid, "motion id and title must be specified")
+	}
+	if !IsMotionID(req.ID) {
+		return Response{}, requestErrorf(ErrorInvalid, "motion id %v must start with %q", req.ID, MotionIDPrefix)
+	}
+	t := govOwner.Public.Tree()
+	if motionapi.IsMotion_Local(ctx, t, req.ID) {
+		return Response{}, requestErrorf(ErrorInvalid, "motion %v already exists", req.ID)
+	}
+	pcy := motionproto.TryGetPolicy(ctx, req.Policy)
+	if pcy == nil {
+		return Response{}, requestErrorf(ErrorNotFound, "motion policy %v is not installed", req.Policy)
+	}
+	switch desc := pcy.Descriptor(); req.Type {
+	case motionproto.MotionConcernType:
+		if !desc.AppliesToConcern {
+			return Response{}, requestErrorf(ErrorInvalid, "motion policy %v does not apply to concerns", req.Policy)
+		}
+	case motionproto.MotionProposalType:
+		if !desc.AppliesToProposal {
+			return Response{}, requestErrorf(ErrorInvalid, "motion policy %v does not apply to proposals", req.Policy)
+		}
+	default:
+		return Response{}, requestErrorf(ErrorInvalid, "unknown motion type %v", req.Type)
+	}
+
+	err := must.Try(func() {
+		motionapi.OpenMotion_StageOnly(ctx, govOwner, req.ID, req.Type, req.Policy, req.Author, req.Title, req.Body, "", req.Labels)
+	})
+	if err != nil {
+		return Response{}, fmt.Errorf("open motion error (%w)", err)
+	}
+	base.Infof("bureau: user %v opened motion %v", req.Author, req.ID)
+	m := motionapi.LookupMotion_Local(ctx, govOwner.PublicClone(), req.ID)
+	return Response{Motion: &m}, nil
+}
+
+func processRef_StageOnly(
+	ctx context.Context,
+	govOwner gov.OwnerCloned,
+	requester member.User,
+	req *RefRequest,
+) (Response, error) {
+
+	if req.FromUser != requester {
+		return Response{}, requestErrorf(ErrorUnauthorized, "invalid ref request from user %v; origin of request is not the requesting user", requester)
+	}
+	if req.Type == "" || req.From == req.To {
+		return Response{}, requestErrorf(ErrorInvalid, "invalid reference from motion %v to motion %v of type %q", req.From, req.To, req.Type)
+	}
+	t := govOwner.Public.Tree()
+	for _, id := range []motionproto.MotionID{req.From, req.To} {
+		if !motionapi.IsMotion_Local(ctx, t, id) {
+			return Response{}, requestErrorf(ErrorNotFound, "motion %v not found", id)
+		}
+	}
+	from := motionapi.LookupMotion_Local(ctx, govOwner.PublicClone(), req.From)
+	to := motionapi.LookupMotion_Local(ctx, govOwner.PublicClone(), req.To)
+	if from.Author != requester {
+		return Response{}, requestErrorf(ErrorUnauthorized, "user %v is not the author of motion %v", requester, from.ID)
+	}
+	if from.Closed || to.Closed {
+		return Response{}, requestErrorf(ErrorInvalid, "references can only change between open motions")
+	}
+	linked := from.RefersTo(req.To, req.Type)
+	if linked && !req.Remove {
+		return Response{}, requestErrorf(ErrorInvalid, "motion %v already refers to motion %v as %v", req.From, req.To, req.Type)
+	}
+	if !linked && req.Remove {
+		return Response{}, requestErrorf(ErrorNotFound, "motion %v does not refer to motion %v as %v", req.From, req.To, req.Type)
+	}
+
+	err := must.Try(func() {
+		if req.Remove {
+			motionapi.UnlinkMotions_StageOnly(ctx, govOwner, req.From, req.To, req.Type)
+		} else {
+			motionapi.LinkMotions_StageOnly(ctx, govOwner, req.From, req.To, req.Type)
+		}
+	})
+	if err != nil {
+		return Response{}, fmt.Errorf("motion reference error (%w)", err)
+	}
+	base.Infof("bureau: user %v changed reference from motion %v to motion %v of type %v (remove=%v)", requester, req.From, req.To, req.Type, req.Remove)
+	m := motionapi.LookupMotion_Local(ctx, govOwner.PublicClone(), req.From)
+	return Response{Motion: &m}, nil
+}
@@ -3,40 +3,41 @@ package bureau
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/gov4git/gov4git/v2/proto"
-	"github.com/gov4git/gov4git/v2/proto/account"
 	"github.com/gov4git/gov4git/v2/proto/gov"
 	"github.com/gov4git/gov4git/v2/proto/mail"
 	"github.com/gov4git/gov4git/v2/proto/member"
 	"github.com/gov4git/lib4git/base"
 	"github.com/gov4git/lib4git/form"
 	"github.com/gov4git/lib4git/git"
-	"github.com/gov4git/lib4git/must"
 )
 
 func Process(
 	ctx context.Context,
 	govAddr gov.OwnerAddress,
 	group member.Group,
-) git.ChangeNoResult {
+) git.Change[form.Map, ProcessedRequests] {
 
-	base.Infof("fetching service requests from the community ...")
+	return proto.Retry1(ctx, func() git.Change[form.Map, ProcessedRequests] {
+		base.Infof("fetching service requests from the community ...")
 
-	govOwner := gov.CloneOwner(ctx, govAddr)
-	chg, changed := Process_StageOnly(ctx, govOwner, group)
-	if changed {
-		proto.Commit(ctx, govOwner.Public.Tree(), chg)
-		govOwner.Public.Push(ctx)
-	}
-	return chg
+		govOwner := gov.CloneOwner(ctx, govAddr)
+		chg, changed := Process_StageOnly(ctx, govOwner, group)
+		if changed {
+			proto.Commit(ctx, govOwner.Public.Tree(), chg)
+			proto.Push(ctx, govOwner.Public)
+		}
+		return chg
+	})
 }
 
 func Process_StageOnly(
 	ctx context.Context,
 	govOwner gov.OwnerCloned,
 	group member.Group,
-) (change git.ChangeNoResult, changed bool) {
+) (change git.Change[form.Map, ProcessedRequests], changed bool) {
 
 	// list participating users
 	users := member.ListGroupUsers_Local(ctx, govOwner.PublicClone(), group)
@@ -47,98 +48,99 @@ func Process_StageOnly(
 		accounts[i] = member.GetUser_Local(ctx, govOwner.PublicClone(), user)
 	}
 
-	// fetch user requests
-	var fetchedReqs FetchedRequests
+	// fetch, process and respond to user requests
+	processed := ProcessedRequests{}
 	for i, account := range accounts {
-		if fetched, err := fetchUserRequests(ctx, govOwner, users[i], account); err != nil {
+		if chg, err := processUserRequests_StageOnly(ctx, govOwner, users[i], account); err != nil {
 			base.Infof("fetching bureau requests for user %v (%v)", users[i], err)
 		} else {
-			fetchedReqs = append(fetchedReqs, fetched.Result...)
+			processed = append(processed, chg.Result...)
 		}
 	}
+	if len(processed) > 0 {
+		changed = true
+	}
 
-	// process requests
-	for _, fetched := range fetchedReqs {
-		nOK, nErr := processRequest_StageOnly(ctx, govOwner, fetched)
-		if nOK+nErr > 0 {
-			changed = true
-		}
+	// settle escrows whose release conditions are met
+	if settleDueEscrows_StageOnly(ctx, govOwner.PublicClone(), time.Now()) > 0 {
+		changed = true
 	}
 
-	return git.NewChangeNoResult(
+	return git.NewChange(
 		fmt.Sprintf("Process bureau requests of users in group %v", group),
 		"bureau_process",
+		form.Map{"group": group},
+		processed,
+		nil,
 	), changed
 }
 
 func processRequest_StageOnly(
 	ctx context.Context,
 	govOwner gov.OwnerCloned,
-	fetched FetchedRequest,
-) (numOK int, numErr int) {
-	for _, req := range fetched.Requests {
-		if req.Transfer == nil {
-			numErr++
-			continue
-		}
-		if req.Transfer.FromUser != fetched.User {
-			base.Infof("bureau: invalid transfer request from user %v; origin of transfer is not the requesting user", fetched.User)
-			numErr++
-			continue
-		}
-		err := must.Try(func() {
-			account.Transfer_StageOnly(
-				ctx,
-				govOwner.PublicClone(),
-				member.UserAccountID(req.Transfer.FromUser),
-				member.UserAccountID(req.Transfer.ToUser),
-				account.H(account.PluralAsset, req.Transfer.Amount),
-				fmt.Sprintf("bureau transfer"),
-			)
-		})
-		if err != nil {
-			base.Infof("bureau: transfer error (%v)", err)
-			numErr++
-			continue
-		}
-		numOK++
-		base.Infof("bureau: transferred %v credits from user %v to user %v",
-			req.Transfer.Amount,
-			req.Transfer.FromUser,
-			req.Transfer.ToUser,
-		)
+	requester member.User,
+	req Request,
+) Response {
+
+	var resp Response
+	var err error
+	switch {
+	case req.Transfer != nil:
+		resp, err = processTransfer_StageOnly(ctx, govOwner, requester, req.Transfer)
+	case req.Delegate != nil:
+		resp, err = processDelegate_StageOnly(ctx, govOwner, requester, req.Delegate)
+	case req.Escrow != nil:
+		resp, err = processEscrow_StageOnly(ctx, govOwner, requester, req.Escrow)
+	case req.Settle != nil:
+		resp, err = processSettle_StageOnly(ctx, govOwner, requester, req.Settle)
+	case req.Open != nil:
+		resp, err = processOpen_StageOnly(ctx, govOwner, requester, req.Open)
+	case req.Ref != nil:
+		resp, err = processRef_StageOnly(ctx, govOwner, requester, req.Ref)
+	case req.Tip != nil:
+		resp, err = processTip_StageOnly(ctx, govOwner, requester, req.Tip)
+	default:
+		err = requestErrorf(ErrorInvalid, "unknown request")
+	}
+	if err != nil {
+		base.Infof("bureau: request from user %v failed (%v)", requester, err)
+		return ErrorResponse(err)
 	}
-	return
+	resp.OK = true
+	return resp
 }
 
-func fetchUserRequests(
+func processUserRequests_StageOnly(
 	ctx context.Context,
 	govOwner gov.OwnerCloned,
 	user member.User,
 	account member.UserProfile,
-) (git.Change[form.Map, FetchedRequests], error) {
+) (git.Change[form.Map, ProcessedRequests], error) {
 
-	fetched := FetchedRequests{}
-	var respond mail.Responder[Request, Request] = func(
+	processed := ProcessedRequests{}
+	var respond mail.Responder[Request, Response] = func(
 		ctx context.Context,
-		_ mail.SeqNo,
+		seqNo mail.SeqNo,
 		req Request,
-	) (resp Request, err error) {
-		fetched = append(fetched,
-			FetchedRequest{
+	) (resp Response, err error) {
+		resp = processRequest_StageOnly(ctx, govOwner, user, req)
+		processed = append(processed,
+			ProcessedRequest{
 				User:     user,
 				Address:  account.PublicAddress,
-				Requests: Requests{req},
+				SeqNo:    seqNo,
+				Request:  req,
+				Response: resp,
 			})
-		return req, nil
+		return resp, nil
 	}
 
-	userPublic, err := git.TryCloneOne(ctx, git.Address(account.PublicAddress))
+	userPublic, err := member.TryClonePublic(ctx, account.PublicAddress)
 	if err != nil {
-		return git.Change[form.Map, FetchedRequests]{}, err
+		return git.Change[form.Map, ProcessedRequests]{}, err
 	}
 
-	recvOnly := mail.Respond_StageOnly[Request, Request](
+	recvOnly := mail.Respond_StageOnly[Request, Response](
 		ctx,
 		govOwner.IDOwnerCloned(),
 		account.PublicAddress,
@@ -148,10 +150,10 @@ func fetchUserRequests(
 	)
 
 	return git.NewChange(
-		fmt.Sprintf("Fetched requests from user %v", user),
-		"bureau_fetch_user_requests",
+		fmt.Sprintf("Processed requests from user %v", user),
+		"bureau_process_user_requests",
 		form.Map{"user": user, "account": account},
-		fetched,
+		processed,
 		form.Forms{recvOnly},
 	), nil
 }
@@ -1,28 +1,179 @@
 package bureau
 
 import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gov4git/gov4git/v2/proto/account"
 	"github.com/gov4git/gov4git/v2/proto/id"
+	"github.com/gov4git/gov4git/v2/proto/mail"
 	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/gov4git/v2/proto/motion"
+	"github.com/gov4git/gov4git/v2/proto/motion/motionproto"
+	"github.com/gov4git/gov4git/v2/proto/purpose"
 )
 
 const BureauTopic = "bureau"
 
 type Request struct {
 	Transfer *TransferRequest `json:"transfer"`
+	Delegate *DelegateRequest `json:"delegate,omitempty"`
+	Escrow   *EscrowRequest   `json:"escrow,omitempty"`
+	Settle   *SettleRequest   `json:"settle,omitempty"`
+	Open     *OpenRequest     `json:"open,omitempty"`
+	Ref      *RefRequest      `json:"ref,omitempty"`
+	Tip      *TipRequest      `json:"tip,omitempty"`
 }
 
 type Requests []Request
 
 type TransferRequest struct {
+	FromUser member.User   `json:"from_user"`
+	ToUser   member.User   `json:"to_user"`
+	Asset    account.Asset `json:"asset,omitempty"` // the plural credit, if empty
+	Amount   float64       `json:"amount"`
+}
+
+func (x TransferRequest) GetAsset() account.Asset {
+	if x.Asset == "" {
+		return account.PluralAsset
+	}
+	return x.Asset
+}
+
+// DelegateRequest asks to delegate the voting power of a user to another user, on ballots of the given purpose.
+// An empty purpose applies to ballots of all purposes.
+// If Revoke is set, the delegation for the given purpose is revoked instead.
+type DelegateRequest struct {
+	FromUser member.User     `json:"from_user"`
+	ToUser   member.User     `json:"to_user"`
+	Purpose  purpose.Purpose `json:"purpose"`
+	Revoke   bool            `json:"revoke"`
+}
+
+// EscrowRequest asks to move funds from a user into a dedicated escrow account, to be released to another user later.
+// The funds are released once ReleaseAt has passed (if set) and the motion Motion has closed as accepted (if set).
+// If the motion closes with any other outcome, or is cancelled, the funds are refunded to the requesting user.
+// Revocable escrows can be refunded by the requesting user at any time before they are released.
+type EscrowRequest struct {
+	ID        EscrowID             `json:"id"`
+	FromUser  member.User          `json:"from_user"`
+	ToUser    member.User          `json:"to_user"`
+	Asset     account.Asset        `json:"asset,omitempty"` // the plural credit, if empty
+	Amount    float64              `json:"amount"`
+	ReleaseAt time.Time            `json:"release_at"`       // optional
+	Motion    motionproto.MotionID `json:"motion,omitempty"` // optional
+	Revocable bool                 `json:"revocable"`
+}
+
+func (x EscrowRequest) GetAsset() account.Asset {
+	if x.Asset == "" {
+		return account.PluralAsset
+	}
+	return x.Asset
+}
+
+// SettleRequest asks to settle an escrow of the requesting user ahead of its release conditions.
+// The funds are released to the recipient, or refunded to the requesting user if Revoke is set.
+// Only revocable escrows can be refunded.
+type SettleRequest struct {
 	FromUser member.User `json:"from_user"`
-	ToUser   member.User `json:"to_user"`
-	Amount   float64     `json:"amount"`
+	ID       EscrowID    `json:"id"`
+	Revoke   bool        `json:"revoke"`
+}
+
+// OpenRequest asks to open a motion authored by the requesting user, managed by the given motion policy.
+type OpenRequest struct {
+	Author member.User            `json:"author"`
+	ID     motionproto.MotionID   `json:"id"`
+	Type   motionproto.MotionType `json:"type"`
+	Policy motion.PolicyName      `json:"policy"`
+	Title  string                 `json:"title"`
+	Body   string                 `json:"body"`
+	Labels []string               `json:"labels"`
+}
+
+// RefRequest asks to add a reference between two open motions, or to remove it if Remove is set.
+// The requesting user must be the author of the referring motion.
+type RefRequest struct {
+	FromUser member.User          `json:"from_user"`
+	From     motionproto.MotionID `json:"from"`
+	To       motionproto.MotionID `json:"to"`
+	Type     motionproto.RefType  `json:"type"`
+	Remove   bool                 `json:"remove"`
+}
+
+// TipRequest asks to transfer funds to another user as a tip, annotated with a memo.
+type TipRequest struct {
+	FromUser member.User   `json:"from_user"`
+	ToUser   member.User   `json:"to_user"`
+	Asset    account.Asset `json:"asset,omitempty"` // the plural credit, if empty
+	Amount   float64       `json:"amount"`
+	Memo     string        `json:"memo"`
+}
+
+func (x TipRequest) GetAsset() account.Asset {
+	if x.Asset == "" {
+		return account.PluralAsset
+	}
+	return x.Asset
+}
+
+type ErrorCode string
+
+const (
+	ErrorInvalid           ErrorCode = "invalid"
+	ErrorUnauthorized      ErrorCode = "unauthorized"
+	ErrorNotFound          ErrorCode = "not_found"
+	ErrorInsufficientFunds ErrorCode = "insufficient_funds"
+	ErrorFailed            ErrorCode = "failed"
+)
+
+// RequestError is an error processing a request, classified by an error code.
+type RequestError struct {
+	Code ErrorCode
+	Err  error
+}
+
+func (x *RequestError) Error() string {
+	return fmt.Sprintf("%v: %v", x.Code, x.Err)
+}
+
+func (x *RequestError) Unwrap() error {
+	return x.Err
+}
+
+func requestErrorf(code ErrorCode, format string, args ...any) error {
+	return &RequestError{Code: code, Err: fmt.Errorf(format, args...)}
+}
+
+// Response is the outcome of a request, written back to the requesting user's mailbox.
+// Balance is the resulting balance of the requesting user in the asset affected by the request, if any.
+type Response struct {
+	OK        bool                `json:"ok"`
+	ErrorCode ErrorCode           `json:"error_code,omitempty"`
+	Error     string              `json:"error,omitempty"`
+	Balance   *account.Holding    `json:"balance,omitempty"`
+	Escrow    *EscrowState        `json:"escrow,omitempty"`
+	Motion    *motionproto.Motion `json:"motion,omitempty"`
+}
+
+func ErrorResponse(err error) Response {
+	code := ErrorFailed
+	var reqErr *RequestError
+	if errors.As(err, &reqErr) {
+		code = reqErr.Code
+	}
+	return Response{ErrorCode: code, Error: err.Error()}
 }
 
-type FetchedRequest struct {
+type ProcessedRequest struct {
 	User     member.User      `json:"requesting_user"`
 	Address  id.PublicAddress `json:"requesting_address"`
-	Requests Requests         `json:"requests"`
+	SeqNo    mail.SeqNo       `json:"seqno"`
+	Request  Request          `json:"request"`
+	Response Response         `json:"response"`
 }
 
-type FetchedRequests []FetchedRequest
+type ProcessedRequests []ProcessedRequest
@@ -0,0 +1,98 @@
+package bureau
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/id"
+	"github.com/gov4git/gov4git/v2/proto/mail"
+	"github.com/gov4git/lib4git/git"
+	"github.com/gov4git/lib4git/must"
+)
+
+// RequestStatus is the outcome of a bureau request sent by a user.
+// Pending requests have not been processed by the community yet, and have no response.
+// Legacy requests were processed before responses recorded outcomes, so their outcome is unknown and they have no response.
+type RequestStatus struct {
+	SeqNo    mail.SeqNo `json:"seqno"`
+	Request  Request    `json:"request"`
+	Pending  bool       `json:"pending"`
+	Legacy   bool       `json:"legacy,omitempty"`
+	Response *Response  `json:"response,omitempty"`
+}
+
+type RequestStatuses []RequestStatus
+
+func (x RequestStatuses) Len() int           { return len(x) }
+func (x RequestStatuses) Less(i, j int) bool { return x[i].SeqNo < x[j].SeqNo }
+func (x RequestStatuses) Swap(i, j int)      { x[i], x[j] = x[j], x[i] }
+func (x RequestStatuses) Sort()              { sort.Sort(x) }
+
+func Status(
+	ctx context.Context,
+	userAddr id.PublicAddress,
+	govAddr gov.Address,
+) RequestStatuses {
+
+	return Status_Local(
+		ctx,
+		git.CloneOne(ctx, git.Address(userAddr)).Tree(),
+		gov.Clone(ctx, govAddr).Tree(),
+	)
+}
+
+// Status_Local lists the bureau requests sent from a user's public repo, alongside the responses received from the community.
+func Status_Local(
+	ctx context.Context,
+	userPublic *git.Tree,
+	govPublic *git.Tree,
+) RequestStatuses {
+
+	userCred := id.GetPublicCredentials(ctx, userPublic)
+	govCred := id.GetPublicCredentials(ctx, govPublic)
+	statuses := RequestStatuses{}
+
+	// no requests sent
+	if _, err := git.TreeStat(ctx, userPublic, mail.SendTopicNS(govCred.ID, BureauTopic)); git.IsNotExist(err) {
+		return statuses
+	}
+
+	// no requests received
+	if _, err := git.TreeStat(ctx, govPublic, mail.ReceiveTopicNS(userCred.ID, BureauTopic)); git.IsNotExist(err) {
+		sent, _ := mail.ListSent_Local[id.Signed[mail.RequestEnvelope[Request]]](ctx, userPublic, govPublic, BureauTopic)
+		for _, s := range sent {
+			statuses = append(statuses, RequestStatus{SeqNo: s.SeqNo, Request: s.Msg.Value.Request, Pending: true})
+		}
+		return statuses
+	}
+
+	confirmed, notConfirmed := mail.ConfirmCall_Local[Request, json.RawMessage](ctx, userPublic, govPublic, BureauTopic)
+	for _, c := range confirmed {
+		if isLegacyResponse(c.Effect) {
+			statuses = append(statuses, RequestStatus{SeqNo: c.SeqNo, Request: c.Msg, Legacy: true})
+			continue
+		}
+		var resp Response
+		must.NoError(ctx, json.Unmarshal(c.Effect, &resp))
+		statuses = append(statuses, RequestStatus{SeqNo: c.SeqNo, Request: c.Msg, Response: &resp})
+	}
+	for _, nc := range notConfirmed {
+		statuses = append(statuses, RequestStatus{SeqNo: nc.SeqNo, Request: nc.Msg, Pending: true})
+	}
+	statuses.Sort()
+	return statuses
+}
+
+// isLegacyResponse returns true for responses written before responses recorded outcomes.
+// Legacy responses echo the request, and have neither an ok flag nor an error code.
+func isLegacyResponse(raw json.RawMessage) bool {
+	var keys map[string]json.RawMessage
+	if json.Unmarshal(raw, &keys) != nil {
+		return true
+	}
+	_, ok := keys["ok"]
+	_, code := keys["error_code"]
+	return !ok && !code
+}
@@ -0,0 +1,117 @@
+package bureau
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gov4git/gov4git/v2/proto"
+	"github.com/gov4git/gov4git/v2/proto/account"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/id"
+	"github.com/gov4git/gov4git/v2/proto/mail"
+	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/lib4git/base"
+	"github.com/gov4git/lib4git/form"
+	"github.com/gov4git/lib4git/git"
+	"github.com/gov4git/lib4git/must"
+)
+
+func Tip(
+	ctx context.Context,
+	userAddr id.OwnerAddress,
+	govAddr gov.Address,
+	fromUserOpt member.User, // optional, if empty string, a lookup for the user is performed
+	toUser member.User,
+	asset account.Asset, // optional, if empty string, the plural credit is transferred
+	amount float64,
+	memo string,
+) git.Change[form.Map, mail.RequestEnvelope[Request]] {
+
+	govCloned := gov.Clone(ctx, govAddr)
+	userOwner := id.CloneOwner(ctx, userAddr)
+	chg := Tip_StageOnly(ctx, userOwner, govCloned, fromUserOpt, toUser, asset, amount, memo)
+	proto.Commit(ctx, userOwner.Public.Tree(), chg)
+	proto.Push(ctx, userOwner.Public)
+	return chg
+}
+
+func Tip_StageOnly(
+	ctx context.Context,
+	userOwner id.OwnerCloned,
+	govCloned gov.Cloned,
+	fromUserOpt member.User,
+	toUser member.User,
+	asset account.Asset,
+	amount float64,
+	memo string,
+) git.Change[form.Map, mail.RequestEnvelope[Request]] {
+
+	if fromUserOpt == "" {
+		fromUserOpt = member.FindClonedUser_Local(ctx, govCloned, userOwner)
+	}
+	must.Assertf(ctx, toUser != "", "tip recipient must be specified")
+
+	request := Request{
+		Tip: &TipRequest{
+			FromUser: fromUserOpt,
+			ToUser:   toUser,
+			Asset:    asset,
+			Amount:   amount,
+			Memo:     memo,
+		},
+	}
+
+	sendOnly := mail.Request_StageOnly(ctx, userOwner, govCloned.Tree(), BureauTopic, request)
+	return git.NewChange(
+		"Tip user.",
+		"bureau_tip",
+		form.Map{
+			"from_user": fromUserOpt,
+			"to_user":   toUser,
+			"asset":     asset,
+			"amount":    amount,
+			"memo":      memo,
+		},
+		sendOnly.Result,
+		form.Forms{sendOnly},
+	)
+}
+
+func processTip_StageOnly(
+	ctx context.Context,
+	govOwner gov.OwnerCloned,
+	requester member.User,
+	req *TipRequest,
+) (Response, error) {
+
+	if req.FromUser != requester {
+		return Response{}, requestErrorf(ErrorUnauthorized, "invalid tip request from user %v; origin of tip is not the requesting user", requester)
+	}
+	if req.ToUser == req.FromUser {
+		return Response{}, requestErrorf(ErrorInvalid, "users cannot tip themselves")
+	}
+	if !member.IsUser_Local(ctx, govOwner.PublicClone(), req.ToUser) {
+		return Response{}, requestErrorf(ErrorNotFound, "tip recipient %v is not a user", req.ToUser)
+	}
+	asset := req.GetAsset()
+	amount := account.H(asset, req.Amount)
+	if err := checkFunds_Local(ctx, govOwner.PublicClone(), req.FromUser, amount); err != nil {
+		return Response{}, err
+	}
+	err := must.Try(func() {
+		account.VerifyTransferable_Local(ctx, govOwner.PublicClone(), asset)
+		account.Transfer_StageOnly(
+			ctx,
+			govOwner.PublicClone(),
+			member.UserAccountID(req.FromUser),
+			member.UserAccountID(req.ToUser),
+			amount,
+			fmt.Sprintf("bureau tip: %s", req.Memo),
+		)
+	})
+	if err != nil {
+		return Response{}, fmt.Errorf("tip error (%w)", err)
+	}
+	base.Infof("bureau: user %v tipped %v to user %v", req.FromUser, amount, req.ToUser)
+	return Response{Balance: userBalance_Local(ctx, govOwner.PublicClone(), req.FromUser, asset)}, nil
+}
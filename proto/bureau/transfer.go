@@ -2,12 +2,15 @@ package bureau
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/gov4git/gov4git/v2/proto"
+	"github.com/gov4git/gov4git/v2/proto/account"
 	"github.com/gov4git/gov4git/v2/proto/gov"
 	"github.com/gov4git/gov4git/v2/proto/id"
 	"github.com/gov4git/gov4git/v2/proto/mail"
 	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/lib4git/base"
 	"github.com/gov4git/lib4git/form"
 	"github.com/gov4git/lib4git/git"
 	"github.com/gov4git/lib4git/must"
@@ -19,14 +22,15 @@ func Transfer(
 	govAddr gov.Address,
 	fromUserOpt member.User, // optional, if empty string, a lookup forthe user is performed
 	toUser member.User,
+	asset account.Asset, // optional, if empty string, the plural credit is transferred
 	amount float64,
 ) git.Change[form.Map, mail.RequestEnvelope[Request]] {
 
 	govCloned := gov.Clone(ctx, govAddr)
 	userOwner := id.CloneOwner(ctx, userAddr)
-	chg := Transfer_StageOnly(ctx, userAddr, userOwner, govCloned, fromUserOpt, toUser, amount)
+	chg := Transfer_StageOnly(ctx, userAddr, userOwner, govCloned, fromUserOpt, toUser, asset, amount)
 	proto.Commit(ctx, userOwner.Public.Tree(), chg)
-	userOwner.Public.Push(ctx)
+	proto.Push(ctx, userOwner.Public)
 	return chg
 }
 
@@ -37,6 +41,7 @@ func Transfer_StageOnly(
 	govCloned gov.Cloned,
 	fromUserOpt member.User,
 	toUser member.User,
+	asset account.Asset,
 	amount float64,
 ) git.Change[form.Map, mail.RequestEnvelope[Request]] {
 
@@ -59,6 +64,7 @@ func Transfer_StageOnly(
 		Transfer: &TransferRequest{
 			FromUser: fromUserOpt,
 			ToUser:   toUser,
+			Asset:    asset,
 			Amount:   amount,
 		},
 	}
@@ -70,9 +76,67 @@ func Transfer_StageOnly(
 		form.Map{
 			"from_user": fromUserOpt,
 			"to_user":   toUser,
+			"asset":     asset,
 			"amount":    amount,
 		},
 		sendOnly.Result,
 		form.Forms{sendOnly},
 	)
 }
+
+func processTransfer_StageOnly(
+	ctx context.Context,
+	govOwner gov.OwnerCloned,
+	requester member.User,
+	req *TransferRequest,
+) (Response, error) {
+
+	if req.FromUser != requester {
+		return Response{}, requestErrorf(ErrorUnauthorized, "invalid transfer request from user %v; origin of transfer is not the requesting user", requester)
+	}
+	if !member.IsUser_Local(ctx, govOwner.PublicClone(), req.ToUser) {
+		return Response{}, requestErrorf(ErrorNotFound, "transfer recipient %v is not a user", req.ToUser)
+	}
+	asset := req.GetAsset()
+	amount := account.H(asset, req.Amount)
+	if err := checkFunds_Local(ctx, govOwner.PublicClone(), req.FromUser, amount); err != nil {
+		return Response{}, err
+	}
+	err := must.Try(func() {
+		account.VerifyTransferable_Local(ctx, govOwner.PublicClone(), asset)
+		account.Transfer_StageOnly(
+			ctx,
+			govOwner.PublicClone(),
+			member.UserAccountID(req.FromUser),
+			member.UserAccountID(req.ToUser),
+			amount,
+			fmt.Sprintf("bureau transfer"),
+		)
+	})
+	if err != nil {
+		return Response{}, fmt.Errorf("transfer error (%w)", err)
+	}
+	base.Infof("bureau: transferred %v %v from user %v to user %v",
+		req.Amount,
+		asset,
+		req.FromUser,
+		req.ToUser,
+	)
+	return Response{Balance: userBalance_Local(ctx, govOwner.PublicClone(), req.FromUser, asset)}, nil
+}
+
+func checkFunds_Local(ctx context.Context, cloned gov.Cloned, user member.User, amount account.Holding) error {
+	if amount.Quantity.Sign() <= 0 {
+		return requestErrorf(ErrorInvalid, "amount must be positive")
+	}
+	balance := account.Get_Local(ctx, cloned, member.UserAccountID(user)).Balance(amount.Asset)
+	if balance.Quantity.Cmp(amount.Quantity) < 0 {
+		return requestErrorf(ErrorInsufficientFunds, "balance %v is less than %v", balance, amount)
+	}
+	return nil
+}
+
+func userBalance_Local(ctx context.Context, cloned gov.Cloned, user member.User, asset account.Asset) *account.Holding {
+	h := account.Get_Local(ctx, cloned, member.UserAccountID(user)).Balance(asset)
+	return &h
+}
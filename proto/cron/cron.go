@@ -6,13 +6,16 @@ import (
 	"os"
 	"time"
 
-	"github.com/google/go-github/v58/github"
 	"github.com/gov4git/gov4git/v2"
 	govgh "github.com/gov4git/gov4git/v2/github"
 	"github.com/gov4git/gov4git/v2/proto"
 	"github.com/gov4git/gov4git/v2/proto/ballot/ballotapi"
+	"github.com/gov4git/gov4git/v2/proto/demurrage"
 	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/issuance"
+	"github.com/gov4git/gov4git/v2/proto/member"
 	"github.com/gov4git/gov4git/v2/proto/motion/motionapi"
+	"github.com/gov4git/gov4git/v2/tracker"
 	"github.com/gov4git/lib4git/base"
 	"github.com/gov4git/lib4git/form"
 	"github.com/gov4git/lib4git/git"
@@ -24,16 +27,34 @@ var CronNS = ns.NS{"cron", "cron.json"}
 
 func Cron(
 	ctx context.Context,
-	repo govgh.Repo,
-	ghc *github.Client,
+	tr tracker.Tracker, // if nil, motions are tracked natively in the governance repo
 	govAddr gov.OwnerAddress,
 	//
-	githubFreq time.Duration, // frequency of importing from github
+	githubFreq time.Duration, // frequency of importing from the issue tracker
+	fullSyncFreq time.Duration, // frequency of full reconciliation with the issue tracker; imports in between only fetch updated issues
 	communityFreq time.Duration, // frequency of fetching community votes and service requests
 	//
 	maxPar int, // parallelism for fetching community votes
 ) form.Map {
 
+	// voter repos fetched by a failed attempt are reused by the retries
+	ctx = member.WithPublicRepos(ctx, member.NewPublicRepos())
+	// only the changes to the repos are retried; comments and the dashboard are written to the issue tracker once, after the push
+	return govgh.RetryWithTracker(ctx, tr, func(tr tracker.Tracker) form.Map {
+		return cron(ctx, tr, govAddr, githubFreq, fullSyncFreq, communityFreq, maxPar)
+	})
+}
+
+func cron(
+	ctx context.Context,
+	tr tracker.Tracker,
+	govAddr gov.OwnerAddress,
+	githubFreq time.Duration,
+	fullSyncFreq time.Duration,
+	communityFreq time.Duration,
+	maxPar int,
+) form.Map {
+
 	cloned := gov.CloneOwner(ctx, govAddr)
 	govTree := cloned.Public.Tree()
 
@@ -48,29 +69,39 @@ func Cron(
 	must.Assertf(ctx, err == nil || err == os.ErrNotExist, "opening cron state (%v)", err)
 
 	now := time.Now()
-	shouldSyncGithub := now.Sub(state.LastGithubImport) > githubFreq
+	shouldSyncGithub := tr != nil && now.Sub(state.LastGithubImport) > githubFreq
 	shouldSyncCommunity := now.Sub(state.LastCommunityTally) > communityFreq
 
 	report := form.Map{}
 
-	// import from github
+	// import from the issue tracker
 	if shouldSyncGithub {
 
 		// fetch repo maintainers
-		maintainers := govgh.FetchRepoMaintainers(ctx, repo, ghc)
-		base.Infof("maintainers for %v are %v", repo, form.SprintJSON(maintainers))
+		maintainers := tr.ListMaintainers(ctx)
+		base.Infof("maintainers for %v are %v", tr, form.SprintJSON(maintainers))
 
 		// process managed issues and pull requests
-		base.Infof("CRON: syncing managed issues and pull requests")
-		report["processed_managed_issues"] = govgh.SyncManagedIssues_StageOnly(ctx, repo, ghc, govAddr, cloned)
+		// a full reconciliation catches deleted issues, which are invisible to incremental syncs
+		since := state.GithubSyncCursor
+		if now.Sub(state.LastGithubFullSync) > fullSyncFreq {
+			since = time.Time{}
+		}
+		base.Infof("CRON: syncing managed issues and pull requests (updated since %v)", since)
+		syncChanges := govgh.SyncManagedIssuesSince_StageOnly(ctx, tr, govAddr, cloned, since)
+		report["processed_managed_issues"] = syncChanges
+		state.GithubSyncCursor = syncChanges.Cursor
+		if since.IsZero() {
+			state.LastGithubFullSync = now
+		}
 
 		// process joins
 		base.Infof("CRON: processing join requests")
-		report["processed_joins"] = govgh.ProcessJoinRequestIssues_StageOnly(ctx, repo, ghc, govAddr, cloned, maintainers, false)
+		report["processed_joins"] = govgh.ProcessJoinRequestIssues_StageOnly(ctx, tr, govAddr, cloned, maintainers, false)
 
 		// process directives
 		base.Infof("CRON: processing directives")
-		report["processed_directives"] = govgh.ProcessDirectiveIssues_StageOnly(ctx, repo, ghc, govAddr, cloned, maintainers)
+		report["processed_directives"] = govgh.ProcessDirectiveIssues_StageOnly(ctx, tr, govAddr, cloned, maintainers)
 
 		state.LastGithubImport = time.Now()
 	}
@@ -85,14 +116,31 @@ func Cron(
 		state.LastCommunityTally = time.Now()
 	}
 
+	// freeze and close ballots whose deadlines have passed
+	base.Infof("CRON: enforcing ballot deadlines")
+	report["schedule"] = ballotapi.Schedule_StageOnly(ctx, cloned, now, maxPar).Result
+
+	// issue credits from recurring issuance schedules
+	base.Infof("CRON: running issuance schedules")
+	report["issuance"] = issuance.Run_StageOnly(ctx, cloned.PublicClone(), now).Result
+
+	// decay idle balances
+	base.Infof("CRON: applying demurrage policies")
+	report["demurrage"] = demurrage.Apply_StageOnly(ctx, cloned.PublicClone(), now).Result
+
 	motionapi.Pipeline_StageOnly(ctx, cloned)
 
-	// display notices on github
-	govgh.DisplayNotices_StageOnly(ctx, repo, ghc, cloned.PublicClone())
+	if tr != nil {
+		// display notices on the issue tracker
+		govgh.DisplayNotices_StageOnly(ctx, tr, cloned.PublicClone())
 
-	// update community dashboard on github
-	base.Infof("CRON: publishing community dashboard")
-	govgh.PublishDashboard(ctx, repo, ghc, cloned.PublicClone())
+		// update community dashboard on the issue tracker
+		base.Infof("CRON: publishing community dashboard")
+		govgh.PublishDashboard(ctx, tr, cloned.PublicClone())
+	} else {
+		// display notices in the motion threads of the governance repo
+		motionapi.DisplayNoticesInThreads_StageOnly(ctx, cloned.PublicClone())
+	}
 
 	// prepare commit message
 	report["cron"] = state
@@ -121,13 +169,13 @@ func Cron(
 	must.NoError(ctx, err)
 	if !govStatus.IsClean() {
 		proto.Commit(ctx, cloned.Public.Tree(), cronChg)
-		cloned.Public.Push(ctx)
+		proto.Push(ctx, cloned.Public)
 	}
 
 	// push cron state
 	git.ToFileStage(ctx, cronTree, CronNS, state)
 	proto.Commit(ctx, cronTree, cronChg)
-	cronCloned.Push(ctx)
+	proto.Push(ctx, cronCloned)
 
 	return report
 }
@@ -141,5 +189,7 @@ type LatestChange struct {
 
 type CronState struct {
 	LastGithubImport   time.Time `json:"last_github_import"`
+	LastGithubFullSync time.Time `json:"last_github_full_sync"`
+	GithubSyncCursor   time.Time `json:"github_sync_cursor"` // update time of the most recently updated issue seen by the last sync
 	LastCommunityTally time.Time `json:"last_community_tally"`
 }
@@ -0,0 +1,181 @@
+package delegation
+
+import (
+	"context"
+
+	"github.com/gov4git/gov4git/v2/proto"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/history/trace"
+	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/gov4git/v2/proto/purpose"
+	"github.com/gov4git/lib4git/git"
+	"github.com/gov4git/lib4git/must"
+)
+
+func Delegate(
+	ctx context.Context,
+	addr gov.Address,
+	from member.User,
+	to member.User,
+	p purpose.Purpose,
+
+) {
+	proto.Retry(ctx, func() {
+		cloned := gov.Clone(ctx, addr)
+		Delegate_StageOnly(ctx, cloned, from, to, p)
+		proto.Commitf(ctx, cloned, "delegation_delegate", "delegate votes of %v to %v for purpose %q", from, to, p)
+		proto.Push(ctx, cloned)
+	})
+}
+
+func Delegate_StageOnly(
+	ctx context.Context,
+	cloned gov.Cloned,
+	from member.User,
+	to member.User,
+	p purpose.Purpose,
+
+) {
+	must.Assertf(ctx, from != to, "user %v cannot delegate to themselves", from)
+	must.Assertf(ctx, member.IsUser_Local(ctx, cloned, from), "user %v not found", from)
+	must.Assertf(ctx, member.IsUser_Local(ctx, cloned, to), "delegate %v not found", to)
+
+	ud := Get_Local(ctx, cloned, from)
+	ud.ByPurpose[p] = to
+	delegationKV.Set(ctx, delegationNS, cloned.Tree(), from, ud)
+
+	trace.Log_StageOnly(ctx, cloned, &trace.Event{
+		Op:     "delegation_delegate",
+		Args:   trace.M{"from": from, "to": to, "purpose": p},
+		Result: nil,
+	})
+}
+
+func Undelegate(
+	ctx context.Context,
+	addr gov.Address,
+	from member.User,
+	p purpose.Purpose,
+
+) {
+	proto.Retry(ctx, func() {
+		cloned := gov.Clone(ctx, addr)
+		Undelegate_StageOnly(ctx, cloned, from, p)
+		proto.Commitf(ctx, cloned, "delegation_undelegate", "revoke delegation of %v for purpose %q", from, p)
+		proto.Push(ctx, cloned)
+	})
+}
+
+func Undelegate_StageOnly(
+	ctx context.Context,
+	cloned gov.Cloned,
+	from member.User,
+	p purpose.Purpose,
+
+) {
+	ud := Get_Local(ctx, cloned, from)
+	_, ok := ud.ByPurpose[p]
+	must.Assertf(ctx, ok, "user %v has no delegation for purpose %q", from, p)
+	delete(ud.ByPurpose, p)
+	if len(ud.ByPurpose) == 0 {
+		delegationKV.Remove(ctx, delegationNS, cloned.Tree(), from)
+	} else {
+		delegationKV.Set(ctx, delegationNS, cloned.Tree(), from, ud)
+	}
+
+	trace.Log_StageOnly(ctx, cloned, &trace.Event{
+		Op:     "delegation_undelegate",
+		Args:   trace.M{"from": from, "purpose": p},
+		Result: nil,
+	})
+}
+
+func Get(
+	ctx context.Context,
+	addr gov.Address,
+	user member.User,
+
+) UserDelegations {
+	return Get_Local(ctx, gov.Clone(ctx, addr), user)
+}
+
+// Get_Local returns the delegations of a user. Users without delegations have an empty record.
+func Get_Local(
+	ctx context.Context,
+	cloned gov.Cloned,
+	user member.User,
+
+) UserDelegations {
+	ud, err := must.Try1[UserDelegations](
+		func() UserDelegations {
+			return delegationKV.Get(ctx, delegationNS, cloned.Tree(), user)
+		},
+	)
+	if git.IsNotExist(err) {
+		return UserDelegations{User: user, ByPurpose: map[purpose.Purpose]member.User{}}
+	}
+	must.NoError(ctx, err)
+	if ud.ByPurpose == nil {
+		ud.ByPurpose = map[purpose.Purpose]member.User{}
+	}
+	return ud
+}
+
+func List(
+	ctx context.Context,
+	addr gov.Address,
+
+) []UserDelegations {
+	return List_Local(ctx, gov.Clone(ctx, addr))
+}
+
+func List_Local(
+	ctx context.Context,
+	cloned gov.Cloned,
+
+) []UserDelegations {
+	if _, err := git.TreeStat(ctx, cloned.Tree(), delegationNS); git.IsNotExist(err) {
+		return nil
+	}
+	users := delegationKV.ListKeys(ctx, delegationNS, cloned.Tree())
+	return delegationKV.GetMany(ctx, delegationNS, cloned.Tree(), users)
+}
+
+// Resolve follows the delegation chain of a user for a given ballot purpose.
+// The chain ends at the first delegate who voted directly, which is returned.
+// If the chain ends at a delegate who did not vote, or contains a cycle, no delegate is returned.
+func Resolve(
+	ctx context.Context,
+	delegations map[member.User]UserDelegations,
+	user member.User,
+	p purpose.Purpose,
+	votedDirectly func(member.User) bool,
+
+) (member.User, bool) {
+
+	visited := map[member.User]bool{user: true}
+	cur := user
+	for {
+		next, ok := delegations[cur].Lookup(p)
+		if !ok {
+			return "", false
+		}
+		if visited[next] {
+			return "", false // cycle
+		}
+		visited[next] = true
+		if votedDirectly(next) {
+			return next, true
+		}
+		cur = next
+	}
+}
+
+// Index returns the delegations of all users, keyed by the delegating user.
+func Index(uds []UserDelegations) map[member.User]UserDelegations {
+	r := map[member.User]UserDelegations{}
+	for _, ud := range uds {
+		r[ud.User] = ud
+	}
+	return r
+}
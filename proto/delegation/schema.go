@@ -0,0 +1,32 @@
+// Package delegation implements a registry of voting power delegations between community members.
+package delegation
+
+import (
+	"github.com/gov4git/gov4git/v2/proto"
+	"github.com/gov4git/gov4git/v2/proto/kv"
+	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/gov4git/v2/proto/purpose"
+)
+
+var (
+	delegationNS = proto.RootNS.Append("delegation")
+	delegationKV = kv.KV[member.User, UserDelegations]{}
+)
+
+// AllPurposes is the purpose of a delegation that applies to ballots of any purpose,
+// unless the user has a more specific delegation for the purpose of the ballot.
+const AllPurposes purpose.Purpose = ""
+
+// UserDelegations records the delegates of a user, by ballot purpose.
+type UserDelegations struct {
+	User      member.User                     `json:"user"`
+	ByPurpose map[purpose.Purpose]member.User `json:"by_purpose"`
+}
+
+func (x UserDelegations) Lookup(p purpose.Purpose) (member.User, bool) {
+	if d, ok := x.ByPurpose[p]; ok {
+		return d, true
+	}
+	d, ok := x.ByPurpose[AllPurposes]
+	return d, ok
+}
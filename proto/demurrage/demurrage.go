@@ -0,0 +1,191 @@
+package demurrage
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/gov4git/gov4git/v2/proto"
+	"github.com/gov4git/gov4git/v2/proto/account"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/history/trace"
+	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/lib4git/form"
+	"github.com/gov4git/lib4git/git"
+	"github.com/gov4git/lib4git/must"
+)
+
+func Set(
+	ctx context.Context,
+	addr gov.Address,
+	p Policy,
+
+) {
+	proto.Retry(ctx, func() {
+		cloned := gov.Clone(ctx, addr)
+		Set_StageOnly(ctx, cloned, p)
+		proto.Commitf(ctx, cloned, "demurrage_set", "set demurrage policy for %v", p.Asset)
+		proto.Push(ctx, cloned)
+	})
+}
+
+// Set_StageOnly sets the demurrage policy of an asset, replacing any prior policy.
+// The first period of the policy starts when it is set.
+func Set_StageOnly(
+	ctx context.Context,
+	cloned gov.Cloned,
+	p Policy,
+
+) {
+	must.Assertf(ctx, p.Rate > 0 && p.Rate <= 1, "demurrage rate must be in (0, 1]")
+	must.Assertf(ctx, p.Period > 0, "demurrage period must be positive")
+	must.Assertf(ctx, p.Threshold.Sign() >= 0, "demurrage threshold must not be negative")
+	must.Assertf(ctx, member.IsGroup_Local(ctx, cloned, p.Group), "group %v not found", p.Group)
+	must.Assertf(ctx, account.Exists_Local(ctx, cloned, p.Destination), "destination account %v not found", p.Destination)
+	p.LastApplied = time.Now()
+
+	policyKV.Set(ctx, policyNS, cloned.Tree(), p.Asset, p)
+	trace.Log_StageOnly(ctx, cloned, &trace.Event{
+		Op:     "demurrage_set",
+		Args:   trace.M{"policy": p},
+		Result: nil,
+	})
+}
+
+func Remove(
+	ctx context.Context,
+	addr gov.Address,
+	asset account.Asset,
+
+) {
+	proto.Retry(ctx, func() {
+		cloned := gov.Clone(ctx, addr)
+		Remove_StageOnly(ctx, cloned, asset)
+		proto.Commitf(ctx, cloned, "demurrage_remove", "remove demurrage policy for %v", asset)
+		proto.Push(ctx, cloned)
+	})
+}
+
+func Remove_StageOnly(
+	ctx context.Context,
+	cloned gov.Cloned,
+	asset account.Asset,
+
+) {
+	must.Assertf(ctx, policyKV.Contains(ctx, policyNS, cloned.Tree(), asset), "no demurrage policy for %v", asset)
+	policyKV.Remove(ctx, policyNS, cloned.Tree(), asset)
+	trace.Log_StageOnly(ctx, cloned, &trace.Event{
+		Op:     "demurrage_remove",
+		Args:   trace.M{"asset": asset},
+		Result: nil,
+	})
+}
+
+func List(
+	ctx context.Context,
+	addr gov.Address,
+
+) []Policy {
+	return List_Local(ctx, gov.Clone(ctx, addr))
+}
+
+func List_Local(
+	ctx context.Context,
+	cloned gov.Cloned,
+
+) []Policy {
+	if _, err := git.TreeStat(ctx, cloned.Tree(), policyNS); git.IsNotExist(err) {
+		return nil
+	}
+	assets := policyKV.ListKeys(ctx, policyNS, cloned.Tree())
+	slices.Sort(assets)
+	return policyKV.GetMany(ctx, policyNS, cloned.Tree(), assets)
+}
+
+func Apply(
+	ctx context.Context,
+	addr gov.Address,
+	now time.Time,
+
+) git.Change[form.Map, ApplyResults] {
+	return proto.Retry1(ctx, func() git.Change[form.Map, ApplyResults] {
+		cloned := gov.Clone(ctx, addr)
+		chg := Apply_StageOnly(ctx, cloned, now)
+		proto.CommitIfChanged(ctx, cloned, chg)
+		proto.Push(ctx, cloned)
+		return chg
+	})
+}
+
+// Apply_StageOnly charges the demurrage due for all full periods elapsed since each policy was last applied.
+func Apply_StageOnly(
+	ctx context.Context,
+	cloned gov.Cloned,
+	now time.Time,
+
+) git.Change[form.Map, ApplyResults] {
+
+	results := ApplyResults{}
+	for _, p := range List_Local(ctx, cloned) {
+		n := int64(now.Sub(p.LastApplied) / p.Period)
+		if n <= 0 {
+			continue
+		}
+		results = append(results, applyPolicy_StageOnly(ctx, cloned, p, n))
+		p.LastApplied = p.LastApplied.Add(time.Duration(n) * p.Period)
+		policyKV.Set(ctx, policyNS, cloned.Tree(), p.Asset, p)
+	}
+
+	return git.NewChange(
+		fmt.Sprintf("Applied %d demurrage policies", len(results)),
+		"demurrage_apply",
+		form.Map{"now": now},
+		results,
+		nil,
+	)
+}
+
+func applyPolicy_StageOnly(
+	ctx context.Context,
+	cloned gov.Cloned,
+	p Policy,
+	periods int64,
+
+) ApplyResult {
+
+	result := ApplyResult{Asset: p.Asset, Periods: periods, Decayed: map[member.User]account.Holding{}}
+	factor := 1 - math.Pow(1-p.Rate, float64(periods))
+	divisible := account.LookupAsset_Local(ctx, cloned, p.Asset).Divisible
+	users := member.ListGroupUsers_Local(ctx, cloned, p.Group)
+	slices.Sort(users)
+	for _, u := range users {
+		balance := account.Get_Local(ctx, cloned, member.UserAccountID(u)).Balance(p.Asset).Quantity
+		excess := balance.Sub(p.Threshold)
+		if excess.Sign() <= 0 {
+			continue
+		}
+		decay := account.Q(excess.Float64() * factor)
+		if !divisible {
+			decay = decay.Trunc()
+		}
+		if decay.Sign() <= 0 {
+			continue
+		}
+		if decay.Cmp(excess) > 0 {
+			decay = excess
+		}
+		amount := account.HQ(p.Asset, decay)
+		account.Decay_StageOnly(
+			ctx,
+			cloned,
+			member.UserAccountID(u),
+			p.Destination,
+			amount,
+			fmt.Sprintf("demurrage on %v for %d periods", p.Asset, periods),
+		)
+		result.Decayed[u] = amount
+	}
+	return result
+}
@@ -0,0 +1,38 @@
+// Package demurrage implements policies that decay idle member balances, to keep credits circulating.
+package demurrage
+
+import (
+	"time"
+
+	"github.com/gov4git/gov4git/v2/proto"
+	"github.com/gov4git/gov4git/v2/proto/account"
+	"github.com/gov4git/gov4git/v2/proto/kv"
+	"github.com/gov4git/gov4git/v2/proto/member"
+)
+
+var (
+	policyNS = proto.RootNS.Append("demurrage")
+	policyKV = kv.KV[account.Asset, Policy]{}
+)
+
+// Policy decays the balances of an asset held by the members of Group.
+// Once per Period, the fraction Rate of the part of each balance above Threshold is moved to the Destination account.
+// Periods elapsed since the policy was last applied are compounded.
+type Policy struct {
+	Asset       account.Asset     `json:"asset"`
+	Group       member.Group      `json:"group"`
+	Rate        float64           `json:"rate"`
+	Period      time.Duration     `json:"period"`
+	Threshold   account.Quantity  `json:"threshold"`
+	Destination account.AccountID `json:"destination"`
+	LastApplied time.Time         `json:"last_applied"`
+}
+
+// ApplyResult records the demurrage charged by a policy.
+type ApplyResult struct {
+	Asset   account.Asset                   `json:"asset"`
+	Periods int64                           `json:"periods"`
+	Decayed map[member.User]account.Holding `json:"decayed"`
+}
+
+type ApplyResults []ApplyResult
@@ -16,9 +16,11 @@ func SetSettings(
 	config Settings,
 ) git.Change[Settings, form.None] {
 
-	cloned := gov.Clone(ctx, addr)
-	chg := SetSettings_StageOnly(ctx, cloned, config)
-	return proto.CommitIfChanged(ctx, cloned, chg)
+	return proto.Retry1(ctx, func() git.Change[Settings, form.None] {
+		cloned := gov.Clone(ctx, addr)
+		chg := SetSettings_StageOnly(ctx, cloned, config)
+		return proto.CommitIfChanged(ctx, cloned, chg)
+	})
 }
 
 func SetSettings_StageOnly(
@@ -7,15 +7,18 @@ type Asset string
 type Holding struct {
 	Asset    Asset   `json:"asset"`
 	Quantity float64 `json:"quantity"`
+	Exact    string  `json:"exact,omitempty"` // exact decimal quantity; absent from holdings recorded before quantities were exact
 }
 
 type AccountEvent struct {
 	Issue    *AccountIssueEvent    `json:"issue"`
 	Burn     *AccountBurnEvent     `json:"burn"`
 	Transfer *AccountTransferEvent `json:"transfer"`
+	Decay    *AccountDecayEvent    `json:"decay,omitempty"`
 }
 
 type AccountIssueEvent struct {
+	Issuer AccountID `json:"issuer,omitempty"` // account the amount was issued from; the asset's issuer, if empty
 	To     AccountID `json:"to"`
 	Amount Holding   `json:"amount"`
 }
@@ -30,3 +33,10 @@ type AccountTransferEvent struct {
 	To     AccountID `json:"to"`
 	Amount Holding   `json:"amount"`
 }
+
+// AccountDecayEvent records the demurrage charged on an account balance.
+type AccountDecayEvent struct {
+	From   AccountID `json:"from"`
+	To     AccountID `json:"to"`
+	Amount Holding   `json:"amount"`
+}
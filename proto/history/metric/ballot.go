@@ -0,0 +1,25 @@
+package metric
+
+import "time"
+
+type BallotID string
+
+type BallotEvent struct {
+	Freeze *BallotFreeze `json:"freeze,omitempty"`
+	Close  *BallotClose  `json:"close,omitempty"`
+}
+
+// BallotFreeze records the scheduled freezing of a ballot.
+type BallotFreeze struct {
+	ID       BallotID     `json:"id"`
+	Policy   BallotPolicy `json:"policy"`
+	Deadline time.Time    `json:"deadline"`
+}
+
+// BallotClose records the scheduled closing of a ballot.
+type BallotClose struct {
+	ID       BallotID     `json:"id"`
+	Policy   BallotPolicy `json:"policy"`
+	Deadline time.Time    `json:"deadline"`
+	Receipts Receipts     `json:"receipts"`
+}
@@ -12,4 +12,5 @@ type Event struct {
 	Motion  *MotionEvent  `json:"motion,omitempty"`
 	Account *AccountEvent `json:"account,omitempty"`
 	Vote    *VoteEvent    `json:"vote,omitempty"`
+	Ballot  *BallotEvent  `json:"ballot,omitempty"`
 }
@@ -3,6 +3,7 @@ package metric
 import (
 	"github.com/gov4git/gov4git/v2/proto/gov"
 	"github.com/gov4git/gov4git/v2/proto/journal"
+	"github.com/gov4git/lib4git/ns"
 	"golang.org/x/net/context"
 )
 
@@ -54,3 +55,8 @@ func IsMuted(ctx context.Context) bool {
 	v, ok := ctx.Value(muteCtxKey{}).(bool)
 	return ok && v
 }
+
+// HistoryNS returns the namespace of the metric history in the community repo.
+func HistoryNS() ns.NS {
+	return metricHistoryNS
+}
@@ -4,15 +4,22 @@ import (
 	"bytes"
 	"context"
 	"crypto/ed25519"
+	crypto_rand "crypto/rand"
+	"crypto/sha256"
+	"fmt"
 
 	"github.com/gov4git/lib4git/form"
 	"github.com/gov4git/lib4git/must"
+	"golang.org/x/crypto/curve25519"
+	"golang.org/x/crypto/nacl/box"
 )
 
 type Ed25519PublicKey = form.Bytes
 
 type Ed25519PrivateKey = form.Bytes
 
+type Curve25519PublicKey = form.Bytes
+
 func GenerateCredentials() (PrivateCredentials, error) {
 	pubKey, privKey, err := ed25519.GenerateKey(nil)
 	if err != nil {
@@ -70,3 +77,47 @@ func Sign[V form.Form](ctx context.Context, priv PrivateCredentials, value V) Si
 	signature, pubKey := SignBytes(ctx, priv, data)
 	return Signed[V]{Value: value, Plaintext: data, Signature: signature, PublicKeyEd25519: pubKey}
 }
+
+// encryption
+
+// DeriveBoxKeys derives a curve25519 key pair, for anonymous encryption, from the owner's private credentials and a label.
+// Distinct labels result in independent key pairs.
+// The private key is never stored, as it can be re-derived by the owner when needed.
+func DeriveBoxKeys(ctx context.Context, priv PrivateCredentials, label string) (pubKey *[32]byte, privKey *[32]byte) {
+	h := sha256.New()
+	h.Write(priv.PrivateKeyEd25519)
+	h.Write([]byte("gov4git-box:" + label))
+	privKey = new([32]byte)
+	copy(privKey[:], h.Sum(nil))
+	pub, err := curve25519.X25519(privKey[:], curve25519.Basepoint)
+	must.NoError(ctx, err)
+	pubKey = new([32]byte)
+	copy(pubKey[:], pub)
+	return pubKey, privKey
+}
+
+// DeriveBoxPublicKey returns the public key of the key pair derived by DeriveBoxKeys.
+func DeriveBoxPublicKey(ctx context.Context, priv PrivateCredentials, label string) Curve25519PublicKey {
+	pubKey, _ := DeriveBoxKeys(ctx, priv, label)
+	return Curve25519PublicKey(pubKey[:])
+}
+
+// SealAnonymous encrypts a plaintext to the given public key, so that only the owner of the private key can decrypt it.
+func SealAnonymous(ctx context.Context, pubKey Curve25519PublicKey, plaintext []byte) form.Bytes {
+	must.Assertf(ctx, len(pubKey) == 32, "invalid curve25519 public key")
+	var key [32]byte
+	copy(key[:], pubKey)
+	sealed, err := box.SealAnonymous(nil, plaintext, &key, crypto_rand.Reader)
+	must.NoError(ctx, err)
+	return form.Bytes(sealed)
+}
+
+// OpenAnonymous decrypts a ciphertext sealed to the key pair derived from the owner's private credentials and the given label.
+func OpenAnonymous(ctx context.Context, priv PrivateCredentials, label string, sealed []byte) ([]byte, error) {
+	pubKey, privKey := DeriveBoxKeys(ctx, priv, label)
+	plaintext, ok := box.OpenAnonymous(nil, sealed, pubKey, privKey)
+	if !ok {
+		return nil, fmt.Errorf("cannot decrypt sealed message")
+	}
+	return plaintext, nil
+}
@@ -0,0 +1,29 @@
+package id
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+func TestSealAnonymous(t *testing.T) {
+	ctx := context.Background()
+	cred, err := GenerateCredentials()
+	if err != nil {
+		t.Fatal(err)
+	}
+	plaintext := []byte("secret vote")
+
+	sealed := SealAnonymous(ctx, DeriveBoxPublicKey(ctx, cred, "a"), plaintext)
+	opened, err := OpenAnonymous(ctx, cred, "a", sealed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(opened, plaintext) {
+		t.Errorf("expecting %q, got %q", plaintext, opened)
+	}
+
+	if _, err := OpenAnonymous(ctx, cred, "b", sealed); err == nil {
+		t.Errorf("opening with a different label should fail")
+	}
+}
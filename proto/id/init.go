@@ -18,8 +18,8 @@ func Init(
 	ownerCloned := CloneOwner(ctx, ownerAddr)
 	privChg := Init_Local(ctx, ownerCloned)
 
-	ownerCloned.Public.Push(ctx)
-	ownerCloned.Private.Push(ctx)
+	proto.Push(ctx, ownerCloned.Public)
+	proto.Push(ctx, ownerCloned.Private)
 	return privChg
 }
 
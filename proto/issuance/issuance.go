@@ -0,0 +1,227 @@
+package issuance
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/gov4git/gov4git/v2/proto"
+	"github.com/gov4git/gov4git/v2/proto/account"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/history/trace"
+	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/lib4git/form"
+	"github.com/gov4git/lib4git/git"
+	"github.com/gov4git/lib4git/must"
+)
+
+func Create(
+	ctx context.Context,
+	addr gov.Address,
+	s Schedule,
+
+) {
+	proto.Retry(ctx, func() {
+		cloned := gov.Clone(ctx, addr)
+		Create_StageOnly(ctx, cloned, s)
+		proto.Commitf(ctx, cloned, "issuance_create", "create issuance schedule %v", s.ID)
+		proto.Push(ctx, cloned)
+	})
+}
+
+func Create_StageOnly(
+	ctx context.Context,
+	cloned gov.Cloned,
+	s Schedule,
+
+) {
+	must.Assertf(ctx, s.ID != "", "schedule id is empty")
+	must.Assertf(ctx, !scheduleKV.Contains(ctx, scheduleNS, cloned.Tree(), s.ID), "schedule %v already exists", s.ID)
+	must.Assertf(ctx, s.Period > 0, "schedule period must be positive")
+	must.Assertf(ctx, s.Amount.Quantity.Sign() > 0, "schedule amount must be positive")
+	verifyIssuable_Local(ctx, cloned, s)
+	if s.Start.IsZero() {
+		s.Start = time.Now()
+	}
+	s.LastPeriod = -1
+
+	scheduleKV.Set(ctx, scheduleNS, cloned.Tree(), s.ID, s)
+	trace.Log_StageOnly(ctx, cloned, &trace.Event{
+		Op:     "issuance_create",
+		Args:   trace.M{"schedule": s},
+		Result: nil,
+	})
+}
+
+func Remove(
+	ctx context.Context,
+	addr gov.Address,
+	id ScheduleID,
+
+) {
+	proto.Retry(ctx, func() {
+		cloned := gov.Clone(ctx, addr)
+		Remove_StageOnly(ctx, cloned, id)
+		proto.Commitf(ctx, cloned, "issuance_remove", "remove issuance schedule %v", id)
+		proto.Push(ctx, cloned)
+	})
+}
+
+func Remove_StageOnly(
+	ctx context.Context,
+	cloned gov.Cloned,
+	id ScheduleID,
+
+) {
+	must.Assertf(ctx, scheduleKV.Contains(ctx, scheduleNS, cloned.Tree(), id), "schedule %v not found", id)
+	scheduleKV.Remove(ctx, scheduleNS, cloned.Tree(), id)
+	trace.Log_StageOnly(ctx, cloned, &trace.Event{
+		Op:     "issuance_remove",
+		Args:   trace.M{"id": id},
+		Result: nil,
+	})
+}
+
+func Get_Local(
+	ctx context.Context,
+	cloned gov.Cloned,
+	id ScheduleID,
+
+) Schedule {
+	return scheduleKV.Get(ctx, scheduleNS, cloned.Tree(), id)
+}
+
+func List(
+	ctx context.Context,
+	addr gov.Address,
+
+) []Schedule {
+	return List_Local(ctx, gov.Clone(ctx, addr))
+}
+
+func List_Local(
+	ctx context.Context,
+	cloned gov.Cloned,
+
+) []Schedule {
+	if _, err := git.TreeStat(ctx, cloned.Tree(), scheduleNS); git.IsNotExist(err) {
+		return nil
+	}
+	ids := scheduleKV.ListKeys(ctx, scheduleNS, cloned.Tree())
+	slices.Sort(ids)
+	return scheduleKV.GetMany(ctx, scheduleNS, cloned.Tree(), ids)
+}
+
+func Run(
+	ctx context.Context,
+	addr gov.Address,
+	now time.Time,
+
+) git.Change[form.Map, RunResults] {
+	return proto.Retry1(ctx, func() git.Change[form.Map, RunResults] {
+		cloned := gov.Clone(ctx, addr)
+		chg := Run_StageOnly(ctx, cloned, now)
+		proto.CommitIfChanged(ctx, cloned, chg)
+		proto.Push(ctx, cloned)
+		return chg
+	})
+}
+
+// Run_StageOnly issues the current period of every schedule whose current period has not been issued yet.
+// Only the current period is issued: periods that passed while the schedule was not run are skipped, not issued retroactively.
+// A schedule that cannot be issued, e.g. because its group or the issuer of its asset was removed, is reported in its result,
+// and does not prevent other schedules from running. It is retried by the next run.
+func Run_StageOnly(
+	ctx context.Context,
+	cloned gov.Cloned,
+	now time.Time,
+
+) git.Change[form.Map, RunResults] {
+
+	results := RunResults{}
+	for _, s := range List_Local(ctx, cloned) {
+		period := s.PeriodAt(now)
+		if period < 0 || period <= s.LastPeriod {
+			continue
+		}
+		result, err := must.Try1(func() RunResult { return runPeriod_StageOnly(ctx, cloned, s, period) })
+		if err != nil {
+			results = append(results, RunResult{Schedule: s.ID, Period: period, Error: err.Error()})
+			continue
+		}
+		results = append(results, result)
+		s.LastPeriod = period
+		scheduleKV.Set(ctx, scheduleNS, cloned.Tree(), s.ID, s)
+	}
+
+	return git.NewChange(
+		fmt.Sprintf("Ran %d issuance schedules", len(results)),
+		"issuance_run",
+		form.Map{"now": now},
+		results,
+		nil,
+	)
+}
+
+// verifyIssuable_Local checks that the amount of the schedule can be issued to its group.
+func verifyIssuable_Local(
+	ctx context.Context,
+	cloned gov.Cloned,
+	s Schedule,
+
+) {
+	must.Assertf(ctx, member.IsGroup_Local(ctx, cloned, s.Group), "group %v not found", s.Group)
+	account.VerifyHolding_Local(ctx, cloned, s.Amount)
+	issuer := account.LookupAsset_Local(ctx, cloned, s.Amount.Asset).Issuer
+	must.Assertf(ctx, account.Exists_Local(ctx, cloned, issuer), "issuer account %v of asset %v does not exist", issuer, s.Amount.Asset)
+}
+
+func runPeriod_StageOnly(
+	ctx context.Context,
+	cloned gov.Cloned,
+	s Schedule,
+	period int64,
+
+) RunResult {
+
+	// compute the amounts issued to all users, before staging any of them
+	verifyIssuable_Local(ctx, cloned, s)
+	result := RunResult{Schedule: s.ID, Period: period, Issued: map[member.User]account.Holding{}}
+	divisible := account.LookupAsset_Local(ctx, cloned, s.Amount.Asset).Divisible
+	users := member.ListGroupUsers_Local(ctx, cloned, s.Group)
+	slices.Sort(users)
+	for _, u := range users {
+		must.Assertf(ctx, account.Exists_Local(ctx, cloned, member.UserAccountID(u)), "account of user %v does not exist", u)
+		amount := s.Amount
+		if s.MaxBalance != nil {
+			balance := account.Get_Local(ctx, cloned, member.UserAccountID(u)).Balance(s.Amount.Asset).Quantity
+			room := s.MaxBalance.Sub(balance)
+			if !divisible {
+				room = room.Trunc()
+			}
+			if room.Sign() <= 0 {
+				continue
+			}
+			if room.Cmp(amount.Quantity) < 0 {
+				amount = account.HQ(amount.Asset, room)
+			}
+		}
+		result.Issued[u] = amount
+	}
+
+	for _, u := range users {
+		amount, ok := result.Issued[u]
+		if !ok {
+			continue
+		}
+		account.Issue_StageOnly(
+			ctx,
+			cloned,
+			member.UserAccountID(u),
+			amount,
+			fmt.Sprintf("issuance schedule %v, period %d (%v)", s.ID, period, s.Note),
+		)
+	}
+	return result
+}
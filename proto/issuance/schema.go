@@ -0,0 +1,51 @@
+// Package issuance implements recurring schedules for issuing assets to the members of a group.
+package issuance
+
+import (
+	"time"
+
+	"github.com/gov4git/gov4git/v2/proto"
+	"github.com/gov4git/gov4git/v2/proto/account"
+	"github.com/gov4git/gov4git/v2/proto/kv"
+	"github.com/gov4git/gov4git/v2/proto/member"
+)
+
+var (
+	scheduleNS = proto.RootNS.Append("issuance")
+	scheduleKV = kv.KV[ScheduleID, Schedule]{}
+)
+
+type ScheduleID string
+
+// Schedule issues Amount to every member of Group once per Period.
+// Periods are counted from Start, and each period is issued at most once,
+// so running the schedule repeatedly within a period does not issue again.
+// Periods in which the schedule was not run are not issued retroactively.
+type Schedule struct {
+	ID         ScheduleID        `json:"id"`
+	Group      member.Group      `json:"group"`
+	Amount     account.Holding   `json:"amount"`
+	Period     time.Duration     `json:"period"`
+	Start      time.Time         `json:"start"`
+	MaxBalance *account.Quantity `json:"max_balance,omitempty"` // if set, members are issued only up to this balance
+	Note       string            `json:"note"`
+	LastPeriod int64             `json:"last_period"` // most recent period issued, or -1 if none
+}
+
+// PeriodAt returns the index of the period containing the given time, or -1 if the schedule has not started.
+func (x Schedule) PeriodAt(t time.Time) int64 {
+	if t.Before(x.Start) {
+		return -1
+	}
+	return int64(t.Sub(x.Start) / x.Period)
+}
+
+// RunResult records the issuance made by a schedule for one period.
+type RunResult struct {
+	Schedule ScheduleID                      `json:"schedule"`
+	Period   int64                           `json:"period"`
+	Issued   map[member.User]account.Holding `json:"issued"`
+	Error    string                          `json:"error,omitempty"` // if set, nothing was issued, and the period is retried by the next run
+}
+
+type RunResults []RunResult
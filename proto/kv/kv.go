@@ -28,6 +28,11 @@ func (KV[K, V]) KeyNS(ns ns.NS, key K) ns.NS {
 	return ns.Append(form.StringHashForFilename(string(key)))
 }
 
+// ValueNS returns the path of the file holding the value for a key.
+func (x KV[K, V]) ValueNS(ns ns.NS, key K) ns.NS {
+	return x.KeyNS(ns, key).Append(valueFilebase)
+}
+
 func (x KV[K, V]) Set(ctx context.Context, ns ns.NS, t *git.Tree, key K, value V) git.ChangeNoResult {
 	keyNS := x.KeyNS(ns, key)
 	git.TreeMkdirAll(ctx, t, keyNS)
@@ -2,6 +2,7 @@ package mail
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sort"
 	"strconv"
@@ -31,6 +32,15 @@ type Receiver[Msg form.Form, Effect form.Form] func(
 	msg Msg,
 ) (effect Effect, err error)
 
+// ReceiveFailed is called for each message that is skipped, because the receiver returned an error.
+type ReceiveFailed func(
+	ctx context.Context,
+	seqNo SeqNo,
+	err error,
+)
+
+var ErrSignatureNotValid = errors.New("signature not valid")
+
 func Receive_StageOnly[Msg form.Form, Effect form.Form](
 	ctx context.Context,
 	receiver *git.Tree,
@@ -40,6 +50,20 @@ func Receive_StageOnly[Msg form.Form, Effect form.Form](
 	receive Receiver[Msg, Effect], // called multiple times, once for each incoming message
 ) git.Change[form.Map, []MsgEffect[Msg, Effect]] {
 
+	return ReceiveWithFailures_StageOnly(ctx, receiver, senderAddr, sender, topic, receive, nil)
+}
+
+// ReceiveWithFailures_StageOnly is like Receive_StageOnly, and also reports the messages that are skipped to failed.
+func ReceiveWithFailures_StageOnly[Msg form.Form, Effect form.Form](
+	ctx context.Context,
+	receiver *git.Tree,
+	senderAddr id.PublicAddress,
+	sender *git.Tree,
+	topic string,
+	receive Receiver[Msg, Effect], // called multiple times, once for each incoming message
+	failed ReceiveFailed, // optional
+) git.Change[form.Map, []MsgEffect[Msg, Effect]] {
+
 	// prep
 	receiverCred := id.GetPublicCredentials(ctx, receiver)
 	senderCred := id.GetPublicCredentials(ctx, sender)
@@ -67,6 +91,9 @@ func Receive_StageOnly[Msg form.Form, Effect form.Form](
 		effect, err := receive(ctx, i, msg)
 		if err != nil {
 			base.Infof("responding to message %d in sender repo (%v)", i, err)
+			if failed != nil {
+				failed(ctx, i, err)
+			}
 			continue
 		}
 		msgEffect := MsgEffect[Msg, Effect]{SeqNo: i, Msg: msg, Effect: effect}
@@ -102,6 +129,21 @@ func ReceiveSigned_StageOnly[Msg form.Form, Effect form.Form](
 	receive SignedReceiver[Msg, Effect],
 ) git.Change[form.Map, []MsgEffect[Msg, Effect]] {
 
+	return ReceiveSignedWithFailures_StageOnly(ctx, receiverCloned, senderAddr, senderPublic, topic, receive, nil)
+}
+
+// ReceiveSignedWithFailures_StageOnly is like ReceiveSigned_StageOnly, and also reports the messages that are skipped to failed.
+// Messages with invalid signatures are reported with ErrSignatureNotValid.
+func ReceiveSignedWithFailures_StageOnly[Msg form.Form, Effect form.Form](
+	ctx context.Context,
+	receiverCloned id.OwnerCloned,
+	senderAddr id.PublicAddress,
+	senderPublic *git.Tree,
+	topic string,
+	receive SignedReceiver[Msg, Effect],
+	failed ReceiveFailed, // optional
+) git.Change[form.Map, []MsgEffect[Msg, Effect]] {
+
 	receiverPrivCred := id.GetOwnerCredentials(ctx, receiverCloned)
 	var receiver Receiver[id.Signed[Msg], id.Signed[Effect]] = func(
 		ctx context.Context,
@@ -109,7 +151,7 @@ func ReceiveSigned_StageOnly[Msg form.Form, Effect form.Form](
 		signedReq id.Signed[Msg],
 	) (signedResp id.Signed[Effect], err error) {
 		if !signedReq.Verify(ctx) {
-			return signedResp, fmt.Errorf("signature not valid")
+			return signedResp, ErrSignatureNotValid
 		}
 		effect, err := receive(ctx, seqNo, signedReq)
 		if err != nil {
@@ -117,7 +159,7 @@ func ReceiveSigned_StageOnly[Msg form.Form, Effect form.Form](
 		}
 		return id.Sign(ctx, receiverPrivCred, effect), nil
 	}
-	recvOnly := Receive_StageOnly[id.Signed[Msg], id.Signed[Effect]](ctx, receiverCloned.Public.Tree(), senderAddr, senderPublic, topic, receiver)
+	recvOnly := ReceiveWithFailures_StageOnly[id.Signed[Msg], id.Signed[Effect]](ctx, receiverCloned.Public.Tree(), senderAddr, senderPublic, topic, receiver, failed)
 	msgEffects := make([]MsgEffect[Msg, Effect], len(recvOnly.Result))
 	for i, signedMsgEffect := range recvOnly.Result {
 		msgEffects[i] = MsgEffect[Msg, Effect]{
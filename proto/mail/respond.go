@@ -25,6 +25,20 @@ func Respond_StageOnly[Req form.Form, Resp form.Form](
 	respond Responder[Req, Resp],
 ) git.Change[form.Map, []ResponseEnvelope[Resp]] {
 
+	return RespondWithFailures_StageOnly(ctx, receiverCloned, senderAddr, senderPublic, topic, respond, nil)
+}
+
+// RespondWithFailures_StageOnly is like Respond_StageOnly, and also reports the requests that are skipped to failed.
+func RespondWithFailures_StageOnly[Req form.Form, Resp form.Form](
+	ctx context.Context,
+	receiverCloned id.OwnerCloned,
+	senderAddr id.PublicAddress,
+	senderPublic *git.Tree,
+	topic string,
+	respond Responder[Req, Resp],
+	failed ReceiveFailed, // optional
+) git.Change[form.Map, []ResponseEnvelope[Resp]] {
+
 	var signedReceive SignedReceiver[RequestEnvelope[Req], ResponseEnvelope[Resp]] = func(
 		ctx context.Context,
 		seqNo SeqNo,
@@ -43,7 +57,7 @@ func Respond_StageOnly[Req form.Form, Resp form.Form](
 		}, nil
 	}
 
-	chg := ReceiveSigned_StageOnly[RequestEnvelope[Req], ResponseEnvelope[Resp]](ctx, receiverCloned, senderAddr, senderPublic, topic, signedReceive)
+	chg := ReceiveSignedWithFailures_StageOnly[RequestEnvelope[Req], ResponseEnvelope[Resp]](ctx, receiverCloned, senderAddr, senderPublic, topic, signedReceive, failed)
 	respEnvs := make([]ResponseEnvelope[Resp], len(chg.Result))
 	for i, msgEffect := range chg.Result {
 		respEnvs[i] = msgEffect.Effect
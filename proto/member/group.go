@@ -13,10 +13,12 @@ import (
 )
 
 func SetGroup(ctx context.Context, addr gov.Address, name Group) {
-	cloned := gov.Clone(ctx, addr)
-	chg := SetGroup_StageOnly(ctx, cloned, name)
-	proto.Commit(ctx, cloned.Tree(), chg)
-	cloned.Push(ctx)
+	proto.Retry(ctx, func() {
+		cloned := gov.Clone(ctx, addr)
+		chg := SetGroup_StageOnly(ctx, cloned, name)
+		proto.Commit(ctx, cloned.Tree(), chg)
+		proto.Push(ctx, cloned)
+	})
 }
 
 func SetGroup_StageOnly(ctx context.Context, cloned gov.Cloned, name Group) git.ChangeNoResult {
@@ -33,10 +35,12 @@ func IsGroup_Local(ctx context.Context, cloned gov.Cloned, name Group) bool {
 }
 
 func AddGroup(ctx context.Context, addr gov.Address, name Group) {
-	cloned := gov.Clone(ctx, addr)
-	chg := AddGroup_StageOnly(ctx, cloned, name)
-	proto.Commit(ctx, cloned.Tree(), chg)
-	cloned.Push(ctx)
+	proto.Retry(ctx, func() {
+		cloned := gov.Clone(ctx, addr)
+		chg := AddGroup_StageOnly(ctx, cloned, name)
+		proto.Commit(ctx, cloned.Tree(), chg)
+		proto.Push(ctx, cloned)
+	})
 }
 
 func AddGroup_StageOnly(ctx context.Context, cloned gov.Cloned, name Group) git.ChangeNoResult {
@@ -56,10 +60,12 @@ func AddGroup_StageOnly(ctx context.Context, cloned gov.Cloned, name Group) git.
 }
 
 func RemoveGroup(ctx context.Context, addr gov.Address, name Group) {
-	cloned := gov.Clone(ctx, addr)
-	chg := RemoveGroup_StageOnly(ctx, cloned, name)
-	proto.Commit(ctx, cloned.Tree(), chg)
-	cloned.Push(ctx)
+	proto.Retry(ctx, func() {
+		cloned := gov.Clone(ctx, addr)
+		chg := RemoveGroup_StageOnly(ctx, cloned, name)
+		proto.Commit(ctx, cloned.Tree(), chg)
+		proto.Push(ctx, cloned)
+	})
 }
 
 func RemoveGroup_StageOnly(ctx context.Context, cloned gov.Cloned, name Group) git.ChangeNoResult {
@@ -34,10 +34,12 @@ func (u User) MetricAccountID() metric.AccountID {
 type Group string
 
 func AddMember(ctx context.Context, addr gov.Address, user User, group Group) {
-	cloned := gov.Clone(ctx, addr)
-	chg := AddMember_StageOnly(ctx, cloned, user, group)
-	proto.Commit(ctx, cloned.Tree(), chg)
-	cloned.Push(ctx)
+	proto.Retry(ctx, func() {
+		cloned := gov.Clone(ctx, addr)
+		chg := AddMember_StageOnly(ctx, cloned, user, group)
+		proto.Commit(ctx, cloned.Tree(), chg)
+		proto.Push(ctx, cloned)
+	})
 }
 
 func AddMember_StageOnly(ctx context.Context, cloned gov.Cloned, user User, group Group) git.ChangeNoResult {
@@ -70,10 +72,12 @@ func IsMember_Local(ctx context.Context, cloned gov.Cloned, user User, group Gro
 }
 
 func RemoveMember(ctx context.Context, addr gov.Address, user User, group Group) {
-	cloned := gov.Clone(ctx, addr)
-	chg := RemoveMember_StageOnly(ctx, cloned, user, group)
-	proto.Commit(ctx, cloned.Tree(), chg)
-	cloned.Push(ctx)
+	proto.Retry(ctx, func() {
+		cloned := gov.Clone(ctx, addr)
+		chg := RemoveMember_StageOnly(ctx, cloned, user, group)
+		proto.Commit(ctx, cloned.Tree(), chg)
+		proto.Push(ctx, cloned)
+	})
 }
 
 func RemoveMember_StageOnly(ctx context.Context, cloned gov.Cloned, user User, group Group) git.ChangeNoResult {
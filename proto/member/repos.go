@@ -0,0 +1,191 @@
+package member
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/gov4git/gov4git/v2/proto/id"
+	"github.com/gov4git/lib4git/base"
+	"github.com/gov4git/lib4git/git"
+	"github.com/gov4git/lib4git/must"
+)
+
+// PublicRepos fetches the public repos of users at most once, and shares the clones among all operations that read them,
+// e.g. the tallies of all open ballots and the processing of bureau requests during a sync.
+// Without a git cache, remote repos are fetched shallowly, and only the branch in the user's public address is fetched.
+// With a git cache, see WithGitCache, repos are fetched through the cache.
+// Clones are read-only.
+type PublicRepos struct {
+	lk    sync.Mutex
+	repos map[id.PublicAddress]*publicRepo
+	stats PublicRepoStats
+}
+
+type publicRepo struct {
+	done   chan struct{} // closed when the fetch completes
+	cloned git.Cloned
+	err    error
+}
+
+type PublicRepoStats struct {
+	Fetched      int     `json:"fetched"`       // repos fetched successfully
+	Unreachable  int     `json:"unreachable"`   // repos that could not be fetched
+	Reused       int     `json:"reused"`        // clones served without fetching again
+	FetchSeconds float64 `json:"fetch_seconds"` // time spent fetching, summed over parallel fetches
+}
+
+func NewPublicRepos() *PublicRepos {
+	return &PublicRepos{repos: map[id.PublicAddress]*publicRepo{}}
+}
+
+type publicReposCtxKey struct{}
+
+// WithPublicRepos returns a context, in which the public repos of users are cloned through the given cache.
+func WithPublicRepos(ctx context.Context, repos *PublicRepos) context.Context {
+	return context.WithValue(ctx, publicReposCtxKey{}, repos)
+}
+
+func GetPublicRepos(ctx context.Context) *PublicRepos {
+	repos, _ := ctx.Value(publicReposCtxKey{}).(*PublicRepos)
+	return repos
+}
+
+type gitCacheCtxKey struct{}
+
+// WithGitCache returns a context, in which git clones go through the on-disk cache at dir.
+// Public repos are then fetched through the cache, rather than shallowly, so that they benefit from cache updates.
+func WithGitCache(ctx context.Context, dir string) context.Context {
+	return context.WithValue(git.WithCache(ctx, dir), gitCacheCtxKey{}, true)
+}
+
+func hasGitCache(ctx context.Context) bool {
+	ok, _ := ctx.Value(gitCacheCtxKey{}).(bool)
+	return ok
+}
+
+// TryClonePublic clones the public repo of a user, through the public repos cache of the context, if there is one.
+func TryClonePublic(ctx context.Context, addr id.PublicAddress) (git.Cloned, error) {
+	if repos := GetPublicRepos(ctx); repos != nil {
+		return repos.TryClone(ctx, addr)
+	}
+	return git.TryCloneOne(ctx, git.Address(addr))
+}
+
+// TryClone returns the clone of the repo at addr, fetching it on first use.
+// Concurrent calls for the same repo wait for a single fetch.
+func (x *PublicRepos) TryClone(ctx context.Context, addr id.PublicAddress) (git.Cloned, error) {
+
+	x.lk.Lock()
+	if r, ok := x.repos[addr]; ok {
+		x.stats.Reused++
+		x.lk.Unlock()
+		<-r.done
+		return r.cloned, r.err
+	}
+	r := &publicRepo{done: make(chan struct{})}
+	x.repos[addr] = r
+	x.lk.Unlock()
+
+	start := time.Now()
+	if hasGitCache(ctx) {
+		r.cloned, r.err = git.TryCloneOne(ctx, git.Address(addr))
+	} else {
+		r.cloned, r.err = must.Try1(func() git.Cloned { return cloneShallow(ctx, git.Address(addr)) })
+	}
+	took := time.Since(start)
+	close(r.done)
+
+	x.lk.Lock()
+	defer x.lk.Unlock()
+	if r.err != nil {
+		x.stats.Unreachable++
+	} else {
+		x.stats.Fetched++
+	}
+	x.stats.FetchSeconds += took.Seconds()
+	base.Infof("fetched public repo %v in %v (%v)", addr, took, r.err)
+
+	return r.cloned, r.err
+}
+
+func (x *PublicRepos) Stats() PublicRepoStats {
+	x.lk.Lock()
+	defer x.lk.Unlock()
+	return x.stats
+}
+
+// cloneShallow fetches the latest commit of the branch at addr into memory.
+func cloneShallow(ctx context.Context, addr git.Address) git.Cloned {
+	c := &shallowClone{addr: addr, repo: git.InitInMemory(ctx)}
+	c.Pull(ctx)
+	err := must.Try(func() { git.Checkout(ctx, git.Worktree(ctx, c.repo), addr.Branch) })
+	switch {
+	case errors.Is(err, plumbing.ErrReferenceNotFound): // the branch does not exist yet
+		must.NoError(ctx, c.repo.CreateBranch(&config.Branch{Name: string(addr.Branch)}))
+		git.SetHeadToBranch(ctx, c.repo, addr.Branch)
+	case err != nil:
+		must.NoError(ctx, err)
+	}
+	return c
+}
+
+type shallowClone struct {
+	addr git.Address
+	repo *git.Repository
+}
+
+func (x *shallowClone) Push(ctx context.Context) {
+	must.Errorf(ctx, "shallow clone of %v cannot be pushed", x.addr.Repo)
+}
+
+func (x *shallowClone) Pull(ctx context.Context) {
+	remote := gogit.NewRemote(
+		x.repo.Storer,
+		&config.RemoteConfig{Name: "origin", URLs: []string{string(x.addr.Repo)}},
+	)
+	opts := shallowFetchOptions(x.addr)
+	opts.Auth = git.GetAuth(ctx, x.addr.Repo)
+	err := remote.FetchContext(ctx, opts)
+	// fail if the repo is inaccessible; ignore empty repos and missing branches
+	must.Assertf(ctx,
+		err == nil ||
+			git.IsRemoteRepoIsEmpty(err) ||
+			git.IsAlreadyUpToDate(err) ||
+			git.IsNoMatchingRefSpec(err), "url=%q branch=%q err=%v", x.addr.Repo, x.addr.Branch, err)
+}
+
+// shallowFetchOptions fetches only the branch in addr, at the depth given by fetchDepth.
+func shallowFetchOptions(addr git.Address) *gogit.FetchOptions {
+	refspec := config.RefSpec(fmt.Sprintf("refs/heads/%s:refs/heads/%s", addr.Branch, addr.Branch))
+	return &gogit.FetchOptions{
+		RemoteName: "origin",
+		RefSpecs:   []config.RefSpec{refspec},
+		Depth:      fetchDepth(addr.Repo),
+		Force:      true,
+	}
+}
+
+// fetchDepth returns 1 for remote repos.
+// Local repos are served by go-git, which does not support shallow fetches, so they are fetched in full.
+func fetchDepth(u git.URL) int {
+	if ep, err := transport.NewEndpoint(string(u)); err == nil && ep.Protocol == "file" {
+		return 0
+	}
+	return 1
+}
+
+func (x *shallowClone) Repo() *git.Repository {
+	return x.repo
+}
+
+func (x *shallowClone) Tree() *git.Tree {
+	t, _ := x.repo.Worktree()
+	return t
+}
@@ -0,0 +1,30 @@
+package member
+
+import (
+	"testing"
+
+	"github.com/go-git/go-git/v5/config"
+	"github.com/gov4git/lib4git/git"
+)
+
+func TestShallowFetchOptions(t *testing.T) {
+	cases := []struct {
+		addr  git.Address
+		depth int
+	}{
+		{git.Address{Repo: "https://github.com/a/b.git", Branch: git.MainBranch}, 1},
+		{git.Address{Repo: "git@github.com:a/b.git", Branch: git.MainBranch}, 1},
+		{git.Address{Repo: "file:///tmp/a/b", Branch: git.MainBranch}, 0},
+		{git.Address{Repo: "/tmp/a/b", Branch: git.MainBranch}, 0},
+	}
+	for _, c := range cases {
+		opts := shallowFetchOptions(c.addr)
+		if opts.Depth != c.depth {
+			t.Errorf("expecting depth %v for %v, got %v", c.depth, c.addr.Repo, opts.Depth)
+		}
+		want := config.RefSpec("refs/heads/main:refs/heads/main")
+		if len(opts.RefSpecs) != 1 || opts.RefSpecs[0] != want {
+			t.Errorf("expecting refspecs [%v] for %v, got %v", want, c.addr.Repo, opts.RefSpecs)
+		}
+	}
+}
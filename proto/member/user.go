@@ -57,10 +57,12 @@ func AddUserByPublicAddress_StageOnly(ctx context.Context, cloned gov.Cloned, na
 }
 
 func AddUser(ctx context.Context, addr gov.Address, name User, acct UserProfile) {
-	cloned := gov.Clone(ctx, addr)
-	chg := AddUser_StageOnly(ctx, cloned, name, acct)
-	proto.Commit(ctx, cloned.Tree(), chg)
-	cloned.Push(ctx)
+	proto.Retry(ctx, func() {
+		cloned := gov.Clone(ctx, addr)
+		chg := AddUser_StageOnly(ctx, cloned, name, acct)
+		proto.Commit(ctx, cloned.Tree(), chg)
+		proto.Push(ctx, cloned)
+	})
 }
 
 func AddUser_StageOnly(ctx context.Context, cloned gov.Cloned, name User, profile UserProfile) git.ChangeNoResult {
@@ -81,10 +83,12 @@ func AddUser_StageOnly(ctx context.Context, cloned gov.Cloned, name User, profil
 }
 
 func RemoveUser(ctx context.Context, addr gov.Address, name User) {
-	cloned := gov.Clone(ctx, addr)
-	chg := RemoveUser_StageOnly(ctx, cloned, name)
-	proto.Commit(ctx, cloned.Tree(), chg)
-	cloned.Push(ctx)
+	proto.Retry(ctx, func() {
+		cloned := gov.Clone(ctx, addr)
+		chg := RemoveUser_StageOnly(ctx, cloned, name)
+		proto.Commit(ctx, cloned.Tree(), chg)
+		proto.Push(ctx, cloned)
+	})
 }
 
 func RemoveUser_StageOnly(ctx context.Context, cloned gov.Cloned, name User) git.ChangeNoResult {
@@ -110,10 +114,12 @@ func RemoveUser_StageOnly(ctx context.Context, cloned gov.Cloned, name User) git
 // set prop
 
 func SetUserProp[V form.Form](ctx context.Context, addr gov.Address, user User, key string, value V) {
-	cloned := gov.Clone(ctx, addr)
-	chg := SetUserProp_StageOnly(ctx, cloned, user, key, value)
-	proto.Commit(ctx, cloned.Tree(), chg)
-	cloned.Push(ctx)
+	proto.Retry(ctx, func() {
+		cloned := gov.Clone(ctx, addr)
+		chg := SetUserProp_StageOnly(ctx, cloned, user, key, value)
+		proto.Commit(ctx, cloned.Tree(), chg)
+		proto.Push(ctx, cloned)
+	})
 }
 
 func SetUserProp_StageOnly[V form.Form](
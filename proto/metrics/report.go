@@ -45,7 +45,7 @@ func AssembleReport_Local(
 	allTimeSeries := ComputeSeries(entries, earliest, latest)
 
 	matchAccount := account.Get_Local(ctx, cloned, pmp_0.MatchingPoolAccountID)
-	matchFunds := matchAccount.Balance(account.PluralAsset).Quantity
+	matchFunds := matchAccount.Balance(account.PluralAsset).Quantity.Float64()
 
 	var w bytes.Buffer
 
@@ -86,7 +86,8 @@ func AssembleReport_Local(
 	fmt.Fprintf(&w, "|  ---:|  :--- |\n")
 	fmt.Fprintf(&w, "| Credits issued | %0.6f |\n", last30DaysSeries.DailyCreditsIssued.Total())
 	fmt.Fprintf(&w, "| Credits burned | %0.6f |\n", last30DaysSeries.DailyCreditsBurned.Total())
-	fmt.Fprintf(&w, "| Credits transferred | %0.6f |\n\n", last30DaysSeries.DailyCreditsTransferred.Total())
+	fmt.Fprintf(&w, "| Credits transferred | %0.6f |\n", last30DaysSeries.DailyCreditsTransferred.Total())
+	fmt.Fprintf(&w, "| Credits decayed | %0.6f |\n\n", last30DaysSeries.DailyCreditsDecayed.Total())
 
 	fmt.Printf("### Daily breakdown\n\n")
 
@@ -128,7 +129,8 @@ func AssembleReport_Local(
 	fmt.Fprintf(&w, "|  ---:|  :--- |\n")
 	fmt.Fprintf(&w, "| Credits issued | %0.6f |\n", allTimeSeries.DailyCreditsIssued.Total())
 	fmt.Fprintf(&w, "| Credits burned | %0.6f |\n", allTimeSeries.DailyCreditsBurned.Total())
-	fmt.Fprintf(&w, "| Credits transferred | %0.6f |\n\n", allTimeSeries.DailyCreditsTransferred.Total())
+	fmt.Fprintf(&w, "| Credits transferred | %0.6f |\n", allTimeSeries.DailyCreditsTransferred.Total())
+	fmt.Fprintf(&w, "| Credits decayed | %0.6f |\n\n", allTimeSeries.DailyCreditsDecayed.Total())
 
 	return &ReportAssets{
 		Series: &ReportSeries{
@@ -17,6 +17,7 @@ type Series struct {
 	DailyCreditsIssued      DailySeries
 	DailyCreditsBurned      DailySeries
 	DailyCreditsTransferred DailySeries
+	DailyCreditsDecayed     DailySeries
 	//
 	DailyClearedBounties DailySeries
 	DailyClearedRewards  DailySeries
@@ -45,6 +46,7 @@ func ComputeSeries(
 	dailyCreditIssued := DailyBuckets{}
 	dailyCreditBurned := DailyBuckets{}
 	dailyCreditTransferred := DailyBuckets{}
+	dailyCreditDecayed := DailyBuckets{}
 	dailyCreditInBounties := DailyBuckets{}
 	dailyCreditInRewards := DailyBuckets{}
 	dailyCreditInRefunds := DailyBuckets{}
@@ -66,6 +68,9 @@ func ComputeSeries(
 			if e.Payload.Account.Transfer != nil {
 				dailyCreditTransferred.Add(e.Stamp, e.Payload.Account.Transfer.Amount.Quantity)
 			}
+			if e.Payload.Account.Decay != nil {
+				dailyCreditDecayed.Add(e.Stamp, e.Payload.Account.Decay.Amount.Quantity)
+			}
 		}
 		if e.Payload.Join != nil {
 			dailyNumJoins.Add(e.Stamp, 1)
@@ -140,6 +145,7 @@ func ComputeSeries(
 		DailyCreditsIssued:       dailyCreditIssued.XY(earliest, latest),
 		DailyCreditsBurned:       dailyCreditBurned.XY(earliest, latest),
 		DailyCreditsTransferred:  dailyCreditTransferred.XY(earliest, latest),
+		DailyCreditsDecayed:      dailyCreditDecayed.XY(earliest, latest),
 		DailyClearedBounties:     dailyCreditInBounties.XY(earliest, latest),
 		DailyClearedRewards:      dailyCreditInRewards.XY(earliest, latest),
 		DailyClearedRefunds:      dailyCreditInRefunds.XY(earliest, latest),
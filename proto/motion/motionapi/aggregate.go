@@ -16,9 +16,11 @@ func AggregateMotions(
 
 ) {
 
-	cloned := gov.CloneOwner(ctx, addr)
-	AggregateMotions_StageOnly(ctx, cloned, args...)
-	proto.Commitf(ctx, cloned.PublicClone(), "motion_aggregate", "Aggregate motions")
+	proto.Retry(ctx, func() {
+		cloned := gov.CloneOwner(ctx, addr)
+		AggregateMotions_StageOnly(ctx, cloned, args...)
+		proto.Commitf(ctx, cloned.PublicClone(), "motion_aggregate", "Aggregate motions")
+	})
 }
 
 func AggregateMotions_StageOnly(
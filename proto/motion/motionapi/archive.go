@@ -15,9 +15,11 @@ func ArchiveMotions(
 
 ) {
 
-	cloned := gov.CloneOwner(ctx, addr)
-	ArchiveMotions_StageOnly(ctx, cloned, args...)
-	proto.Commitf(ctx, cloned.PublicClone(), "motion_archive", "Archive motions")
+	proto.Retry(ctx, func() {
+		cloned := gov.CloneOwner(ctx, addr)
+		ArchiveMotions_StageOnly(ctx, cloned, args...)
+		proto.Commitf(ctx, cloned.PublicClone(), "motion_archive", "Archive motions")
+	})
 }
 
 func ArchiveMotions_StageOnly(
@@ -20,10 +20,12 @@ func CancelMotion(
 
 ) (motionproto.Report, notice.Notices) {
 
-	cloned := gov.CloneOwner(ctx, addr)
-	report, notices := CancelMotion_StageOnly(ctx, cloned, id, args...)
-	proto.Commitf(ctx, cloned.Public, "motion_cancel", "Cancel motion %v", id)
-	return report, notices
+	return proto.Retry2(ctx, func() (motionproto.Report, notice.Notices) {
+		cloned := gov.CloneOwner(ctx, addr)
+		report, notices := CancelMotion_StageOnly(ctx, cloned, id, args...)
+		proto.Commitf(ctx, cloned.Public, "motion_cancel", "Cancel motion %v", id)
+		return report, notices
+	})
 }
 
 func CancelMotion_StageOnly(
@@ -16,10 +16,12 @@ func ClearMotions(
 
 ) ([]motionproto.Report, []notice.Notices) {
 
-	cloned := gov.CloneOwner(ctx, addr)
-	report, notices := ClearMotions_StageOnly(ctx, cloned, args...)
-	proto.Commitf(ctx, cloned.PublicClone(), "motion_clear", "Clear motions")
-	return report, notices
+	return proto.Retry2(ctx, func() ([]motionproto.Report, []notice.Notices) {
+		cloned := gov.CloneOwner(ctx, addr)
+		report, notices := ClearMotions_StageOnly(ctx, cloned, args...)
+		proto.Commitf(ctx, cloned.PublicClone(), "motion_clear", "Clear motions")
+		return report, notices
+	})
 }
 
 func ClearMotions_StageOnly(
@@ -21,10 +21,12 @@ func CloseMotion(
 
 ) (motionproto.Report, notice.Notices) {
 
-	cloned := gov.CloneOwner(ctx, addr)
-	report, notices := CloseMotion_StageOnly(ctx, cloned, id, decision, args...)
-	proto.Commitf(ctx, cloned.Public, "motion_close", "Close motion %v", id)
-	return report, notices
+	return proto.Retry2(ctx, func() (motionproto.Report, notice.Notices) {
+		cloned := gov.CloneOwner(ctx, addr)
+		report, notices := CloseMotion_StageOnly(ctx, cloned, id, decision, args...)
+		proto.Commitf(ctx, cloned.Public, "motion_close", "Close motion %v", id)
+		return report, notices
+	})
 }
 
 func CloseMotion_StageOnly(
@@ -53,6 +55,7 @@ func CloseMotion_StageOnly(
 
 	// commit closure
 	motion.Closed = true
+	motion.Decision = decision
 	motion.ClosedAt = time.Now()
 	motionproto.MotionKV.Set(ctx, motionproto.MotionNS, t, id, motion)
 
@@ -24,9 +24,11 @@ func EditMotion(
 
 ) git.ChangeNoResult {
 
-	cloned := gov.CloneOwner(ctx, addr)
-	chg := EditMotionMeta_StageOnly(ctx, cloned, id, author, title, body, trackerURL, labels)
-	return proto.CommitIfChanged(ctx, cloned.PublicClone(), chg)
+	return proto.Retry1(ctx, func() git.ChangeNoResult {
+		cloned := gov.CloneOwner(ctx, addr)
+		chg := EditMotionMeta_StageOnly(ctx, cloned, id, author, title, body, trackerURL, labels)
+		return proto.CommitIfChanged(ctx, cloned.PublicClone(), chg)
+	})
 }
 
 func EditMotionMeta_StageOnly(
@@ -19,10 +19,12 @@ func FreezeMotion(
 
 ) (motionproto.Report, notice.Notices) {
 
-	cloned := gov.CloneOwner(ctx, addr)
-	report, notices := FreezeMotion_StageOnly(ctx, cloned, id, args...)
-	proto.Commitf(ctx, cloned.PublicClone(), "motion_freeze", "Freeze motion %v", id)
-	return report, notices
+	return proto.Retry2(ctx, func() (motionproto.Report, notice.Notices) {
+		cloned := gov.CloneOwner(ctx, addr)
+		report, notices := FreezeMotion_StageOnly(ctx, cloned, id, args...)
+		proto.Commitf(ctx, cloned.PublicClone(), "motion_freeze", "Freeze motion %v", id)
+		return report, notices
+	})
 }
 
 func FreezeMotion_StageOnly(
@@ -71,10 +73,12 @@ func UnfreezeMotion(
 
 ) (motionproto.Report, notice.Notices) {
 
-	cloned := gov.CloneOwner(ctx, addr)
-	report, notices := UnfreezeMotion_StageOnly(ctx, cloned, id, args...)
-	proto.Commitf(ctx, cloned.PublicClone(), "motion_unfreeze", "Unfreeze motion %v", id)
-	return report, notices
+	return proto.Retry2(ctx, func() (motionproto.Report, notice.Notices) {
+		cloned := gov.CloneOwner(ctx, addr)
+		report, notices := UnfreezeMotion_StageOnly(ctx, cloned, id, args...)
+		proto.Commitf(ctx, cloned.PublicClone(), "motion_unfreeze", "Unfreeze motion %v", id)
+		return report, notices
+	})
 }
 
 func UnfreezeMotion_StageOnly(
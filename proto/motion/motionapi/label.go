@@ -0,0 +1,54 @@
+package motionapi
+
+import (
+	"context"
+	"slices"
+
+	"github.com/gov4git/gov4git/v2/proto"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/motion/motionproto"
+	"github.com/gov4git/lib4git/git"
+)
+
+func LabelMotion(
+	ctx context.Context,
+	addr gov.OwnerAddress,
+	id motionproto.MotionID,
+	add []string,
+	remove []string,
+
+) git.ChangeNoResult {
+
+	return proto.Retry1(ctx, func() git.ChangeNoResult {
+		cloned := gov.CloneOwner(ctx, addr)
+		chg := LabelMotion_StageOnly(ctx, cloned, id, add, remove)
+		return proto.CommitIfChanged(ctx, cloned.PublicClone(), chg)
+	})
+}
+
+// LabelMotion_StageOnly adds and removes labels from a motion, leaving the rest of its metadata intact.
+func LabelMotion_StageOnly(
+	ctx context.Context,
+	cloned gov.OwnerCloned,
+	id motionproto.MotionID,
+	add []string,
+	remove []string,
+
+) git.ChangeNoResult {
+
+	motion := motionproto.MotionKV.Get(ctx, motionproto.MotionNS, cloned.PublicClone().Tree(), id)
+
+	labels := []string{}
+	for _, l := range motion.Labels {
+		if !slices.Contains(remove, l) {
+			labels = append(labels, l)
+		}
+	}
+	for _, l := range add {
+		if !slices.Contains(labels, l) {
+			labels = append(labels, l)
+		}
+	}
+
+	return EditMotionMeta_StageOnly(ctx, cloned, id, motion.Author, motion.Title, motion.Body, motion.TrackerURL, labels)
+}
@@ -19,10 +19,12 @@ func LinkMotions(
 
 ) (fromReport motionproto.Report, fromNotices notice.Notices, toReport motionproto.Report, toNotices notice.Notices) {
 
-	cloned := gov.CloneOwner(ctx, addr)
-	ft, fn, tr, tn := LinkMotions_StageOnly(ctx, cloned, fromID, toID, typ, args...)
-	proto.Commitf(ctx, cloned.PublicClone(), "motion_link", "Link from motion %v to motion %v as %v", fromID, toID, typ)
-	return ft, fn, tr, tn
+	proto.Retry(ctx, func() {
+		cloned := gov.CloneOwner(ctx, addr)
+		fromReport, fromNotices, toReport, toNotices = LinkMotions_StageOnly(ctx, cloned, fromID, toID, typ, args...)
+		proto.Commitf(ctx, cloned.PublicClone(), "motion_link", "Link from motion %v to motion %v as %v", fromID, toID, typ)
+	})
+	return
 }
 
 func LinkMotions_StageOnly(
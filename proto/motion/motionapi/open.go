@@ -29,10 +29,12 @@ func OpenMotion(
 
 ) (motionproto.Report, notice.Notices) {
 
-	cloned := gov.CloneOwner(ctx, addr)
-	report, notices := OpenMotion_StageOnly(ctx, cloned, id, typ, policy, author, title, desc, trackerURL, labels)
-	proto.Commitf(ctx, cloned.PublicClone(), "motion_open", "Open motion %v", id)
-	return report, notices
+	return proto.Retry2(ctx, func() (motionproto.Report, notice.Notices) {
+		cloned := gov.CloneOwner(ctx, addr)
+		report, notices := OpenMotion_StageOnly(ctx, cloned, id, typ, policy, author, title, desc, trackerURL, labels)
+		proto.Commitf(ctx, cloned.PublicClone(), "motion_open", "Open motion %v", id)
+		return report, notices
+	})
 }
 
 func OpenMotion_StageOnly(
@@ -14,10 +14,12 @@ func Pipeline(
 
 ) {
 
-	cloned := gov.CloneOwner(ctx, addr)
-	Pipeline_StageOnly(ctx, cloned)
-	proto.Commitf(ctx, cloned.PublicClone(), "motion_pipeline", "motion pipeline")
-	cloned.PublicClone().Push(ctx)
+	proto.Retry(ctx, func() {
+		cloned := gov.CloneOwner(ctx, addr)
+		Pipeline_StageOnly(ctx, cloned)
+		proto.Commitf(ctx, cloned.PublicClone(), "motion_pipeline", "motion pipeline")
+		proto.Push(ctx, cloned.PublicClone())
+	})
 }
 
 func Pipeline_StageOnly(
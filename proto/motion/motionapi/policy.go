@@ -42,10 +42,12 @@ func SavePolicyState[PS form.Form](
 
 ) {
 
-	cloned := gov.Clone(ctx, addr)
-	SavePolicyState_StageOnly[PS](ctx, cloned, id, policyState)
-	proto.Commitf(ctx, cloned, "motion_save_policy_state", "update motion policy state")
-	cloned.Push(ctx)
+	proto.Retry(ctx, func() {
+		cloned := gov.Clone(ctx, addr)
+		SavePolicyState_StageOnly[PS](ctx, cloned, id, policyState)
+		proto.Commitf(ctx, cloned, "motion_save_policy_state", "update motion policy state")
+		proto.Push(ctx, cloned)
+	})
 }
 
 func SavePolicyState_StageOnly[PS form.Form](
@@ -94,10 +96,12 @@ func SaveClassState[PS form.Form](
 
 ) {
 
-	cloned := gov.CloneOwner(ctx, addr)
-	SaveClassState_StageOnly[PS](ctx, cloned, policyName, policyState)
-	proto.Commitf(ctx, cloned.PublicClone(), "motion_save_policy_class_state", "update motion policy class state")
-	cloned.PublicClone().Push(ctx)
+	proto.Retry(ctx, func() {
+		cloned := gov.CloneOwner(ctx, addr)
+		SaveClassState_StageOnly[PS](ctx, cloned, policyName, policyState)
+		proto.Commitf(ctx, cloned.PublicClone(), "motion_save_policy_class_state", "update motion policy class state")
+		proto.Push(ctx, cloned.PublicClone())
+	})
 }
 
 func SaveClassState_StageOnly[PS form.Form](
@@ -18,9 +18,11 @@ func ScoreMotions(
 
 ) git.Change[form.Map, motionproto.Motions] {
 
-	cloned := gov.CloneOwner(ctx, addr)
-	chg := ScoreMotions_StageOnly(ctx, cloned, args...)
-	return proto.CommitIfChanged(ctx, cloned.Public, chg)
+	return proto.Retry1(ctx, func() git.Change[form.Map, motionproto.Motions] {
+		cloned := gov.CloneOwner(ctx, addr)
+		chg := ScoreMotions_StageOnly(ctx, cloned, args...)
+		return proto.CommitIfChanged(ctx, cloned.Public, chg)
+	})
 }
 
 func ScoreMotions_StageOnly(
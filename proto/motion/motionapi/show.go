@@ -3,6 +3,7 @@ package motionapi
 import (
 	"context"
 
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotapi"
 	"github.com/gov4git/gov4git/v2/proto/gov"
 	"github.com/gov4git/gov4git/v2/proto/motion/motionproto"
 	"github.com/gov4git/lib4git/must"
@@ -33,6 +34,12 @@ func ShowMotion_Local(
 		func() motionproto.MotionView {
 			p := motionproto.GetPolicy(ctx, m.Policy)
 			pv, pb := p.Show(ctx, cloned, m, args...)
+			for i := range pb {
+				if !pb[i].BallotAd.Closed {
+					d := ballotapi.WouldPass_Local(ctx, cloned, pb[i].BallotID)
+					pb[i].BallotDecision = &d
+				}
+			}
 
 			return motionproto.MotionView{
 				Motion:  m,
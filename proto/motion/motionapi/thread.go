@@ -0,0 +1,119 @@
+package motionapi
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gov4git/gov4git/v2/proto"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/gov4git/v2/proto/motion/motionproto"
+	"github.com/gov4git/lib4git/git"
+	"github.com/gov4git/lib4git/must"
+)
+
+// Motion threads are markdown files in the governance repo, which collect comments and notices for each motion.
+// They take the place of issue comments for communities that do not use an external issue tracker.
+
+func CommentMotion(
+	ctx context.Context,
+	addr gov.OwnerAddress,
+	id motionproto.MotionID,
+	author member.User,
+	body string,
+
+) git.ChangeNoResult {
+
+	return proto.Retry1(ctx, func() git.ChangeNoResult {
+		cloned := gov.CloneOwner(ctx, addr)
+		chg := CommentMotion_StageOnly(ctx, cloned, id, author, body)
+		return proto.CommitIfChanged(ctx, cloned.PublicClone(), chg)
+	})
+}
+
+func CommentMotion_StageOnly(
+	ctx context.Context,
+	cloned gov.OwnerCloned,
+	id motionproto.MotionID,
+	author member.User, // optional
+	body string,
+
+) git.ChangeNoResult {
+
+	must.Assertf(ctx, author == "" || member.IsUser_Local(ctx, cloned.PublicClone(), author), "comment author %v is not in the community", author)
+	must.Assertf(ctx, body != "", "comment is empty")
+	_ = motionproto.MotionKV.Get(ctx, motionproto.MotionNS, cloned.PublicClone().Tree(), id) // verify motion exists
+
+	subject := "Comment"
+	if author != "" {
+		subject = fmt.Sprintf("Comment by @%v", author)
+	}
+	appendMotionThread_StageOnly(ctx, cloned.PublicClone(), id, subject, body)
+	return git.NewChangeNoResult(fmt.Sprintf("Comment on motion %v", id), "motion_comment")
+}
+
+func LoadMotionThread(
+	ctx context.Context,
+	addr gov.Address,
+	id motionproto.MotionID,
+) string {
+
+	return LoadMotionThread_Local(ctx, gov.Clone(ctx, addr), id)
+}
+
+func LoadMotionThread_Local(
+	ctx context.Context,
+	cloned gov.Cloned,
+	id motionproto.MotionID,
+) string {
+
+	if _, err := git.TreeStat(ctx, cloned.Tree(), motionproto.MotionThreadNS(id)); git.IsNotExist(err) {
+		return ""
+	}
+	return git.FileToString(ctx, cloned.Tree(), motionproto.MotionThreadNS(id))
+}
+
+func DisplayNoticesInThreads(
+	ctx context.Context,
+	addr gov.OwnerAddress,
+) git.ChangeNoResult {
+
+	return proto.Retry1(ctx, func() git.ChangeNoResult {
+		cloned := gov.CloneOwner(ctx, addr)
+		DisplayNoticesInThreads_StageOnly(ctx, cloned.PublicClone())
+		return proto.Commitf(ctx, cloned.PublicClone(), "motion_display_notices", "Display motion notices in motion threads")
+	})
+}
+
+// DisplayNoticesInThreads_StageOnly is a notice sink, which appends the notices of every motion that have not been shown yet to the motion's thread.
+func DisplayNoticesInThreads_StageOnly(
+	ctx context.Context,
+	cloned gov.Cloned,
+) {
+
+	for _, motion := range ListMotions_Local(ctx, cloned.Tree()) {
+		queue := LoadMotionNotices_Local(ctx, cloned, motion.ID)
+		for _, nstate := range queue.NoticeStates {
+			if nstate.IsShown() {
+				continue
+			}
+			appendMotionThread_StageOnly(ctx, cloned, motion.ID, fmt.Sprintf("Notice `%v`", nstate.ID), nstate.Notice.Body)
+			nstate.MarkShown()
+		}
+		SaveMotionNotices_StageOnly(ctx, cloned, motion.ID, queue)
+	}
+}
+
+func appendMotionThread_StageOnly(
+	ctx context.Context,
+	cloned gov.Cloned,
+	id motionproto.MotionID,
+	subject string,
+	body string,
+) {
+
+	thread := LoadMotionThread_Local(ctx, cloned, id)
+	thread += fmt.Sprintf("#### %s\nOn `%s`\n\n%s\n\n", subject, time.Now().Format(time.RFC850), body)
+	git.StringToFileStage(ctx, cloned.Tree(), motionproto.MotionThreadNS(id), thread)
+}
@@ -19,10 +19,12 @@ func UnlinkMotions(
 
 ) (fromReport motionproto.Report, fromNotices notice.Notices, toReport motionproto.Report, toNotices notice.Notices) {
 
-	cloned := gov.CloneOwner(ctx, addr)
-	ft, fn, tr, tn := UnlinkMotions_StageOnly(ctx, cloned, fromID, toID, typ, args...)
-	proto.Commitf(ctx, cloned.PublicClone(), "motion_unlink", "Unlink from motion %v to motion %v as %v", fromID, toID, typ)
-	return ft, fn, tr, tn
+	proto.Retry(ctx, func() {
+		cloned := gov.CloneOwner(ctx, addr)
+		fromReport, fromNotices, toReport, toNotices = UnlinkMotions_StageOnly(ctx, cloned, fromID, toID, typ, args...)
+		proto.Commitf(ctx, cloned.PublicClone(), "motion_unlink", "Unlink from motion %v to motion %v as %v", fromID, toID, typ)
+	})
+	return
 }
 
 func UnlinkMotions_StageOnly(
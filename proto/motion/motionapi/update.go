@@ -16,10 +16,12 @@ func UpdateMotions(
 
 ) ([]motionproto.Report, []notice.Notices) {
 
-	cloned := gov.CloneOwner(ctx, addr)
-	report, notices := UpdateMotions_StageOnly(ctx, cloned, args...)
-	proto.Commitf(ctx, cloned.PublicClone(), "motion_update", "Update motions")
-	return report, notices
+	return proto.Retry2(ctx, func() ([]motionproto.Report, []notice.Notices) {
+		cloned := gov.CloneOwner(ctx, addr)
+		report, notices := UpdateMotions_StageOnly(ctx, cloned, args...)
+		proto.Commitf(ctx, cloned.PublicClone(), "motion_update", "Update motions")
+		return report, notices
+	})
 }
 
 func UpdateMotions_StageOnly(
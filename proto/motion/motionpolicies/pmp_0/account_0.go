@@ -65,5 +65,5 @@ func Boot_StageOnly(ctx context.Context, cloned gov.Cloned) {
 
 func GetMatchFundBalance_Local(ctx context.Context, cloned gov.Cloned) float64 {
 	a := account.Get_Local(ctx, cloned, MatchingPoolAccountID)
-	return a.Balance(account.PluralAsset).Quantity
+	return a.Balance(account.PluralAsset).Quantity.Float64()
 }
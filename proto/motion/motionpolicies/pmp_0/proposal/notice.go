@@ -32,7 +32,7 @@ func cancelNotice(
 	// refunded
 	fmt.Fprintf(&w, "Refunds issued:\n")
 	for _, refund := range ballotproto.FlattenRefunds(outcome.Refunded) {
-		fmt.Fprintf(&w, "- User @%v was refunded `%0.6f` credits\n", refund.User, refund.Amount.Quantity)
+		fmt.Fprintf(&w, "- User @%v was refunded `%0.6f` credits\n", refund.User, refund.Amount.Quantity.Float64())
 	}
 	fmt.Fprintln(&w, "")
 
@@ -69,9 +69,9 @@ func closeNotice(
 
 	// bounty
 	if bountyDonated {
-		fmt.Fprintf(&w, "Bounty of `%0.6f` credits was donated to the community's matching fund.\n\n", bounty.Quantity)
+		fmt.Fprintf(&w, "Bounty of `%0.6f` credits was donated to the community's matching fund.\n\n", bounty.Quantity.Float64())
 	} else {
-		fmt.Fprintf(&w, "Bounty of `%0.6f` credits was awarded to @%v.\n\n", bounty.Quantity, prop.Author)
+		fmt.Fprintf(&w, "Bounty of `%0.6f` credits was awarded to @%v.\n\n", bounty.Quantity.Float64(), prop.Author)
 	}
 
 	// resolved issues
@@ -84,7 +84,7 @@ func closeNotice(
 	// rewarded reviewers
 	fmt.Fprintf(&w, "Rewarded PR reviewers:\n")
 	for _, reward := range rewards {
-		fmt.Fprintf(&w, "- Reviewer @%v was awarded `%0.6f` credits\n", reward.To, reward.Amount.Quantity)
+		fmt.Fprintf(&w, "- Reviewer @%v was awarded `%0.6f` credits\n", reward.To, reward.Amount.Quantity.Float64())
 	}
 	fmt.Fprintln(&w, "")
 
@@ -68,7 +68,7 @@ func (x concernPolicy) Open(
 	// open a poll for the motion
 	ballotapi.Open_StageOnly(
 		ctx,
-		ConcernPriorityPollPolicyName,
+		priorityPollPolicy(pmp_1.LoadConcernClassState_Local(ctx, cloned)),
 		cloned,
 		state.PriorityPoll,
 		pmp_1.ConcernAccountID(con.ID),
@@ -22,7 +22,7 @@ func cancelNotice(ctx context.Context, motion motionproto.Motion, outcome ballot
 	// refunded
 	fmt.Fprintf(&w, "Refunds issued:\n")
 	for _, refund := range ballotproto.FlattenRefunds(outcome.Refunded) {
-		fmt.Fprintf(&w, "- User @%v was refunded `%0.6f` credits\n", refund.User, refund.Amount.Quantity)
+		fmt.Fprintf(&w, "- User @%v was refunded `%0.6f` credits\n", refund.User, refund.Amount.Quantity.Float64())
 	}
 	fmt.Fprintln(&w, "")
 
@@ -2,11 +2,16 @@ package concern
 
 import (
 	"context"
+	"slices"
+	"time"
 
+	"github.com/gov4git/gov4git/v2/proto"
 	"github.com/gov4git/gov4git/v2/proto/ballot/ballotio"
 	"github.com/gov4git/gov4git/v2/proto/ballot/ballotpolicies/sv"
 	"github.com/gov4git/gov4git/v2/proto/ballot/ballotproto"
 	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/motion/motionpolicies/pmp_1"
+	"github.com/gov4git/lib4git/must"
 )
 
 func init() {
@@ -18,9 +23,66 @@ func init() {
 			Kernel: ScoreKernel{},
 		},
 	)
+	ballotio.Install(
+		ctx,
+		ConcernConvictionPriorityPollPolicyName,
+		sv.SV{
+			Kernel: sv.MakeConvictionScoreKernel(ctx, ConcernConvictionHalfLife, ScoreKernel{}),
+		},
+	)
+}
+
+const (
+	ConcernPriorityPollPolicyName ballotproto.PolicyName = "pmp-concern-priority-v1"
+
+	// ConcernConvictionPriorityPollPolicyName is a priority poll, where the QV impact of committed credits accrues over time.
+	// It can be enabled for new concerns using SetPriorityPollPolicy.
+	ConcernConvictionPriorityPollPolicyName ballotproto.PolicyName = "pmp-concern-priority-conviction-v1"
+	ConcernConvictionHalfLife                                      = 7 * 24 * time.Hour
+)
+
+func priorityPollPolicy(policyState *pmp_1.ConcernPolicyState) ballotproto.PolicyName {
+	if policyState.PriorityPollPolicy != "" {
+		return policyState.PriorityPollPolicy
+	}
+	return ConcernPriorityPollPolicyName
 }
 
-const ConcernPriorityPollPolicyName ballotproto.PolicyName = "pmp-concern-priority-v1"
+// PriorityPollPolicies are the ballot policies that can be used by the priority polls of concerns.
+var PriorityPollPolicies = []ballotproto.PolicyName{
+	ConcernPriorityPollPolicyName,
+	ConcernConvictionPriorityPollPolicyName,
+}
+
+// SetPriorityPollPolicy sets the ballot policy of the priority polls of concerns opened from now on.
+// Priority polls of existing concerns are not affected.
+func SetPriorityPollPolicy(
+	ctx context.Context,
+	addr gov.OwnerAddress,
+	policy ballotproto.PolicyName,
+
+) {
+
+	proto.Retry(ctx, func() {
+		cloned := gov.CloneOwner(ctx, addr)
+		SetPriorityPollPolicy_StageOnly(ctx, cloned, policy)
+		proto.Commitf(ctx, cloned.PublicClone(), "pmp_set_priority_poll_policy", "Set priority poll policy to %v", policy)
+		proto.Push(ctx, cloned.PublicClone())
+	})
+}
+
+func SetPriorityPollPolicy_StageOnly(
+	ctx context.Context,
+	cloned gov.OwnerCloned,
+	policy ballotproto.PolicyName,
+
+) {
+
+	must.Assertf(ctx, slices.Contains(PriorityPollPolicies, policy), "unknown priority poll policy %v", policy)
+	policyState := pmp_1.LoadConcernClassState_Local(ctx, cloned)
+	policyState.PriorityPollPolicy = policy
+	pmp_1.SaveConcernClassState_StageOnly(ctx, cloned, policyState)
+}
 
 type ScoreKernel struct{}
 
@@ -39,6 +39,9 @@ func NewConcernState(id motionproto.MotionID) *ConcernState {
 type ConcernPolicyState struct {
 	WithheldEscrowFraction float64 `json:"withheld_escrow_fraction"`
 	MatchDeficit           float64 `json:"match_deficit"`
+	// PriorityPollPolicy is the ballot policy of the priority polls of newly opened concerns.
+	// If empty, the QV priority poll policy is used. It is set by concern.SetPriorityPollPolicy.
+	PriorityPollPolicy ballotproto.PolicyName `json:"priority_poll_policy,omitempty"`
 }
 
 var InitialPolicyState = &ConcernPolicyState{
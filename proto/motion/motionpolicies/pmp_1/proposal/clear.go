@@ -163,8 +163,8 @@ func calcReviewersRewards(
 	rewardAccount := account.Get_Local(ctx, cloned.PublicClone(), pmp_1.ProposalRewardAccountID(prop.ID))
 	remainder := rewardAccount.Balance(account.PluralAsset).Quantity
 	donation := account.H(account.PluralAsset, 0.0)
-	if remainder > 0 {
-		donation = account.H(
+	if remainder.Sign() > 0 {
+		donation = account.HQ(
 			account.PluralAsset,
 			remainder,
 		)
@@ -187,7 +187,7 @@ func calcReviewersRewards(
 	}
 
 	rewards.Sort()
-	return rewards, receipts, donation.Quantity
+	return rewards, receipts, donation.Quantity.Float64()
 }
 
 func calcRealBounty(
@@ -80,7 +80,7 @@ func (x proposalPolicy) Close(
 				priorityFunds,
 				fmt.Sprintf("bounty for proposal %v was donated", prop.ID),
 			)
-			bountyDonation = priorityFunds.Quantity
+			bountyDonation = priorityFunds.Quantity.Float64()
 			receipts = append(receipts,
 				metric.Receipt{
 					To:     pmp_0.MatchingPoolAccountID.MetricAccountID(),
@@ -94,9 +94,9 @@ func (x proposalPolicy) Close(
 			authorAccount := member.UserAccountID(prop.Author)
 
 			matchAccount := account.Get_Local(ctx, cloned.PublicClone(), pmp_0.MatchingPoolAccountID)
-			matchFunds := matchAccount.Balance(account.PluralAsset).Quantity
+			matchFunds := matchAccount.Balance(account.PluralAsset).Quantity.Float64()
 
-			awardFromCon, awardFromMatch, donateFromCon := calcRealBounty(priorityFunds.Quantity, matchFunds, projectedBounty)
+			awardFromCon, awardFromMatch, donateFromCon := calcRealBounty(priorityFunds.Quantity.Float64(), matchFunds, projectedBounty)
 			realizedBounty = awardFromCon + awardFromMatch
 			bountyDonation = donateFromCon
 
@@ -183,7 +183,7 @@ func (x proposalPolicy) Close(
 			CostOfReview:        costOfReview,
 			Rewarded:            rewards,
 			RewardDonation:      rewardDonation,
-			CostOfPriority:      priorityFunds.Quantity,
+			CostOfPriority:      priorityFunds.Quantity.Float64(),
 			ProjectedBounty:     projectedBounty,
 			RealizedBounty:      realizedBounty,
 			BountyDonation:      bountyDonation,
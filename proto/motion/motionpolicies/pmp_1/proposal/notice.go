@@ -28,7 +28,7 @@ func cancelNotice(
 
 	fmt.Fprintf(&w, "Refunds issued:\n")
 	for _, refund := range ballotproto.FlattenRefunds(outcome.Refunded) {
-		fmt.Fprintf(&w, "- Reviewer @%v was refunded `%0.6f` credits\n", refund.User, refund.Amount.Quantity)
+		fmt.Fprintf(&w, "- Reviewer @%v was refunded `%0.6f` credits\n", refund.User, refund.Amount.Quantity.Float64())
 	}
 	fmt.Fprintln(&w, "")
 
@@ -102,7 +102,7 @@ func closeNotice(
 	if len(r.Rewarded) > 0 {
 		fmt.Fprintf(&w, "PR __reviewers__ were rewarded:\n")
 		for _, reward := range r.Rewarded {
-			fmt.Fprintf(&w, "- Reviewer @%v was rewarded `%0.6f` credits\n", reward.To, reward.Amount.Quantity)
+			fmt.Fprintf(&w, "- Reviewer @%v was rewarded `%0.6f` credits\n", reward.To, reward.Amount.Quantity.Float64())
 		}
 		fmt.Fprintln(&w, "")
 	}
@@ -31,7 +31,7 @@ func cancelNotice(
 	if len(refunds) > 0 {
 		fmt.Fprintf(&w, "Refunds issued:\n")
 		for _, refund := range refunds {
-			fmt.Fprintf(&w, "- User @%v was refunded `%0.6f` credits\n", refund.User, refund.Amount.Quantity)
+			fmt.Fprintf(&w, "- User @%v was refunded `%0.6f` credits\n", refund.User, refund.Amount.Quantity.Float64())
 		}
 		fmt.Fprintln(&w, "")
 	}
@@ -71,7 +71,7 @@ func closeNotice(
 	if len(refunds) > 0 {
 		fmt.Fprintf(&w, "Refunds issued:\n")
 		for _, refund := range refunds {
-			fmt.Fprintf(&w, "- Prioritizer @%v was refunded `%0.6f` credits\n", refund.User, refund.Amount.Quantity)
+			fmt.Fprintf(&w, "- Prioritizer @%v was refunded `%0.6f` credits\n", refund.User, refund.Amount.Quantity.Float64())
 		}
 		fmt.Fprintln(&w, "")
 	}
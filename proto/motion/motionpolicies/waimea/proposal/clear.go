@@ -75,7 +75,7 @@ func closeResolvedConcerns(
 		ctx,
 		cloned.PublicClone(),
 		waimea.ProposalBountyAccountID(prop.ID),
-	).Assets.Balance(account.PluralAsset).Quantity
+	).Assets.Balance(account.PluralAsset).Quantity.Float64()
 }
 
 func loadApprovalPoll(
@@ -154,8 +154,8 @@ func disberseReviewersRewards(
 	rewardAccount := account.Get_Local(ctx, cloned.PublicClone(), waimea.ProposalRewardAccountID(prop.ID))
 	remainder := rewardAccount.Balance(account.PluralAsset).Quantity
 	donation := account.H(account.PluralAsset, 0.0)
-	if remainder > 0 {
-		donation = account.H(
+	if remainder.Sign() > 0 {
+		donation = account.HQ(
 			account.PluralAsset,
 			remainder,
 		)
@@ -178,5 +178,5 @@ func disberseReviewersRewards(
 	}
 
 	rewards.Sort()
-	return rewards, receipts, donation.Quantity
+	return rewards, receipts, donation.Quantity.Float64()
 }
@@ -32,7 +32,7 @@ func cancelNotice(
 	if len(refunds) > 0 {
 		fmt.Fprintf(&w, "Refunds were issued:\n")
 		for _, refund := range refunds {
-			fmt.Fprintf(&w, "- Reviewer @%v was refunded `%0.6f` credits\n", refund.User, refund.Amount.Quantity)
+			fmt.Fprintf(&w, "- Reviewer @%v was refunded `%0.6f` credits\n", refund.User, refund.Amount.Quantity.Float64())
 		}
 		fmt.Fprintln(&w, "")
 	}
@@ -106,7 +106,7 @@ func closeNotice(
 	if len(r.Rewarded) > 0 {
 		fmt.Fprintf(&w, "PR __reviewers__ were rewarded as follows:\n")
 		for _, reward := range r.Rewarded {
-			fmt.Fprintf(&w, "- Reviewer @%v was rewarded `%0.6f` credits\n", reward.To, reward.Amount.Quantity)
+			fmt.Fprintf(&w, "- Reviewer @%v was rewarded `%0.6f` credits\n", reward.To, reward.Amount.Quantity.Float64())
 		}
 		fmt.Fprintln(&w, "")
 	}
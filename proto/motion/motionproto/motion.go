@@ -43,9 +43,10 @@ type Motion struct {
 	Body       string   `json:"description"`
 	Labels     []string `json:"labels"`
 	// state, mutable
-	Frozen    bool `json:"frozen"`
-	Closed    bool `json:"closed"`
-	Cancelled bool `json:"cancelled"`
+	Frozen    bool     `json:"frozen"`
+	Closed    bool     `json:"closed"`
+	Cancelled bool     `json:"cancelled"`
+	Decision  Decision `json:"decision,omitempty"` // set when the motion is closed
 	//
 	Archived bool `json:"archived"`
 	// attention ranking, mutable
@@ -17,6 +17,11 @@ func MotionNoticesNS(id MotionID) ns.NS {
 	return MotionKV.KeyNS(MotionNS, id).Append("notices.json")
 }
 
+// MotionThreadNS is the markdown thread of comments and notices, kept for each motion in the governance repo.
+func MotionThreadNS(id MotionID) ns.NS {
+	return MotionKV.KeyNS(MotionNS, id).Append("thread.md")
+}
+
 func MotionAccountID(motionID MotionID) account.AccountID {
 	return account.AccountIDFromLine(account.Pair("motion", motionID.String()))
 }
@@ -155,12 +155,13 @@ type Policy interface {
 }
 
 type MotionBallot struct {
-	Label         string               `json:"ballot_label"`
-	BallotID      ballotproto.BallotID `json:"ballot_id"`
-	BallotChoices []string             `json:"ballot_choices"`
-	BallotAd      ballotproto.Ad       `json:"ballot_ad"`
-	BallotTally   ballotproto.Tally    `json:"ballot_tally"`
-	BallotMargin  *ballotproto.Margin  `json:"ballot_margin,omitempty"`
+	Label          string                `json:"ballot_label"`
+	BallotID       ballotproto.BallotID  `json:"ballot_id"`
+	BallotChoices  []string              `json:"ballot_choices"`
+	BallotAd       ballotproto.Ad        `json:"ballot_ad"`
+	BallotTally    ballotproto.Tally     `json:"ballot_tally"`
+	BallotMargin   *ballotproto.Margin   `json:"ballot_margin,omitempty"`
+	BallotDecision *ballotproto.Decision `json:"ballot_decision,omitempty"` // whether an open ballot would pass, if closed now
 }
 
 type MotionBallots []MotionBallot
@@ -18,9 +18,11 @@ func SaveNoticeQueue(
 	queue *NoticeQueue,
 ) git.Change[*NoticeQueue, form.None] {
 
-	cloned := gov.Clone(ctx, addr)
-	chg := SaveNoticeQueue_StageOnly(ctx, cloned, filepath, queue)
-	return proto.CommitIfChanged(ctx, cloned, chg)
+	return proto.Retry1(ctx, func() git.Change[*NoticeQueue, form.None] {
+		cloned := gov.Clone(ctx, addr)
+		chg := SaveNoticeQueue_StageOnly(ctx, cloned, filepath, queue)
+		return proto.CommitIfChanged(ctx, cloned, chg)
+	})
 }
 
 func SaveNoticeQueue_StageOnly(
@@ -43,7 +43,7 @@ func Panorama_Local(
 	// voterProfile := member.GetUser_Local(ctx, cloned, voterUser)
 	voterAccountID := member.UserAccountID(voterUser)
 
-	realBalance := account.Get_Local(ctx, cloned, account.AccountID(voterAccountID)).Balance(account.PluralAsset).Quantity
+	realBalance := account.Get_Local(ctx, cloned, account.AccountID(voterAccountID)).Balance(account.PluralAsset).Quantity.Float64()
 
 	realMVS := motionapi.TrackMotionBatch_Local(ctx, cloned, voterAddr, voterOwner)
 
@@ -95,7 +95,7 @@ func Panorama_Local(
 		projMVS[i].Voter = nil
 	}
 
-	projBalance := account.Get_Local(ctx, cloned, account.AccountID(voterAccountID)).Balance(account.PluralAsset).Quantity
+	projBalance := account.Get_Local(ctx, cloned, account.AccountID(voterAccountID)).Balance(account.PluralAsset).Quantity.Float64()
 
 	return &Panoramic{
 		RealBalance:      realBalance,
@@ -30,7 +30,7 @@ func CommitIfChanged[C git.Commitable](ctx context.Context, cloned git.Cloned, c
 	must.NoError(ctx, err)
 	if !status.IsClean() {
 		Commit(ctx, cloned.Tree(), commitable)
-		cloned.Push(ctx)
+		Push(ctx, cloned)
 	}
 	return commitable
 }
@@ -0,0 +1,90 @@
+package proto
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math/rand"
+	"time"
+
+	"github.com/gov4git/lib4git/base"
+	"github.com/gov4git/lib4git/git"
+	"github.com/gov4git/lib4git/must"
+)
+
+// A push is rejected as a non-fast-forward update, when the remote branch has moved since the clone,
+// e.g. when cron and a maintainer change the community repo at the same time.
+// Operations push through Push, which reports such rejections as ErrPushConflict,
+// and recover from them optimistically, by re-cloning and replaying their changes.
+var (
+	MaxPushAttempts  = 5
+	PushRetryBackoff = 250 * time.Millisecond // delay before the first retry; it doubles with every retry
+)
+
+// ErrPushConflict is the cause of pushes rejected due to a concurrent update of the remote.
+var ErrPushConflict = errors.New("push conflict")
+
+// Push pushes the changes committed in cloned to its remote.
+// If the push is rejected due to a concurrent update of the remote, Push panics with an error wrapping ErrPushConflict.
+func Push(ctx context.Context, cloned git.Cloned) {
+	err := must.Try(func() { cloned.Push(ctx) })
+	switch {
+	case err == nil:
+		return
+	case git.IsNonFastForwardUpdate(err):
+		must.Panic(ctx, fmt.Errorf("%w: %w", ErrPushConflict, err))
+	case errors.Is(err, fs.ErrNotExist):
+		// A push, rejected by the remote, leaves the freshness stamp of the shared cache replica removed.
+		// The next push through the replica then fails to remove the stamp, before anything is pushed.
+		must.Panic(ctx, fmt.Errorf("%w: %w", ErrPushConflict, err))
+	default:
+		must.Panic(ctx, err)
+	}
+}
+
+// IsPushConflict reports whether err is a rejection of a push due to a concurrent update of the remote.
+func IsPushConflict(err error) bool {
+	return errors.Is(err, ErrPushConflict)
+}
+
+// Retry runs f, and re-runs it if it fails due to a push conflict, up to MaxPushAttempts times with exponential backoff.
+// f must clone the repos it changes, so that every attempt stages its changes on top of the latest remote state.
+// Side effects outside of the cloned repos are not undone between attempts; writes to an issue tracker should be deferred with github.RetryWithTracker.
+func Retry(ctx context.Context, f func()) {
+	Retry1(ctx, func() struct{} { f(); return struct{}{} })
+}
+
+func Retry1[R any](ctx context.Context, f func() R) R {
+	backoff := PushRetryBackoff
+	for attempt := 1; ; attempt++ {
+		r, err := must.Try1Thru(f)
+		if err == nil {
+			return r
+		}
+		if attempt >= MaxPushAttempts || !IsPushConflict(err.Wrapped()) {
+			panic(err)
+		}
+		// jitter separates writers who collided in lockstep
+		delay := backoff + time.Duration(rand.Int63n(int64(backoff)+1))
+		base.Infof("push rejected on attempt %d of %d (%v); retrying in %v", attempt, MaxPushAttempts, err, delay)
+		select {
+		case <-ctx.Done():
+			must.NoError(ctx, ctx.Err())
+		case <-time.After(delay):
+		}
+		backoff *= 2
+	}
+}
+
+func Retry2[R1 any, R2 any](ctx context.Context, f func() (R1, R2)) (R1, R2) {
+	type pair struct {
+		r1 R1
+		r2 R2
+	}
+	p := Retry1(ctx, func() pair {
+		r1, r2 := f()
+		return pair{r1, r2}
+	})
+	return p.r1, p.r2
+}
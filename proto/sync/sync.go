@@ -2,35 +2,67 @@ package sync
 
 import (
 	"context"
+	"time"
 
 	"github.com/gov4git/gov4git/v2/proto/ballot/ballotapi"
 	"github.com/gov4git/gov4git/v2/proto/bureau"
 	"github.com/gov4git/gov4git/v2/proto/gov"
 	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/lib4git/base"
 	"github.com/gov4git/lib4git/form"
 	"github.com/gov4git/lib4git/git"
 )
 
+// Timing reports the duration of the steps of a sync in seconds.
+type Timing struct {
+	Tally    float64 `json:"tally_seconds"`
+	Schedule float64 `json:"schedule_seconds"`
+	Bureau   float64 `json:"bureau_seconds"`
+	Total    float64 `json:"total_seconds"`
+}
+
 func Sync(
 	ctx context.Context,
 	govAddr gov.OwnerAddress,
 	maxPar int,
 ) git.Change[form.Map, form.Map] {
 
+	// the public repos of members are fetched once and shared by all ballots and the bureau
+	repos := member.NewPublicRepos()
+	ctx = member.WithPublicRepos(ctx, repos)
+	var timing Timing
+	start := time.Now()
+
+	// freeze and close ballots whose deadlines have passed, after counting the votes cast before the deadlines
+	scheduleChg := ballotapi.Schedule(ctx, govAddr, time.Now(), maxPar)
+	timing.Schedule = time.Since(start).Seconds()
+
 	// collect votes and tally all open ballots
+	tallyStart := time.Now()
 	tallyChg := ballotapi.TallyAll(ctx, govAddr, maxPar)
+	timing.Tally = time.Since(tallyStart).Seconds()
 
 	// process bureau requests by users
+	bureauStart := time.Now()
 	bureauChg := bureau.Process(ctx, govAddr, member.Everybody)
+	timing.Bureau = time.Since(bureauStart).Seconds()
+
+	timing.Total = time.Since(start).Seconds()
+	stats := repos.Stats()
+	base.Infof("sync took %.2fs (schedule %.2fs, tally %.2fs, bureau %.2fs); fetched %d public repos in %.2fs, %d unreachable, %d reused",
+		timing.Total, timing.Schedule, timing.Tally, timing.Bureau, stats.Fetched, stats.FetchSeconds, stats.Unreachable, stats.Reused)
 
 	return git.NewChange(
 		"Governance-community sync",
 		"sync_sync",
 		form.Map{},
 		form.Map{
-			"tally_result":  tallyChg.Result,
-			"bureau_result": bureauChg.Result,
+			"tally_result":    tallyChg.Result,
+			"schedule_result": scheduleChg.Result,
+			"bureau_result":   bureauChg.Result,
+			"public_repos":    stats,
+			"timing":          timing,
 		},
-		form.Forms{tallyChg, bureauChg},
+		form.Forms{scheduleChg, tallyChg, bureauChg},
 	)
 }
@@ -0,0 +1,143 @@
+package account
+
+import (
+	"testing"
+
+	"github.com/gov4git/gov4git/v2/proto/account"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotapi"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotio"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotpolicies/sv"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotproto"
+	"github.com/gov4git/gov4git/v2/proto/bureau"
+	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/gov4git/v2/proto/purpose"
+	"github.com/gov4git/gov4git/v2/runtime"
+	"github.com/gov4git/gov4git/v2/test"
+	"github.com/gov4git/lib4git/form"
+	"github.com/gov4git/lib4git/must"
+	"github.com/gov4git/lib4git/testutil"
+)
+
+const (
+	testReputationAsset account.Asset = "reputation"
+	testBadgeAsset      account.Asset = "badge"
+
+	testReputationPolicyName ballotproto.PolicyName = "test-reputation-qv"
+)
+
+func TestAssets(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 2)
+
+	// define a non-transferable reputation asset and an indivisible badge asset
+	account.DefineAsset(ctx, cty.Gov(), account.AssetInfo{Asset: testReputationAsset, Transferable: false, Divisible: true})
+	account.DefineAsset(ctx, cty.Gov(), account.AssetInfo{Asset: testBadgeAsset, Transferable: true, Divisible: false})
+	if n := len(account.ListAssets(ctx, cty.Gov())); n != 3 {
+		t.Fatalf("expecting 3 assets, got %v", n)
+	}
+	if must.Try(func() {
+		account.DefineAsset(ctx, cty.Gov(), account.AssetInfo{Asset: testBadgeAsset})
+	}) == nil {
+		t.Fatalf("redefining an asset should fail")
+	}
+
+	// issue
+	account.Issue(ctx, cty.Gov(), cty.MemberAccountID(0), account.H(testReputationAsset, 10.0), "test")
+	account.Issue(ctx, cty.Gov(), cty.MemberAccountID(0), account.H(testBadgeAsset, 2.0), "test")
+	if must.Try(func() {
+		account.Issue(ctx, cty.Gov(), cty.MemberAccountID(0), account.H(testBadgeAsset, 0.5), "test")
+	}) == nil {
+		t.Fatalf("issuing a fraction of an indivisible asset should fail")
+	}
+
+	// member transfers of the reputation asset are refused, while badges can be transferred
+	bureau.Transfer(ctx, cty.MemberOwner(0), cty.Gov(), member.User(""), cty.MemberUser(1), testReputationAsset, 1.0)
+	bureau.Transfer(ctx, cty.MemberOwner(0), cty.Gov(), member.User(""), cty.MemberUser(1), testBadgeAsset, 1.0)
+	bureau.Process(ctx, cty.Organizer(), member.Everybody)
+	if q := account.Get(ctx, cty.Gov(), cty.MemberAccountID(1)).Balance(testReputationAsset).Quantity.Float64(); q != 0.0 {
+		t.Errorf("expecting no reputation transferred, got %v", q)
+	}
+	if q := account.Get(ctx, cty.Gov(), cty.MemberAccountID(1)).Balance(testBadgeAsset).Quantity.Float64(); q != 1.0 {
+		t.Errorf("expecting one badge transferred, got %v", q)
+	}
+
+	// ballots can charge voters in the reputation asset
+	ballotio.Install(ctx, testReputationPolicyName, sv.SV{Kernel: sv.MakeQVScoreKernel(ctx, 1.0), Asset: testReputationAsset})
+	ballotName := ballotproto.ParseBallotID("asset/a")
+	ballotapi.Open(ctx, testReputationPolicyName, cty.Organizer(), ballotName, account.NobodyAccountID, purpose.Unspecified, "", "reputation", "reputation ballot", []string{"x"}, member.Everybody)
+	ballotapi.Vote(ctx, cty.MemberOwner(0), cty.Gov(), ballotName, ballotproto.OneElection("x", 4.0))
+	ballotapi.TallyAll(ctx, cty.Organizer(), 1)
+
+	acc := account.Get(ctx, cty.Gov(), cty.MemberAccountID(0))
+	if q := acc.Balance(testReputationAsset).Quantity.Float64(); q != 6.0 {
+		t.Errorf("expecting reputation balance %v, got %v", 6.0, q)
+	}
+	if q := acc.Balance(account.PluralAsset).Quantity.Float64(); q != 0.0 {
+		t.Errorf("expecting no plural credits charged, got %v", q)
+	}
+
+	// cancelling refunds the reputation asset
+	ballotapi.Cancel(ctx, cty.Organizer(), ballotName)
+	if q := account.Get(ctx, cty.Gov(), cty.MemberAccountID(0)).Balance(testReputationAsset).Quantity.Float64(); q != 10.0 {
+		t.Errorf("expecting refunded reputation balance %v, got %v", 10.0, q)
+	}
+}
+
+func TestMemberIssuer(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 2)
+
+	// an asset issued from a member's account is issued by overdrawing the member's holdings of the asset
+	account.DefineAsset(ctx, cty.Gov(), account.AssetInfo{Asset: testBadgeAsset, Transferable: true, Divisible: false, Issuer: cty.MemberAccountID(1)})
+	account.Issue(ctx, cty.Gov(), cty.MemberAccountID(0), account.H(testBadgeAsset, 2.0), "test")
+	if q := account.Get(ctx, cty.Gov(), cty.MemberAccountID(0)).Balance(testBadgeAsset).Quantity.Float64(); q != 2.0 {
+		t.Errorf("expecting 2 badges issued, got %v", q)
+	}
+	if q := account.Get(ctx, cty.Gov(), cty.MemberAccountID(1)).Balance(testBadgeAsset).Quantity.Float64(); q != -2.0 {
+		t.Errorf("expecting member issuer to be overdrawn by the issued supply, got %v", q)
+	}
+
+	// the issuer cannot transfer the asset, as it holds none
+	if must.Try(func() {
+		account.Transfer(ctx, cty.Gov(), cty.MemberAccountID(1), cty.MemberAccountID(0), account.H(testBadgeAsset, 1.0), "test")
+	}) == nil {
+		t.Errorf("transferring from an overdrawn issuer should fail")
+	}
+
+	// the supply of the asset is conserved
+	report := account.Audit(ctx, cty.Gov())
+	for _, s := range report.Supply {
+		if s.Asset == testBadgeAsset && (!s.Conserved || s.Issued.Float64() != 2.0) {
+			t.Errorf("expecting 2 badges issued and conserved, got %v", form.SprintJSON(s))
+		}
+	}
+}
+
+func TestBallotAsset(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 2)
+
+	account.DefineAsset(ctx, cty.Gov(), account.AssetInfo{Asset: testReputationAsset, Transferable: false, Divisible: true})
+	account.Issue(ctx, cty.Gov(), cty.MemberAccountID(0), account.H(testReputationAsset, 10.0), "test")
+
+	// the ballot charges the reputation asset, while its policy charges plural credits
+	ballotName := ballotproto.ParseBallotID("asset/b")
+	ballotapi.Open(ctx, ballotio.QVPolicyName, cty.Organizer(), ballotName, account.NobodyAccountID, purpose.Unspecified, "", "reputation", "reputation ballot", []string{"x"}, member.Everybody,
+		ballotapi.WithAsset(testReputationAsset),
+	)
+	ballotapi.Vote(ctx, cty.MemberOwner(0), cty.Gov(), ballotName, ballotproto.OneElection("x", 4.0))
+	ballotapi.TallyAll(ctx, cty.Organizer(), 1)
+
+	if q := account.Get(ctx, cty.Gov(), cty.MemberAccountID(0)).Balance(testReputationAsset).Quantity.Float64(); q != 6.0 {
+		t.Errorf("expecting reputation balance %v, got %v", 6.0, q)
+	}
+
+	// ranked-choice policies do not charge voters
+	if must.Try(func() {
+		ballotapi.Open(ctx, ballotio.IRVPolicyName, cty.Organizer(), ballotproto.ParseBallotID("asset/c"), account.NobodyAccountID, purpose.Unspecified, "", "irv", "irv ballot", []string{"x"}, member.Everybody,
+			ballotapi.WithAsset(testReputationAsset),
+		)
+	}) == nil {
+		t.Errorf("expecting charged asset to be refused by a policy that does not charge")
+	}
+}
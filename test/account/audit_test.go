@@ -0,0 +1,129 @@
+package account
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gov4git/gov4git/v2/proto"
+	"github.com/gov4git/gov4git/v2/proto/account"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotapi"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotio"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotproto"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/kv"
+	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/gov4git/v2/proto/purpose"
+	"github.com/gov4git/gov4git/v2/runtime"
+	"github.com/gov4git/gov4git/v2/test"
+	"github.com/gov4git/lib4git/form"
+	"github.com/gov4git/lib4git/git"
+	"github.com/gov4git/lib4git/testutil"
+)
+
+func TestAudit(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 2)
+
+	// issue, transfer, burn and charge votes
+	account.Issue(ctx, cty.Gov(), cty.MemberAccountID(0), account.H(account.PluralAsset, 10.0), "test")
+	account.Issue(ctx, cty.Gov(), cty.MemberAccountID(1), account.H(account.PluralAsset, 0.3), "test")
+	account.Transfer(ctx, cty.Gov(), cty.MemberAccountID(0), cty.MemberAccountID(1), account.H(account.PluralAsset, 0.1), "test")
+	account.Burn(ctx, cty.Gov(), cty.MemberAccountID(1), account.H(account.PluralAsset, 0.2), "test")
+
+	ballotName := ballotproto.ParseBallotID("audit/a")
+	ballotapi.Open(ctx, ballotio.QVPolicyName, cty.Organizer(), ballotName, account.NobodyAccountID, purpose.Unspecified, "", "audit", "audit ballot", []string{"x"}, member.Everybody)
+	ballotapi.Vote(ctx, cty.MemberOwner(0), cty.Gov(), ballotName, ballotproto.OneElection("x", 1.5))
+	ballotapi.TallyAll(ctx, cty.Organizer(), 1)
+	ballotapi.Close(ctx, cty.Organizer(), ballotName, account.BurnAccountID)
+
+	report := account.Audit(ctx, cty.Gov())
+	if !report.IsConsistent() {
+		t.Fatalf("expecting consistent accounts, got %v", form.SprintJSON(report))
+	}
+	if len(report.Supply) != 1 || report.Supply[0].Issued != account.Q(10.3) {
+		t.Errorf("expecting %v issued, got %v", account.Q(10.3), form.SprintJSON(report.Supply))
+	}
+
+	// tamper with an account balance, bypassing the account api
+	cloned := gov.Clone(ctx, cty.Gov())
+	acc := account.Get_Local(ctx, cloned, cty.MemberAccountID(1))
+	acc.Deposit(ctx, account.H(account.PluralAsset, 5.0))
+	kv.KV[account.AccountID, *account.Account]{}.Set(ctx, proto.RootNS.Append("account"), cloned.Tree(), acc.ID, acc)
+	proto.Commitf(ctx, cloned, "tamper", "tamper with account")
+	tamperCommit := git.Head(ctx, cloned.Repo())
+	cloned.Push(ctx)
+
+	// more activity after tampering
+	account.Transfer(ctx, cty.Gov(), cty.MemberAccountID(1), cty.MemberAccountID(0), account.H(account.PluralAsset, 1.0), "test")
+
+	report = account.Audit(ctx, cty.Gov())
+	if report.IsConsistent() || len(report.Discrepancies) != 1 {
+		t.Fatalf("expecting one discrepancy, got %v", form.SprintJSON(report))
+	}
+	d := report.Discrepancies[0]
+	if d.Account != cty.MemberAccountID(1) || d.Recorded.Sub(d.Replayed) != account.Q(5.0) {
+		t.Errorf("unexpected discrepancy %v", form.SprintJSON(d))
+	}
+	if d.Commit != string(tamperCommit) || !strings.HasPrefix(d.Message, "tamper with account") {
+		t.Errorf("expecting discrepancy introduced by %v, got %v", tamperCommit, form.SprintJSON(d))
+	}
+	if report.Supply[0].Conserved {
+		t.Errorf("expecting tampered asset to not be conserved")
+	}
+}
+
+func TestAuditBalancedTampering(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 2)
+
+	account.Issue(ctx, cty.Gov(), cty.MemberAccountID(0), account.H(account.PluralAsset, 10.0), "test")
+
+	// move a balance between accounts, bypassing the account api, so that recorded balances still sum up to zero
+	cloned := gov.Clone(ctx, cty.Gov())
+	from := account.Get_Local(ctx, cloned, cty.MemberAccountID(0))
+	to := account.Get_Local(ctx, cloned, cty.MemberAccountID(1))
+	from.Withdraw(ctx, account.H(account.PluralAsset, 4.0))
+	to.Deposit(ctx, account.H(account.PluralAsset, 4.0))
+	accountNS := proto.RootNS.Append("account")
+	kv.KV[account.AccountID, *account.Account]{}.Set(ctx, accountNS, cloned.Tree(), from.ID, from)
+	kv.KV[account.AccountID, *account.Account]{}.Set(ctx, accountNS, cloned.Tree(), to.ID, to)
+	proto.Commitf(ctx, cloned, "tamper", "tamper with accounts")
+	cloned.Push(ctx)
+
+	report := account.Audit(ctx, cty.Gov())
+	if report.IsConsistent() || len(report.Discrepancies) != 2 {
+		t.Fatalf("expecting two discrepancies, got %v", form.SprintJSON(report))
+	}
+	s := report.Supply[0]
+	if !s.Issuer.Add(s.Burned).Add(s.Circulating).IsZero() {
+		t.Errorf("expecting recorded balances to sum up to zero, got %v", form.SprintJSON(s))
+	}
+	if s.Conserved {
+		t.Errorf("expecting tampered asset to not be conserved")
+	}
+}
+
+func TestAuditExactQuantities(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 2)
+
+	// quantities with more significant digits than a floating-point number holds
+	large, err := account.ParseQuantity("12345678.123456789")
+	if err != nil {
+		t.Fatal(err)
+	}
+	small, err := account.ParseQuantity("0.000000001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	account.Issue(ctx, cty.Gov(), cty.MemberAccountID(0), account.HQ(account.PluralAsset, large), "test")
+	account.Transfer(ctx, cty.Gov(), cty.MemberAccountID(0), cty.MemberAccountID(1), account.HQ(account.PluralAsset, small), "test")
+
+	report := account.Audit(ctx, cty.Gov())
+	if !report.IsConsistent() {
+		t.Fatalf("expecting consistent accounts, got %v", form.SprintJSON(report))
+	}
+	if report.Supply[0].Issued != large {
+		t.Errorf("expecting %v issued, got %v", large, report.Supply[0].Issued)
+	}
+}
@@ -0,0 +1,80 @@
+package account
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/gov4git/gov4git/v2/proto/account"
+	"github.com/gov4git/gov4git/v2/runtime"
+	"github.com/gov4git/gov4git/v2/test"
+	"github.com/gov4git/lib4git/form"
+	"github.com/gov4git/lib4git/testutil"
+)
+
+func TestStatement(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 2)
+
+	u0, u1 := cty.MemberAccountID(0), cty.MemberAccountID(1)
+	account.Issue(ctx, cty.Gov(), u0, account.H(account.PluralAsset, 10.0), "welcome")
+	account.Transfer(ctx, cty.Gov(), u0, u1, account.H(account.PluralAsset, 3.0), "lunch")
+	time.Sleep(time.Second)
+	middle := time.Now()
+	time.Sleep(time.Second)
+	account.Transfer(ctx, cty.Gov(), u1, u0, account.H(account.PluralAsset, 1.0), "change")
+	account.Burn(ctx, cty.Gov(), u0, account.H(account.PluralAsset, 2.0), "fee")
+
+	// full statement
+	st := account.Statement(ctx, cty.Gov(), u0, time.Time{}, time.Time{})
+	if len(st.Entries) != 4 {
+		t.Fatalf("expecting 4 entries, got %v", form.SprintJSON(st))
+	}
+	exp := []struct {
+		op           account.StatementOp
+		counterparty account.AccountID
+		debit        bool
+		balance      float64
+		note         string
+	}{
+		{account.StatementIssue, account.IssueAccountID, false, 10.0, "welcome"},
+		{account.StatementTransfer, u1, true, 7.0, "lunch"},
+		{account.StatementTransfer, u1, false, 8.0, "change"},
+		{account.StatementBurn, account.BurnAccountID, true, 6.0, "fee"},
+	}
+	for i, e := range st.Entries {
+		x := exp[i]
+		if e.Op != x.op || e.Counterparty != x.counterparty || (e.Debit != nil) != x.debit || e.Balance.Quantity != account.Q(x.balance) || e.Note != x.note {
+			t.Errorf("entry %d: unexpected %v", i, form.SprintJSON(e))
+		}
+		if e.Commit == "" {
+			t.Errorf("entry %d: missing commit", i)
+		}
+	}
+
+	// statement from the middle
+	st = account.Statement(ctx, cty.Gov(), u0, middle, time.Time{})
+	if len(st.Entries) != 2 {
+		t.Fatalf("expecting 2 entries, got %v", form.SprintJSON(st))
+	}
+	if q := st.Opening.Balance(account.PluralAsset).Quantity; q != account.Q(7.0) {
+		t.Errorf("expecting opening balance 7, got %v", q)
+	}
+	if q := st.Closing.Balance(account.PluralAsset).Quantity; q != account.Q(6.0) {
+		t.Errorf("expecting closing balance 6, got %v", q)
+	}
+
+	// csv export
+	var w bytes.Buffer
+	if err := st.WriteCSV(&w); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(&w).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 3 || rows[1][6] != "1" || rows[2][5] != "2" || rows[2][7] != "6" || rows[2][8] != "fee" {
+		t.Errorf("unexpected csv %v", rows)
+	}
+}
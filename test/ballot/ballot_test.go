@@ -65,7 +65,7 @@ func TestOpenClose(t *testing.T) {
 
 	// verify no credits left
 	credits := account.Get(ctx, cty.Gov(), cty.MemberAccountID(0)).Balance(account.PluralAsset)
-	if credits.Quantity != 0.0 {
+	if credits.Quantity.Float64() != 0.0 {
 		t.Errorf("expecting %v, got %v", 0.0, credits)
 	}
 
@@ -115,7 +115,7 @@ func TestOpenCancel(t *testing.T) {
 
 	// verify no credits left
 	credits := account.Get(ctx, cty.Gov(), cty.MemberAccountID(0)).Balance(account.PluralAsset)
-	if credits.Quantity != 1.0 {
+	if credits.Quantity.Float64() != 1.0 {
 		t.Errorf("expecting %v, got %v", 1.0, credits)
 	}
 
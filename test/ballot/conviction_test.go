@@ -0,0 +1,141 @@
+package ballot
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gov4git/gov4git/v2/proto/account"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotapi"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotio"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotpolicies/sv"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotproto"
+	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/gov4git/v2/proto/purpose"
+	"github.com/gov4git/gov4git/v2/runtime"
+	"github.com/gov4git/gov4git/v2/test"
+	"github.com/gov4git/lib4git/form"
+	"github.com/gov4git/lib4git/testutil"
+)
+
+const (
+	testConvictionPolicyName          ballotproto.PolicyName = "test-conviction"
+	testConvictionFreezePolicyName    ballotproto.PolicyName = "test-conviction-freeze"
+	testConvictionThresholdPolicyName ballotproto.PolicyName = "test-conviction-threshold"
+)
+
+func TestConviction(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 2)
+
+	ballotio.Install(ctx, testConvictionPolicyName, sv.SV{Kernel: sv.MakeConvictionScoreKernel(ctx, 2*time.Second, nil)})
+
+	ballotName := ballotproto.ParseBallotID("a/b/c")
+	choices := []string{"x"}
+
+	// give voter credits
+	account.Issue(ctx, cty.Gov(), cty.MemberAccountID(0), account.H(account.PluralAsset, 10.0), "test")
+
+	// open
+	ballotapi.Open(ctx, testConvictionPolicyName, cty.Organizer(), ballotName, account.NobodyAccountID, purpose.Unspecified, "", "conviction", "conviction ballot", choices, member.Everybody)
+
+	// vote and tally
+	ballotapi.Vote(ctx, cty.MemberOwner(0), cty.Gov(), ballotName, ballotproto.OneElection("x", 4.0))
+	ballotapi.TallyAll(ctx, cty.Organizer(), testMaxPar)
+	score0 := ballotapi.Show(ctx, cty.Gov(), ballotName).Tally.Scores["x"]
+
+	// wait for conviction to accrue and re-tally without new votes
+	time.Sleep(2 * time.Second)
+	tallyChg := ballotapi.TallyAll(ctx, cty.Organizer(), testMaxPar)
+	fmt.Println("tally: ", form.SprintJSON(tallyChg))
+	if len(tallyChg.Result) != 1 {
+		t.Fatalf("expecting conviction ballot to be re-tallied")
+	}
+	ast := ballotapi.Show(ctx, cty.Gov(), ballotName)
+	score1 := ast.Tally.Scores["x"]
+	if !(score0 < score1 && score1 >= 2.0 && score1 < 4.0) {
+		t.Errorf("expecting conviction to accrue towards 4.0, got %v then %v", score0, score1)
+	}
+	if ast.Margin == nil || ast.Margin.Forecast == nil {
+		t.Errorf("expecting forecast margin")
+	}
+
+	// verify the voter is charged only once
+	if charge := ast.Tally.Charges[cty.MemberUser(0)]; charge != 4.0 {
+		t.Errorf("expecting charge %v, got %v", 4.0, charge)
+	}
+	credits := account.Get(ctx, cty.Gov(), cty.MemberAccountID(0)).Balance(account.PluralAsset)
+	if credits.Quantity.Float64() != 6.0 {
+		t.Errorf("expecting %v, got %v", 6.0, credits.Quantity.Float64())
+	}
+}
+
+func TestConvictionStopsAtFreeze(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 2)
+
+	ballotio.Install(ctx, testConvictionFreezePolicyName, sv.SV{Kernel: sv.MakeConvictionScoreKernel(ctx, 2*time.Second, nil)})
+
+	ballotName := ballotproto.ParseBallotID("a/b/c")
+	choices := []string{"x"}
+
+	account.Issue(ctx, cty.Gov(), cty.MemberAccountID(0), account.H(account.PluralAsset, 10.0), "test")
+
+	// open a ballot that freezes shortly
+	freezesAt := time.Now().Add(3 * time.Second)
+	ballotapi.Open(ctx, testConvictionFreezePolicyName, cty.Organizer(), ballotName, account.NobodyAccountID, purpose.Unspecified, "", "conviction", "conviction ballot", choices, member.Everybody,
+		ballotapi.WithSchedule(nil, &freezesAt, nil))
+
+	ballotapi.Vote(ctx, cty.MemberOwner(0), cty.Gov(), ballotName, ballotproto.OneElection("x", 4.0))
+	ballotapi.TallyAll(ctx, cty.Organizer(), testMaxPar)
+
+	// tally after the freeze deadline
+	time.Sleep(time.Until(freezesAt) + time.Second)
+	ballotapi.Schedule(ctx, cty.Organizer(), time.Now(), testMaxPar)
+	ballotapi.TallyAll(ctx, cty.Organizer(), testMaxPar)
+	score0 := ballotapi.Show(ctx, cty.Gov(), ballotName).Tally.Scores["x"]
+
+	// conviction does not accrue after the freeze, so the tally is not re-committed
+	time.Sleep(time.Second)
+	tallyChg := ballotapi.TallyAll(ctx, cty.Organizer(), testMaxPar)
+	if len(tallyChg.Result) != 0 {
+		t.Errorf("expecting no re-tally of frozen conviction ballot, got %v", form.SprintJSON(tallyChg.Result))
+	}
+	if score1 := ballotapi.Show(ctx, cty.Gov(), ballotName).Tally.Scores["x"]; score1 != score0 {
+		t.Errorf("expecting conviction to stop at %v, got %v", score0, score1)
+	}
+}
+
+func TestConvictionReTallyThreshold(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 2)
+
+	ballotio.Install(ctx, testConvictionThresholdPolicyName, sv.SV{Kernel: sv.MakeConvictionScoreKernel(ctx, 2*time.Second, nil)})
+
+	ballotName := ballotproto.ParseBallotID("a/b/c")
+	choices := []string{"x"}
+
+	account.Issue(ctx, cty.Gov(), cty.MemberAccountID(0), account.H(account.PluralAsset, 10.0), "test")
+	ballotapi.Open(ctx, testConvictionThresholdPolicyName, cty.Organizer(), ballotName, account.NobodyAccountID, purpose.Unspecified, "", "conviction", "conviction ballot", choices, member.Everybody)
+	ballotapi.Vote(ctx, cty.MemberOwner(0), cty.Gov(), ballotName, ballotproto.OneElection("x", 4.0))
+	ballotapi.TallyAll(ctx, cty.Organizer(), testMaxPar)
+	score0 := ballotapi.Show(ctx, cty.Gov(), ballotName).Tally.Scores["x"]
+
+	// accrued conviction below the threshold is not committed
+	saveThreshold := ballotapi.ReTallyThreshold
+	defer func() { ballotapi.ReTallyThreshold = saveThreshold }()
+	ballotapi.ReTallyThreshold = 1.0
+	time.Sleep(time.Second)
+	if tallyChg := ballotapi.TallyAll(ctx, cty.Organizer(), testMaxPar); len(tallyChg.Result) != 0 {
+		t.Errorf("expecting no re-tally below the threshold, got %v", form.SprintJSON(tallyChg.Result))
+	}
+	if score1 := ballotapi.Show(ctx, cty.Gov(), ballotName).Tally.Scores["x"]; score1 != score0 {
+		t.Errorf("expecting score to remain %v, got %v", score0, score1)
+	}
+
+	// closing brings the tally up to date
+	ballotapi.Close(ctx, cty.Organizer(), ballotName, account.BurnAccountID)
+	if score2 := ballotapi.Show(ctx, cty.Gov(), ballotName).Tally.Scores["x"]; score2 <= score0 {
+		t.Errorf("expecting the closing tally to accrue beyond %v, got %v", score0, score2)
+	}
+}
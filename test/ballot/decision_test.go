@@ -0,0 +1,68 @@
+package ballot
+
+import (
+	"testing"
+
+	"github.com/gov4git/gov4git/v2/proto/account"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotapi"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotio"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotproto"
+	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/gov4git/v2/proto/purpose"
+	"github.com/gov4git/gov4git/v2/runtime"
+	"github.com/gov4git/gov4git/v2/test"
+	"github.com/gov4git/lib4git/form"
+	"github.com/gov4git/lib4git/testutil"
+)
+
+func TestDecisionRule(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 2)
+
+	ballotName := ballotproto.ParseBallotID("decision/a")
+	choices := []string{"x"}
+
+	// give voters credits
+	for i := 0; i < 2; i++ {
+		account.Issue(ctx, cty.Gov(), cty.MemberAccountID(i), account.H(account.PluralAsset, 10.0), "test")
+	}
+
+	// open
+	ballotapi.Open(ctx, ballotio.QVPolicyName, cty.Organizer(), ballotName, account.NobodyAccountID, purpose.Unspecified, "", "decision", "decision ballot", choices, member.Everybody,
+		ballotapi.WithDecisionRule(ballotproto.DecisionRule{MinVoters: 2, Supermajority: 0.75}),
+	)
+
+	// one vote in favor does not reach quorum
+	ballotapi.Vote(ctx, cty.MemberOwner(0), cty.Gov(), ballotName, ballotproto.OneElection("x", 9.0))
+	ballotapi.TallyAll(ctx, cty.Organizer(), testMaxPar)
+	if d := ballotapi.WouldPass(ctx, cty.Gov(), ballotName); d.Passed || len(d.Reasons) != 1 {
+		t.Errorf("expecting failure to reach quorum, got %v", form.SprintJSON(d))
+	}
+
+	// a second vote against reaches quorum, but not supermajority
+	ballotapi.Vote(ctx, cty.MemberOwner(1), cty.Gov(), ballotName, ballotproto.OneElection("x", -4.0))
+	ballotapi.TallyAll(ctx, cty.Organizer(), testMaxPar)
+	if d := ballotapi.Show(ctx, cty.Gov(), ballotName).Decision; d == nil || d.Passed || d.Support != 0.6 {
+		t.Errorf("expecting failure to reach supermajority, got %v", form.SprintJSON(d))
+	}
+
+	// the decision is recorded in the outcome
+	account.Create(ctx, cty.Gov(), testDecisionEscrowTo, account.NobodyAccountID, "test")
+	closeChg := ballotapi.Close(ctx, cty.Organizer(), ballotName, testDecisionEscrowTo)
+	if d := closeChg.Result.Decision; d == nil || d.Passed || len(d.Reasons) != 1 {
+		t.Errorf("expecting failed decision in outcome, got %v", form.SprintJSON(closeChg.Result))
+	}
+	if closeChg.Result.Summary != string(ballotproto.SummaryRejected) {
+		t.Errorf("expecting outcome summary %v, got %v", ballotproto.SummaryRejected, closeChg.Result.Summary)
+	}
+
+	// a failed decision does not change how the ballot is closed
+	if !ballotapi.Show(ctx, cty.Gov(), ballotName).Ad.Closed {
+		t.Errorf("expecting ballot to be closed")
+	}
+	if q := account.Get(ctx, cty.Gov(), testDecisionEscrowTo).Balance(account.PluralAsset).Quantity.Float64(); q != 13.0 {
+		t.Errorf("expecting escrow of 13 to be settled despite the failed decision, got %v", q)
+	}
+}
+
+const testDecisionEscrowTo = account.AccountID("decision-escrow")
@@ -0,0 +1,146 @@
+package ballot
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gov4git/gov4git/v2/proto/account"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotapi"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotio"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotproto"
+	"github.com/gov4git/gov4git/v2/proto/bureau"
+	"github.com/gov4git/gov4git/v2/proto/delegation"
+	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/gov4git/v2/proto/purpose"
+	"github.com/gov4git/gov4git/v2/runtime"
+	"github.com/gov4git/gov4git/v2/test"
+	"github.com/gov4git/lib4git/form"
+	"github.com/gov4git/lib4git/testutil"
+)
+
+func TestDelegation(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 3)
+
+	delegatedBallot := ballotproto.ParseBallotID("delegate/a")
+	overrideBallot := ballotproto.ParseBallotID("delegate/b")
+	choices := []string{"x"}
+
+	// give voters credits
+	for i := 0; i < 3; i++ {
+		account.Issue(ctx, cty.Gov(), cty.MemberAccountID(i), account.H(account.PluralAsset, 10.0), "test")
+	}
+
+	// user 0 delegates to user 1 via the bureau, and user 2 delegates to user 0 directly
+	bureau.Delegate(ctx, cty.MemberOwner(0), cty.Gov(), member.User(""), cty.MemberUser(1), delegation.AllPurposes, false)
+	bureau.Process(ctx, cty.Organizer(), member.Everybody)
+	delegation.Delegate(ctx, cty.Gov(), cty.MemberUser(2), cty.MemberUser(0), purpose.Unspecified)
+
+	// open
+	ballotapi.Open(ctx, ballotio.QVPolicyName, cty.Organizer(), delegatedBallot, account.NobodyAccountID, purpose.Unspecified, "", "delegated", "delegated ballot", choices, member.Everybody)
+	ballotapi.Open(ctx, ballotio.QVPolicyName, cty.Organizer(), overrideBallot, account.NobodyAccountID, purpose.Unspecified, "", "override", "override ballot", choices, member.Everybody)
+
+	// user 1 votes on both ballots, user 0 votes directly only on the second ballot
+	ballotapi.Vote(ctx, cty.MemberOwner(1), cty.Gov(), delegatedBallot, ballotproto.OneElection("x", 4.0))
+	ballotapi.Vote(ctx, cty.MemberOwner(1), cty.Gov(), overrideBallot, ballotproto.OneElection("x", 4.0))
+	ballotapi.Vote(ctx, cty.MemberOwner(0), cty.Gov(), overrideBallot, ballotproto.OneElection("x", -1.0))
+
+	tallyChg := ballotapi.TallyAll(ctx, cty.Organizer(), testMaxPar)
+	fmt.Println("tally: ", form.SprintJSON(tallyChg))
+
+	// verify delegated ballot: users 0 and 2 vote like user 1
+	del := ballotapi.Show(ctx, cty.Gov(), delegatedBallot)
+	for _, i := range []int{0, 2} {
+		u := cty.MemberUser(i)
+		if del.Tally.Delegations[u] != cty.MemberUser(1) {
+			t.Errorf("expecting user %v to delegate to %v, got %v", u, cty.MemberUser(1), del.Tally.Delegations[u])
+		}
+		acc := del.Tally.AcceptedVotes[u]
+		if len(acc) != 1 || acc[0].Vote.VoteDelegate != cty.MemberUser(1) {
+			t.Errorf("expecting one delegated vote for user %v, got %v", u, form.SprintJSON(acc))
+		}
+		if charge := del.Tally.Charges[u]; charge != 4.0 {
+			t.Errorf("expecting charge %v for user %v, got %v", 4.0, u, charge)
+		}
+	}
+	if s := del.Tally.Scores["x"]; s != 6.0 {
+		t.Errorf("expecting score %v, got %v", 6.0, s)
+	}
+
+	// verify override ballot: user 0 votes directly, and user 2 follows user 0
+	ovr := ballotapi.Show(ctx, cty.Gov(), overrideBallot)
+	if _, ok := ovr.Tally.Delegations[cty.MemberUser(0)]; ok {
+		t.Errorf("expecting no delegation for user 0, who voted directly")
+	}
+	if ovr.Tally.Delegations[cty.MemberUser(2)] != cty.MemberUser(0) {
+		t.Errorf("expecting user 2 to delegate to user 0, got %v", ovr.Tally.Delegations[cty.MemberUser(2)])
+	}
+	if s := ovr.Tally.Scores["x"]; s != 0.0 {
+		t.Errorf("expecting score %v, got %v", 0.0, s)
+	}
+
+	// tallying again does not mirror votes twice
+	ballotapi.TallyAll(ctx, cty.Organizer(), testMaxPar)
+	if charge := ballotapi.Show(ctx, cty.Gov(), delegatedBallot).Tally.Charges[cty.MemberUser(0)]; charge != 4.0 {
+		t.Errorf("expecting charge %v, got %v", 4.0, charge)
+	}
+
+	// user 0 revokes the delegation
+	bureau.Delegate(ctx, cty.MemberOwner(0), cty.Gov(), member.User(""), "", delegation.AllPurposes, true)
+	bureau.Process(ctx, cty.Organizer(), member.Everybody)
+	if _, ok := delegation.Get(ctx, cty.Gov(), cty.MemberUser(0)).Lookup(purpose.Unspecified); ok {
+		t.Errorf("expecting delegation to be revoked")
+	}
+}
+
+func TestDelegatorOverridesDelegate(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 2)
+
+	ballotName := ballotproto.ParseBallotID("delegate/override")
+	choices := []string{"x"}
+
+	for i := 0; i < 2; i++ {
+		account.Issue(ctx, cty.Gov(), cty.MemberAccountID(i), account.H(account.PluralAsset, 10.0), "test")
+	}
+
+	// user 0 delegates to user 1
+	delegation.Delegate(ctx, cty.Gov(), cty.MemberUser(0), cty.MemberUser(1), purpose.Unspecified)
+	ballotapi.Open(ctx, ballotio.QVPolicyName, cty.Organizer(), ballotName, account.NobodyAccountID, purpose.Unspecified, "", "override", "override ballot", choices, member.Everybody)
+
+	// user 1 votes, and user 0 votes like user 1
+	ballotapi.Vote(ctx, cty.MemberOwner(1), cty.Gov(), ballotName, ballotproto.OneElection("x", 4.0))
+	ballotapi.TallyAll(ctx, cty.Organizer(), testMaxPar)
+	if charge := ballotapi.Show(ctx, cty.Gov(), ballotName).Tally.Charges[cty.MemberUser(0)]; charge != 4.0 {
+		t.Fatalf("expecting delegated charge %v, got %v", 4.0, charge)
+	}
+
+	// user 0 overrides the delegate by voting directly
+	ballotapi.Vote(ctx, cty.MemberOwner(0), cty.Gov(), ballotName, ballotproto.OneElection("x", -1.0))
+	tallyChg := ballotapi.TallyAll(ctx, cty.Organizer(), testMaxPar)
+	fmt.Println("tally: ", form.SprintJSON(tallyChg))
+
+	ast := ballotapi.Show(ctx, cty.Gov(), ballotName)
+	if _, ok := ast.Tally.Delegations[cty.MemberUser(0)]; ok {
+		t.Errorf("expecting delegation of user 0 to be cleared")
+	}
+	if charge := ast.Tally.Charges[cty.MemberUser(0)]; charge != 1.0 {
+		t.Errorf("expecting charge %v, got %v", 1.0, charge)
+	}
+	if s := ast.Tally.ScoresByUser[cty.MemberUser(0)]["x"].Score; s != -1.0 {
+		t.Errorf("expecting user 0 score %v, got %v", -1.0, s)
+	}
+	if s := ast.Tally.Scores["x"]; s != 1.0 {
+		t.Errorf("expecting score %v, got %v", 1.0, s)
+	}
+	credits := account.Get(ctx, cty.Gov(), cty.MemberAccountID(0)).Balance(account.PluralAsset)
+	if credits.Quantity.Float64() != 9.0 {
+		t.Errorf("expecting %v, got %v", 9.0, credits.Quantity.Float64())
+	}
+
+	// tallying again does not unwind twice
+	ballotapi.TallyAll(ctx, cty.Organizer(), testMaxPar)
+	if charge := ballotapi.Show(ctx, cty.Gov(), ballotName).Tally.Charges[cty.MemberUser(0)]; charge != 1.0 {
+		t.Errorf("expecting charge %v, got %v", 1.0, charge)
+	}
+}
@@ -0,0 +1,70 @@
+package ballot
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/gov4git/gov4git/v2/proto/account"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotapi"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotio"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotproto"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/id"
+	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/gov4git/v2/proto/purpose"
+	"github.com/gov4git/gov4git/v2/runtime"
+	"github.com/gov4git/gov4git/v2/test"
+	"github.com/gov4git/lib4git/form"
+	"github.com/gov4git/lib4git/git"
+	"github.com/gov4git/lib4git/testutil"
+)
+
+func TestEncryptedBallot(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 2)
+
+	ballotName := ballotproto.ParseBallotID("encrypted/a")
+	choices := []string{"x", "y"}
+
+	// give voters credits
+	for i := 0; i < 2; i++ {
+		account.Issue(ctx, cty.Gov(), cty.MemberAccountID(i), account.H(account.PluralAsset, 10.0), "test")
+	}
+
+	// open
+	ballotapi.Open(ctx, ballotio.QVPolicyName, cty.Organizer(), ballotName, account.NobodyAccountID, purpose.Unspecified, "", "encrypted", "encrypted ballot", choices, member.Everybody,
+		ballotapi.WithEncryption(),
+	)
+	if ad := ballotapi.Show(ctx, cty.Gov(), ballotName).Ad; !ad.IsEncrypted() {
+		t.Fatalf("expecting ballot to be encrypted")
+	}
+
+	// vote
+	ballotapi.Vote(ctx, cty.MemberOwner(0), cty.Gov(), ballotName, ballotproto.OneElection("x", 4.0))
+	ballotapi.Vote(ctx, cty.MemberOwner(1), cty.Gov(), ballotName, ballotproto.OneElection("y", 1.0))
+
+	// verify the voter's public vote log does not disclose elections
+	govCred := id.GetPublicCredentials(ctx, gov.Clone(ctx, cty.Gov()).Tree())
+	voterCloned := git.CloneOne(ctx, git.Address(cty.MemberOwner(0).Public))
+	voteLog := git.FromFile[ballotproto.VoteLog](ctx, voterCloned.Tree(), ballotproto.VoteLogPath(govCred.ID, ballotName))
+	for _, env := range voteLog.VoteEnvelopes {
+		if len(env.Elections) > 0 || len(env.Sealed) == 0 {
+			t.Fatalf("expecting only sealed envelopes in public vote log, got %v", form.SprintJSON(env))
+		}
+	}
+
+	// verify the voter's public commit message does not disclose elections
+	if msg := publicHeadMessage(t, ctx, cty.MemberOwner(0)); strings.Contains(msg, `"vote_choice"`) {
+		t.Fatalf("expecting the voter's public commit message not to disclose elections, got %v", msg)
+	}
+
+	// tally
+	tallyChg := ballotapi.TallyAll(ctx, cty.Organizer(), testMaxPar)
+	fmt.Println("tally: ", form.SprintJSON(tallyChg))
+
+	ast := ballotapi.Show(ctx, cty.Gov(), ballotName)
+	if ast.Tally.Scores["x"] != 2.0 || ast.Tally.Scores["y"] != 1.0 {
+		t.Errorf("unexpected scores %v", ast.Tally.Scores)
+	}
+}
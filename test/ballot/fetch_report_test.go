@@ -0,0 +1,151 @@
+package ballot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/gov4git/gov4git/v2/proto"
+	"github.com/gov4git/gov4git/v2/proto/account"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotapi"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotio"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotproto"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/id"
+	"github.com/gov4git/gov4git/v2/proto/mail"
+	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/gov4git/v2/proto/purpose"
+	"github.com/gov4git/gov4git/v2/runtime"
+	"github.com/gov4git/gov4git/v2/test"
+	"github.com/gov4git/lib4git/form"
+	"github.com/gov4git/lib4git/testutil"
+)
+
+// TestFetchReport tests that unreachable voter repos and rejected votes are reported by the tally and recorded with the ballot.
+func TestFetchReport(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 2)
+
+	ballotName := ballotproto.ParseBallotID("a/b/c")
+	choices := []string{"x", "y", "z"}
+
+	// open
+	strat := ballotio.QVPolicyName
+	openChg := ballotapi.Open(ctx, strat, cty.Organizer(), ballotName, account.NobodyAccountID, purpose.Unspecified, "", "ballot title", "ballot description", choices, member.Everybody)
+	fmt.Println("open: ", form.SprintJSON(openChg))
+
+	// give voter credits
+	account.Issue(ctx, cty.Gov(), cty.MemberAccountID(0), account.H(account.PluralAsset, 1.0), "test")
+
+	// vote, then freeze, so that the vote is rejected by the tally
+	elections := ballotproto.Elections{
+		ballotproto.NewElection(choices[0], 1.0),
+	}
+	ballotapi.Vote(ctx, cty.MemberOwner(0), cty.Gov(), ballotName, elections)
+	ballotapi.Freeze(ctx, cty.Organizer(), ballotName)
+
+	// tally
+	tallyChg, report := ballotapi.TallyWithReport(ctx, cty.Organizer(), ballotName, testMaxPar)
+	fmt.Println("tally: ", form.SprintJSON(tallyChg))
+
+	if report == nil {
+		t.Fatalf("expecting a fetch report")
+	}
+	voter := report.Voters[cty.MemberUser(0)]
+	if voter == nil || voter.Fetched != 1 || len(voter.Rejected) != 1 || voter.Rejected[0].Reason != "ballot is frozen" {
+		t.Errorf("expecting one vote fetched and rejected, got %v", form.SprintJSON(voter))
+	}
+	if silent := report.Voters[cty.InvalidMemberUser(0)]; silent == nil || silent.Unreachable == "" {
+		t.Errorf("expecting silent member to be unreachable, got %v", form.SprintJSON(silent))
+	}
+	if _, ok := report.Voters[cty.MemberUser(1)]; ok {
+		t.Errorf("expecting no report for a voter without votes or problems")
+	}
+
+	// the report is recorded with the ballot, and the tally file is unaffected
+	recorded := ballotapi.LoadFetchReport(ctx, cty.Gov(), ballotName)
+	if form.SprintJSON(recorded.Voters) != form.SprintJSON(report.Voters) {
+		t.Errorf("expecting %v, got %v", form.SprintJSON(report.Voters), form.SprintJSON(recorded.Voters))
+	}
+	if msg := publicHeadMessage(t, ctx, id.OwnerAddress(cty.Organizer())); strings.Contains(msg, `"fetch_report"`) {
+		t.Errorf("fetch report must not be recorded in the tally commit")
+	}
+
+	// a tally without new votes or problems leaves the recorded report unchanged
+	tallyChg = ballotapi.Tally(ctx, cty.Organizer(), ballotName, testMaxPar)
+	fmt.Println("tally: ", form.SprintJSON(tallyChg))
+	recorded = ballotapi.LoadFetchReport(ctx, cty.Gov(), ballotName)
+	if n := len(recorded.Voters[cty.MemberUser(0)].Rejected); n != 1 {
+		t.Errorf("expecting 1 recorded rejection, got %v", n)
+	}
+
+	// testutil.Hang()
+}
+
+// TestFetchReportInvalidVotes tests that vote envelopes with invalid signatures or inconsistent elections are reported by the tally.
+func TestFetchReportInvalidVotes(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 2)
+
+	ballotName := ballotproto.ParseBallotID("a/b/c")
+	choices := []string{"x", "y", "z"}
+
+	// open
+	strat := ballotio.QVPolicyName
+	openChg := ballotapi.Open(ctx, strat, cty.Organizer(), ballotName, account.NobodyAccountID, purpose.Unspecified, "", "ballot title", "ballot description", choices, member.Everybody)
+	fmt.Println("open: ", form.SprintJSON(openChg))
+
+	// give voter credits
+	account.Issue(ctx, cty.Gov(), cty.MemberAccountID(0), account.H(account.PluralAsset, 1.0), "test")
+
+	// bypass the client checks, and send an envelope with an unknown choice and an envelope with a tampered signature
+	ad := ballotapi.Show(ctx, cty.Gov(), ballotName).Ad
+	topic := ballotproto.BallotTopic(ballotName)
+	voterOwner := id.CloneOwner(ctx, cty.MemberOwner(0))
+	govTree := gov.Clone(ctx, cty.Gov()).Tree()
+	mail.Request_StageOnly(ctx, voterOwner, govTree, topic, ballotproto.VoteEnvelope{
+		Ad:        ad,
+		Elections: ballotproto.Elections{ballotproto.NewElection("w", 1.0)},
+	})
+	mail.SendMakeMsg_StageOnly(ctx, voterOwner.Public.Tree(), govTree, topic,
+		func(ctx context.Context, seqNo mail.SeqNo) id.Signed[mail.RequestEnvelope[ballotproto.VoteEnvelope]] {
+			env := mail.RequestEnvelope[ballotproto.VoteEnvelope]{
+				SeqNo: seqNo,
+				Request: ballotproto.VoteEnvelope{
+					Ad:        ad,
+					Elections: ballotproto.Elections{ballotproto.NewElection(choices[0], 1.0)},
+				},
+			}
+			signed := id.Sign(ctx, id.GetOwnerCredentials(ctx, voterOwner), env)
+			signed.Signature[0] ^= 1
+			return signed
+		},
+	)
+	proto.Commitf(ctx, voterOwner.Public, "ballot_vote", "Vote.")
+	voterOwner.Public.Push(ctx)
+
+	// a valid vote follows the invalid ones
+	elections := ballotproto.Elections{
+		ballotproto.NewElection(choices[1], 1.0),
+	}
+	ballotapi.Vote(ctx, cty.MemberOwner(0), cty.Gov(), ballotName, elections)
+
+	// tally
+	tallyChg, report := ballotapi.TallyWithReport(ctx, cty.Organizer(), ballotName, testMaxPar)
+	fmt.Println("tally: ", form.SprintJSON(tallyChg))
+
+	voter := report.Voters[cty.MemberUser(0)]
+	if voter == nil || voter.Fetched != 1 || len(voter.Invalid) != 2 {
+		t.Fatalf("expecting one vote fetched and two invalid, got %v", form.SprintJSON(voter))
+	}
+	if voter.Invalid[0].SeqNo != 0 || voter.Invalid[0].Kind != ballotproto.InvalidEnvelope {
+		t.Errorf("expecting an invalid envelope, got %v", form.SprintJSON(voter.Invalid[0]))
+	}
+	if voter.Invalid[1].SeqNo != 1 || voter.Invalid[1].Kind != ballotproto.InvalidSignature {
+		t.Errorf("expecting an invalid signature, got %v", form.SprintJSON(voter.Invalid[1]))
+	}
+	if n := tallyChg.Result.NumVoters(); n != 1 {
+		t.Errorf("expecting 1 voter, got %v", n)
+	}
+}
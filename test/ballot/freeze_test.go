@@ -2,7 +2,9 @@ package ballot
 
 import (
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/gov4git/gov4git/v2/proto/account"
 	"github.com/gov4git/gov4git/v2/proto/ballot/ballotapi"
@@ -123,3 +125,61 @@ func TestVoteFreezeTally(t *testing.T) {
 
 	// testutil.Hang()
 }
+
+// TestVotesAfterFreezeDeadline tests that votes are judged by the time they were cast:
+// votes cast before the freeze deadline are counted by tallies that run after it, while votes cast after it are rejected.
+func TestVotesAfterFreezeDeadline(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 2)
+
+	openBallot := ballotproto.ParseBallotID("late/open")
+	secretBallot := ballotproto.ParseBallotID("late/secret")
+	choices := []string{"x"}
+
+	account.Issue(ctx, cty.Gov(), cty.MemberAccountID(0), account.H(account.PluralAsset, 10.0), "test")
+	account.Issue(ctx, cty.Gov(), cty.MemberAccountID(1), account.H(account.PluralAsset, 10.0), "test")
+
+	// open ballots that freeze shortly
+	freezesAt := time.Now().Add(2 * time.Second)
+	ballotapi.Open(ctx, ballotio.QVPolicyName, cty.Organizer(), openBallot, account.NobodyAccountID, purpose.Unspecified, "", "late", "late ballot", choices, member.Everybody,
+		ballotapi.WithSchedule(nil, &freezesAt, nil),
+	)
+	ballotapi.Open(ctx, ballotio.QVPolicyName, cty.Organizer(), secretBallot, account.NobodyAccountID, purpose.Unspecified, "", "late", "late secret ballot", choices, member.Everybody,
+		ballotapi.WithSchedule(nil, &freezesAt, nil),
+		ballotapi.WithCommitReveal(ballotproto.CommitReveal{Deposit: 1.0, Unrevealed: ballotproto.UnrevealedPenalize}),
+	)
+
+	// vote before the deadline, but tally after it, before the schedule freezes the ballots
+	ballotapi.Vote(ctx, cty.MemberOwner(0), cty.Gov(), openBallot, ballotproto.OneElection("x", 4.0))
+	ballotapi.Vote(ctx, cty.MemberOwner(0), cty.Gov(), secretBallot, ballotproto.OneElection("x", 4.0))
+	time.Sleep(time.Until(freezesAt) + time.Second)
+	tallyChg := ballotapi.TallyAll(ctx, cty.Organizer(), testMaxPar)
+	fmt.Println("tally: ", form.SprintJSON(tallyChg))
+
+	if ast := ballotapi.Show(ctx, cty.Gov(), openBallot); ast.Tally.NumVoters() != 1 || len(ast.Tally.RejectedVotes[cty.MemberUser(0)]) != 0 {
+		t.Errorf("expecting vote cast before the deadline to be counted, got %v", form.SprintJSON(ast.Tally))
+	}
+	if ast := ballotapi.Show(ctx, cty.Gov(), secretBallot); len(ast.Tally.Commitments[cty.MemberUser(0)]) != 1 {
+		t.Errorf("expecting commitment cast before the deadline to be counted, got %v", form.SprintJSON(ast.Tally))
+	}
+
+	// vote after the deadline; the schedule tallies the late votes before freezing the ballots
+	ballotapi.Vote(ctx, cty.MemberOwner(1), cty.Gov(), openBallot, ballotproto.OneElection("x", 4.0))
+	ballotapi.Vote(ctx, cty.MemberOwner(1), cty.Gov(), secretBallot, ballotproto.OneElection("x", 4.0))
+	schedChg := ballotapi.Schedule(ctx, cty.Organizer(), time.Now(), testMaxPar)
+	fmt.Println("schedule: ", form.SprintJSON(schedChg))
+	if len(schedChg.Result.Frozen) != 2 {
+		t.Errorf("expecting 2 frozen ballots, got %v", form.SprintJSON(schedChg.Result))
+	}
+
+	for _, name := range []ballotproto.BallotID{openBallot, secretBallot} {
+		ast := ballotapi.Show(ctx, cty.Gov(), name)
+		rej := ast.Tally.RejectedVotes[cty.MemberUser(1)]
+		if len(ast.Tally.Commitments[cty.MemberUser(1)]) != 0 || len(rej) != 1 || !strings.HasPrefix(rej[0].Reason, "ballot froze at") {
+			t.Errorf("expecting late vote on %v to be rejected, got %v", name, form.SprintJSON(ast.Tally))
+		}
+	}
+	if q := account.Get(ctx, cty.Gov(), cty.MemberAccountID(1)).Balance(account.PluralAsset).Quantity.Float64(); q != 10.0 {
+		t.Errorf("expecting no charges for late votes, got balance %v", q)
+	}
+}
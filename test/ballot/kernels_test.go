@@ -0,0 +1,71 @@
+package ballot
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gov4git/gov4git/v2/proto/account"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotapi"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotio"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotproto"
+	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/gov4git/v2/proto/purpose"
+	"github.com/gov4git/gov4git/v2/runtime"
+	"github.com/gov4git/gov4git/v2/test"
+	"github.com/gov4git/lib4git/form"
+	"github.com/gov4git/lib4git/testutil"
+)
+
+func TestScoreKernels(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 2)
+
+	linearBallot := ballotproto.ParseBallotID("kernel/linear")
+	approvalBallot := ballotproto.ParseBallotID("kernel/approval")
+	scoreBallot := ballotproto.ParseBallotID("kernel/score")
+	choices := []string{"x", "y"}
+
+	// give voter credits
+	account.Issue(ctx, cty.Gov(), cty.MemberAccountID(0), account.H(account.PluralAsset, 20.0), "test")
+
+	// open
+	ballotapi.Open(ctx, ballotio.LinearPolicyName, cty.Organizer(), linearBallot, account.NobodyAccountID, purpose.Unspecified, "", "linear", "linear ballot", choices, member.Everybody)
+	ballotapi.Open(ctx, ballotio.ApprovalPolicyName, cty.Organizer(), approvalBallot, account.NobodyAccountID, purpose.Unspecified, "", "approval", "approval ballot", choices, member.Everybody)
+	ballotapi.Open(ctx, ballotio.ScorePolicyName, cty.Organizer(), scoreBallot, account.NobodyAccountID, purpose.Unspecified, "", "score", "score ballot", choices, member.Everybody)
+
+	// vote
+	ballotapi.Vote(ctx, cty.MemberOwner(0), cty.Gov(), linearBallot, ballotproto.OneElection("x", 3.0))
+	ballotapi.Vote(ctx, cty.MemberOwner(0), cty.Gov(), approvalBallot, ballotproto.Elections{
+		ballotproto.NewElection("x", 1.0),
+		ballotproto.NewElection("y", 5.0),
+	})
+	ballotapi.Vote(ctx, cty.MemberOwner(0), cty.Gov(), scoreBallot, ballotproto.OneElection("x", 15.0))
+
+	tallyChg := ballotapi.TallyAll(ctx, cty.Organizer(), testMaxPar)
+	fmt.Println("tally: ", form.SprintJSON(tallyChg))
+
+	// withdraw approval of y
+	ballotapi.Vote(ctx, cty.MemberOwner(0), cty.Gov(), approvalBallot, ballotproto.OneElection("y", -5.0))
+	ballotapi.TallyAll(ctx, cty.Organizer(), testMaxPar)
+
+	// verify scores
+	if s := ballotapi.Show(ctx, cty.Gov(), linearBallot).Tally.Scores["x"]; s != 3.0 {
+		t.Errorf("linear: expecting %v, got %v", 3.0, s)
+	}
+	approval := ballotapi.Show(ctx, cty.Gov(), approvalBallot)
+	if approval.Tally.Scores["x"] != 1.0 || approval.Tally.Scores["y"] != 0.0 {
+		t.Errorf("approval: unexpected scores %v", approval.Tally.Scores)
+	}
+	if approval.Margin == nil || approval.Margin.Reward == nil {
+		t.Errorf("approval: expecting reward margin")
+	}
+	if s := ballotapi.Show(ctx, cty.Gov(), scoreBallot).Tally.Scores["x"]; s != 10.0 {
+		t.Errorf("score: expecting %v, got %v", 10.0, s)
+	}
+
+	// verify charges: 3 (linear) + 1 (approval) + 10 (score)
+	credits := account.Get(ctx, cty.Gov(), cty.MemberAccountID(0)).Balance(account.PluralAsset)
+	if credits.Quantity.Float64() != 6.0 {
+		t.Errorf("expecting %v, got %v", 6.0, credits.Quantity.Float64())
+	}
+}
@@ -113,8 +113,8 @@ func TestQV(t *testing.T) {
 	fmt.Println("close: ", form.SprintJSON(closeChg))
 
 	// check the balances
-	c0 := account.Get(ctx, cty.Gov(), cty.MemberAccountID(0)).Balance(account.PluralAsset).Quantity
-	c1 := account.Get(ctx, cty.Gov(), cty.MemberAccountID(1)).Balance(account.PluralAsset).Quantity
+	c0 := account.Get(ctx, cty.Gov(), cty.MemberAccountID(0)).Balance(account.PluralAsset).Quantity.Float64()
+	c1 := account.Get(ctx, cty.Gov(), cty.MemberAccountID(1)).Balance(account.PluralAsset).Quantity.Float64()
 
 	xb0 := 100.0 - 3.0
 	xb1 := 100.0 - 3.0
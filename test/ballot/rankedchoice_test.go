@@ -0,0 +1,92 @@
+package ballot
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/gov4git/gov4git/v2/proto/account"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotapi"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotio"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotpolicies/rc"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotproto"
+	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/gov4git/v2/proto/purpose"
+	"github.com/gov4git/gov4git/v2/runtime"
+	"github.com/gov4git/gov4git/v2/test"
+	"github.com/gov4git/lib4git/form"
+	"github.com/gov4git/lib4git/must"
+	"github.com/gov4git/lib4git/testutil"
+)
+
+func TestRankedChoice(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 5)
+
+	irvBallot := ballotproto.ParseBallotID("rc/irv")
+	schulzeBallot := ballotproto.ParseBallotID("rc/schulze")
+	choices := []string{"x", "y", "z"}
+
+	// open
+	ballotapi.Open(ctx, ballotio.IRVPolicyName, cty.Organizer(), irvBallot, account.NobodyAccountID, purpose.Unspecified, "", "irv", "irv ballot", choices, member.Everybody)
+	ballotapi.Open(ctx, ballotio.SchulzePolicyName, cty.Organizer(), schulzeBallot, account.NobodyAccountID, purpose.Unspecified, "", "schulze", "schulze ballot", choices, member.Everybody)
+
+	// invalid rankings are refused
+	if must.Try(
+		func() {
+			ballotapi.Vote(ctx, cty.MemberOwner(0), cty.Gov(), irvBallot, ballotproto.Elections{ballotproto.NewRankedElection(ballotproto.Ranking{"x", "x"})})
+		},
+	) == nil {
+		t.Fatalf("repeated ranked choice should fail")
+	}
+
+	// vote
+	rankings := []ballotproto.Ranking{
+		{"x"},
+		{"x"},
+		{"y", "z"},
+		{"y", "z"},
+		{"z", "y"},
+	}
+	for i, r := range rankings {
+		ballotapi.Vote(ctx, cty.MemberOwner(i), cty.Gov(), irvBallot, ballotproto.Elections{ballotproto.NewRankedElection(r)})
+		ballotapi.Vote(ctx, cty.MemberOwner(i), cty.Gov(), schulzeBallot, ballotproto.Elections{ballotproto.NewRankedElection(r)})
+	}
+
+	// tally
+	tallyChg := ballotapi.TallyAll(ctx, cty.Organizer(), testMaxPar)
+	fmt.Println("tally: ", form.SprintJSON(tallyChg))
+
+	// verify irv: z is eliminated in the first round, and its votes transfer to y
+	irv := ballotapi.Show(ctx, cty.Gov(), irvBallot)
+	if len(irv.Tally.Rounds) != 2 {
+		t.Fatalf("expecting 2 rounds, got %v", form.SprintJSON(irv.Tally.Rounds))
+	}
+	if !slices.Equal(irv.Tally.Rounds[0].Eliminated, []string{"z"}) {
+		t.Errorf("expecting z eliminated, got %v", irv.Tally.Rounds[0].Eliminated)
+	}
+	if !slices.Equal(irv.Tally.Rounds.Elected(), []string{"y"}) {
+		t.Errorf("expecting y elected, got %v", irv.Tally.Rounds.Elected())
+	}
+	if irv.Tally.Scores["y"] != 3 || irv.Tally.Scores["x"] != 2 {
+		t.Errorf("unexpected final round scores %v", irv.Tally.Scores)
+	}
+	if irv.Margin == nil || irv.Margin.Help == nil {
+		t.Errorf("expecting help margin")
+	}
+
+	// verify schulze: y beats both x and z pairwise
+	sch := ballotapi.Show(ctx, cty.Gov(), schulzeBallot)
+	if !slices.Equal(sch.Tally.Rounds.Elected(), []string{"y"}) {
+		t.Errorf("expecting y elected, got %v", sch.Tally.Rounds.Elected())
+	}
+	if sch.Tally.Scores["y"] != 2 || sch.Tally.Scores["z"] != 1 || sch.Tally.Scores["x"] != 0 {
+		t.Errorf("unexpected schulze scores %v", sch.Tally.Scores)
+	}
+
+	// close
+	closeChg := ballotapi.Close(ctx, cty.Organizer(), irvBallot, account.BurnAccountID)
+	if closeChg.Result.Summary != string(rc.SummaryElected) || len(closeChg.Result.Rounds) != 2 {
+		t.Errorf("unexpected outcome %v", form.SprintJSON(closeChg.Result))
+	}
+}
@@ -0,0 +1,132 @@
+package ballot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/gov4git/gov4git/v2/proto/account"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotapi"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotio"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotproto"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/id"
+	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/gov4git/v2/proto/purpose"
+	"github.com/gov4git/gov4git/v2/runtime"
+	"github.com/gov4git/gov4git/v2/test"
+	"github.com/gov4git/lib4git/form"
+	"github.com/gov4git/lib4git/git"
+	"github.com/gov4git/lib4git/must"
+	"github.com/gov4git/lib4git/testutil"
+)
+
+func TestSecretBallot(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 3)
+
+	ballotName := ballotproto.ParseBallotID("secret/a")
+	choices := []string{"x", "y"}
+
+	// give voters credits
+	for i := 0; i < 3; i++ {
+		account.Issue(ctx, cty.Gov(), cty.MemberAccountID(i), account.H(account.PluralAsset, 10.0), "test")
+	}
+
+	// open
+	ballotapi.Open(ctx, ballotio.QVPolicyName, cty.Organizer(), ballotName, account.NobodyAccountID, purpose.Unspecified, "", "secret", "secret ballot", choices, member.Everybody,
+		ballotapi.WithCommitReveal(ballotproto.CommitReveal{Deposit: 2.0, Unrevealed: ballotproto.UnrevealedPenalize}),
+	)
+
+	// commit votes
+	ballotapi.Vote(ctx, cty.MemberOwner(0), cty.Gov(), ballotName, ballotproto.OneElection("x", 4.0))
+	ballotapi.Vote(ctx, cty.MemberOwner(1), cty.Gov(), ballotName, ballotproto.OneElection("x", 1.0))
+	ballotapi.Vote(ctx, cty.MemberOwner(2), cty.Gov(), ballotName, ballotproto.OneElection("y", 1.0))
+
+	// verify the voter's public vote log does not disclose elections
+	govCred := id.GetPublicCredentials(ctx, gov.Clone(ctx, cty.Gov()).Tree())
+	voterCloned := git.CloneOne(ctx, git.Address(cty.MemberOwner(0).Public))
+	voteLog := git.FromFile[ballotproto.VoteLog](ctx, voterCloned.Tree(), ballotproto.VoteLogPath(govCred.ID, ballotName))
+	for _, env := range voteLog.VoteEnvelopes {
+		if len(env.Elections) > 0 || env.Commitment == nil {
+			t.Fatalf("expecting only commitments in public vote log, got %v", form.SprintJSON(env))
+		}
+	}
+
+	// tally commitments
+	tallyChg := ballotapi.TallyAll(ctx, cty.Organizer(), testMaxPar)
+	fmt.Println("tally: ", form.SprintJSON(tallyChg))
+	ast := ballotapi.Show(ctx, cty.Gov(), ballotName)
+	if len(ast.Tally.Commitments) != 3 || ast.Tally.NumVoters() != 0 {
+		t.Fatalf("expecting 3 commitments and no accepted votes, got %v", form.SprintJSON(ast.Tally))
+	}
+	if q := account.Get(ctx, cty.Gov(), cty.MemberAccountID(0)).Balance(account.PluralAsset).Quantity.Float64(); q != 8.0 {
+		t.Errorf("expecting deposit to be escrowed, got balance %v", q)
+	}
+
+	// reveals are refused before the ballot is frozen
+	if must.Try(func() { ballotapi.Reveal(ctx, cty.MemberOwner(0), cty.Gov(), ballotName) }) == nil {
+		t.Fatalf("reveal before freeze should fail")
+	}
+
+	// freeze and reveal
+	ballotapi.Freeze(ctx, cty.Organizer(), ballotName)
+	ballotapi.Reveal(ctx, cty.MemberOwner(0), cty.Gov(), ballotName)
+	ballotapi.Reveal(ctx, cty.MemberOwner(1), cty.Gov(), ballotName)
+	ballotapi.TallyAll(ctx, cty.Organizer(), testMaxPar)
+
+	// verify only revealed votes are counted
+	ast = ballotapi.Show(ctx, cty.Gov(), ballotName)
+	if s := ast.Tally.Scores["x"]; s != 3.0 {
+		t.Errorf("expecting score %v, got %v", 3.0, s)
+	}
+	if s := ast.Tally.Scores["y"]; s != 0.0 {
+		t.Errorf("expecting score %v, got %v", 0.0, s)
+	}
+	if q := account.Get(ctx, cty.Gov(), cty.MemberAccountID(0)).Balance(account.PluralAsset).Quantity.Float64(); q != 6.0 {
+		t.Errorf("expecting deposit refund and vote charge, got balance %v", q)
+	}
+
+	// close and verify the unrevealed deposit is forfeited
+	closeChg := ballotapi.Close(ctx, cty.Organizer(), ballotName, account.BurnAccountID)
+	if len(closeChg.Result.Unrevealed[cty.MemberUser(2)]) != 1 {
+		t.Errorf("expecting one unrevealed commitment, got %v", form.SprintJSON(closeChg.Result))
+	}
+	if q := account.Get(ctx, cty.Gov(), cty.MemberAccountID(2)).Balance(account.PluralAsset).Quantity.Float64(); q != 8.0 {
+		t.Errorf("expecting deposit to be forfeited, got balance %v", q)
+	}
+}
+
+func TestSecretVoteNotInCommitMessage(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 1)
+
+	ballotName := ballotproto.ParseBallotID("secret/msg")
+	choices := []string{"secret-choice-x", "secret-choice-y"}
+
+	account.Issue(ctx, cty.Gov(), cty.MemberAccountID(0), account.H(account.PluralAsset, 10.0), "test")
+	ballotapi.Open(ctx, ballotio.QVPolicyName, cty.Organizer(), ballotName, account.NobodyAccountID, purpose.Unspecified, "", "secret", "secret ballot", choices, member.Everybody,
+		ballotapi.WithCommitReveal(ballotproto.CommitReveal{Deposit: 1.0, Unrevealed: ballotproto.UnrevealedPenalize}),
+	)
+	ballotapi.Vote(ctx, cty.MemberOwner(0), cty.Gov(), ballotName, ballotproto.OneElection(choices[1], 4.0))
+
+	// the ad lists all choices, so check for elections instead
+	if msg := publicHeadMessage(t, ctx, cty.MemberOwner(0)); strings.Contains(msg, `"vote_choice"`) {
+		t.Errorf("expecting the voter's public commit message not to disclose the committed choice, got %v", msg)
+	}
+}
+
+// publicHeadMessage returns the message of the latest commit in the public repo of a user.
+func publicHeadMessage(t *testing.T, ctx context.Context, addr id.OwnerAddress) string {
+	cloned := git.CloneOne(ctx, git.Address(addr.Public))
+	head, err := cloned.Repo().Head()
+	if err != nil {
+		t.Fatalf("reading head (%v)", err)
+	}
+	c, err := cloned.Repo().CommitObject(head.Hash())
+	if err != nil {
+		t.Fatalf("reading head commit (%v)", err)
+	}
+	return c.Message
+}
@@ -19,14 +19,14 @@ func TestBureau(t *testing.T) {
 	account.Issue(ctx, cty.Gov(), cty.MemberAccountID(0), account.H(account.PluralAsset, 3.0), "test")
 
 	// user 0 requests transfer to user 1
-	bureau.Transfer(ctx, cty.MemberOwner(0), cty.Gov(), member.User(""), cty.MemberUser(1), 1.0)
+	bureau.Transfer(ctx, cty.MemberOwner(0), cty.Gov(), member.User(""), cty.MemberUser(1), account.PluralAsset, 1.0)
 
 	// process request
 	bureau.Process(ctx, cty.Organizer(), member.Everybody)
 
 	// get resulting balances
-	u0 := account.Get(ctx, cty.Gov(), cty.MemberAccountID(0)).Balance(account.PluralAsset).Quantity
-	u1 := account.Get(ctx, cty.Gov(), cty.MemberAccountID(1)).Balance(account.PluralAsset).Quantity
+	u0 := account.Get(ctx, cty.Gov(), cty.MemberAccountID(0)).Balance(account.PluralAsset).Quantity.Float64()
+	u1 := account.Get(ctx, cty.Gov(), cty.MemberAccountID(1)).Balance(account.PluralAsset).Quantity.Float64()
 
 	if u0 != 2.0 {
 		t.Errorf("expecting 2, got %v", u0)
@@ -0,0 +1,94 @@
+package bureau
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gov4git/gov4git/v2/proto/account"
+	"github.com/gov4git/gov4git/v2/proto/bureau"
+	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/gov4git/v2/proto/motion/motionapi"
+	"github.com/gov4git/gov4git/v2/proto/motion/motionpolicies/zero"
+	"github.com/gov4git/gov4git/v2/proto/motion/motionproto"
+	"github.com/gov4git/gov4git/v2/runtime"
+	"github.com/gov4git/gov4git/v2/test"
+	"github.com/gov4git/lib4git/form"
+	"github.com/gov4git/lib4git/testutil"
+)
+
+func TestEscrow(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 2)
+
+	account.Issue(ctx, cty.Gov(), cty.MemberAccountID(0), account.H(account.PluralAsset, 10.0), "test")
+
+	// open two motions
+	accepted, rejected := motionproto.MotionID("1"), motionproto.MotionID("2")
+	for _, id := range []motionproto.MotionID{accepted, rejected} {
+		motionapi.OpenMotion(ctx, cty.Organizer(), id, motionproto.MotionConcernType, zero.ZeroPolicyName, cty.MemberUser(0), "bounty", "bounty", "https://"+id.String(), nil)
+	}
+
+	// user 0 escrows credits to user 1 under various conditions
+	now := time.Now()
+	escrows := []bureau.EscrowRequest{
+		{ID: "past", ReleaseAt: now.Add(-time.Minute), Amount: 1.0},
+		{ID: "future", ReleaseAt: now.Add(time.Hour), Amount: 1.0},
+		{ID: "accepted", Motion: accepted, Amount: 2.0},
+		{ID: "rejected", Motion: rejected, Amount: 2.0},
+		{ID: "pledge", Revocable: true, Amount: 3.0},
+	}
+	for _, req := range escrows {
+		req.ToUser = cty.MemberUser(1)
+		bureau.Escrow(ctx, cty.MemberOwner(0), cty.Gov(), req)
+	}
+	bureau.Process(ctx, cty.Organizer(), member.Everybody)
+
+	expectStatus := func(id bureau.EscrowID, status bureau.EscrowStatus) {
+		if e := bureau.GetEscrow(ctx, cty.Gov(), id); e.Status != status {
+			t.Errorf("expecting escrow %v to be %v, got %v", id, status, form.SprintJSON(e))
+		}
+	}
+	expectBalances := func(u0, u1 float64) {
+		if q := account.Get(ctx, cty.Gov(), cty.MemberAccountID(0)).Balance(account.PluralAsset).Quantity.Float64(); q != u0 {
+			t.Errorf("expecting user 0 balance %v, got %v", u0, q)
+		}
+		if q := account.Get(ctx, cty.Gov(), cty.MemberAccountID(1)).Balance(account.PluralAsset).Quantity.Float64(); q != u1 {
+			t.Errorf("expecting user 1 balance %v, got %v", u1, q)
+		}
+	}
+
+	// the past time-lock is released right away, everything else is held
+	expectStatus("past", bureau.EscrowReleased)
+	for _, id := range []bureau.EscrowID{"future", "accepted", "rejected", "pledge"} {
+		expectStatus(id, bureau.EscrowHeld)
+	}
+	expectBalances(1.0, 1.0)
+	if q := account.Get(ctx, cty.Gov(), bureau.EscrowAccountID("pledge")).Balance(account.PluralAsset).Quantity.Float64(); q != 3.0 {
+		t.Errorf("expecting 3 in the pledge escrow account, got %v", q)
+	}
+
+	// close the motions
+	motionapi.CloseMotion(ctx, cty.Organizer(), accepted, motionproto.Accept)
+	motionapi.CloseMotion(ctx, cty.Organizer(), rejected, motionproto.Reject)
+
+	// revoking a non-revocable escrow is refused, revoking the pledge refunds it
+	bureau.Settle(ctx, cty.MemberOwner(0), cty.Gov(), "", "future", true)
+	bureau.Settle(ctx, cty.MemberOwner(0), cty.Gov(), "", "pledge", true)
+	bureau.Process(ctx, cty.Organizer(), member.Everybody)
+
+	expectStatus("accepted", bureau.EscrowReleased)
+	expectStatus("rejected", bureau.EscrowRefunded)
+	expectStatus("pledge", bureau.EscrowRefunded)
+	expectStatus("future", bureau.EscrowHeld)
+	expectBalances(6.0, 3.0)
+
+	// the owner can release a held escrow early
+	bureau.Settle(ctx, cty.MemberOwner(0), cty.Gov(), "", "future", false)
+	bureau.Process(ctx, cty.Organizer(), member.Everybody)
+	expectStatus("future", bureau.EscrowReleased)
+	expectBalances(6.0, 4.0)
+
+	if report := account.Audit(ctx, cty.Gov()); !report.IsConsistent() {
+		t.Errorf("expecting consistent audit, got %v", form.SprintJSON(report))
+	}
+}
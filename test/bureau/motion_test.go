@@ -0,0 +1,103 @@
+package bureau
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gov4git/gov4git/v2/proto"
+	"github.com/gov4git/gov4git/v2/proto/account"
+	"github.com/gov4git/gov4git/v2/proto/bureau"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/id"
+	"github.com/gov4git/gov4git/v2/proto/mail"
+	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/gov4git/v2/proto/motion/motionapi"
+	"github.com/gov4git/gov4git/v2/proto/motion/motionpolicies/zero"
+	"github.com/gov4git/gov4git/v2/proto/motion/motionproto"
+	"github.com/gov4git/gov4git/v2/runtime"
+	"github.com/gov4git/gov4git/v2/test"
+	"github.com/gov4git/lib4git/form"
+	"github.com/gov4git/lib4git/must"
+	"github.com/gov4git/lib4git/testutil"
+)
+
+func TestBureauMotions(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 2)
+
+	const claims = motionproto.RefType("claims")
+
+	// user 0 opens a concern, user 1 opens a proposal, and an open request with an unknown policy is refused
+	bureau.Open(ctx, cty.MemberOwner(0), cty.Gov(), bureau.OpenRequest{ID: "bc", Type: motionproto.MotionConcernType, Policy: zero.ZeroPolicyName, Title: "concern"})
+	bureau.Open(ctx, cty.MemberOwner(1), cty.Gov(), bureau.OpenRequest{ID: "bp", Type: motionproto.MotionProposalType, Policy: zero.ZeroPolicyName, Title: "proposal"})
+	bureau.Open(ctx, cty.MemberOwner(1), cty.Gov(), bureau.OpenRequest{ID: "bx", Type: motionproto.MotionConcernType, Policy: "unknown", Title: "unknown"})
+	bureau.Process(ctx, cty.Organizer(), member.Everybody)
+
+	if m := motionapi.LookupMotion(ctx, cty.Gov(), "bc"); m.Author != cty.MemberUser(0) || m.Title != "concern" || m.Closed {
+		t.Errorf("unexpected concern %v", form.SprintJSON(m))
+	}
+	if m := motionapi.LookupMotion(ctx, cty.Gov(), "bp"); m.Author != cty.MemberUser(1) || !m.IsProposal() {
+		t.Errorf("unexpected proposal %v", form.SprintJSON(m))
+	}
+	if st := bureau.Status(ctx, cty.MemberOwner(1).Public, cty.Gov()); len(st) != 2 || st[1].Response.ErrorCode != bureau.ErrorNotFound {
+		t.Errorf("expecting unknown policy to be refused, got %v", form.SprintJSON(st))
+	}
+
+	// only the author of the proposal can reference the concern from it
+	bureau.Ref(ctx, cty.MemberOwner(0), cty.Gov(), "", "bp", "bc", claims, false)
+	bureau.Ref(ctx, cty.MemberOwner(1), cty.Gov(), "", "bp", "bc", claims, false)
+	bureau.Process(ctx, cty.Organizer(), member.Everybody)
+
+	if st := bureau.Status(ctx, cty.MemberOwner(0).Public, cty.Gov()); st[len(st)-1].Response.ErrorCode != bureau.ErrorUnauthorized {
+		t.Errorf("expecting reference by non-author to be refused, got %v", form.SprintJSON(st))
+	}
+	if m := motionapi.LookupMotion(ctx, cty.Gov(), "bc"); !m.ReferredBy("bp", claims) {
+		t.Errorf("expecting concern to be referenced by proposal, got %v", form.SprintJSON(m))
+	}
+
+	// remove the reference
+	bureau.Ref(ctx, cty.MemberOwner(1), cty.Gov(), "", "bp", "bc", claims, true)
+	bureau.Process(ctx, cty.Organizer(), member.Everybody)
+	if m := motionapi.LookupMotion(ctx, cty.Gov(), "bp"); m.RefersTo("bc", claims) {
+		t.Errorf("expecting reference to be removed, got %v", form.SprintJSON(m))
+	}
+
+	// user 0 tips user 1 with a memo
+	account.Issue(ctx, cty.Gov(), cty.MemberAccountID(0), account.H(account.PluralAsset, 5.0), "test")
+	bureau.Tip(ctx, cty.MemberOwner(0), cty.Gov(), "", cty.MemberUser(1), account.PluralAsset, 2.0, "thanks for the fix")
+	bureau.Process(ctx, cty.Organizer(), member.Everybody)
+
+	if q := account.Get(ctx, cty.Gov(), cty.MemberAccountID(1)).Balance(account.PluralAsset).Quantity.Float64(); q != 2.0 {
+		t.Errorf("expecting tipped balance 2, got %v", q)
+	}
+	stmt := account.Statement(ctx, cty.Gov(), cty.MemberAccountID(1), time.Time{}, time.Time{})
+	if n := len(stmt.Entries); n == 0 || !strings.Contains(stmt.Entries[n-1].Note, "thanks for the fix") {
+		t.Errorf("expecting the memo in the statement, got %v", form.SprintJSON(stmt))
+	}
+}
+
+// TestBureauMotionIDSquatting tests that users cannot open motions under the ids of future GitHub issues.
+func TestBureauMotionIDSquatting(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 2)
+
+	squat := bureau.OpenRequest{Author: cty.MemberUser(0), ID: "42", Type: motionproto.MotionConcernType, Policy: zero.ZeroPolicyName, Title: "squat"}
+	if err := must.Try(func() { bureau.Open(ctx, cty.MemberOwner(0), cty.Gov(), squat) }); err == nil {
+		t.Errorf("expecting open request with an issue number id to be refused")
+	}
+
+	// bypass the client check
+	userOwner := id.CloneOwner(ctx, cty.MemberOwner(0))
+	mail.Request_StageOnly(ctx, userOwner, gov.Clone(ctx, cty.Gov()).Tree(), bureau.BureauTopic, bureau.Request{Open: &squat})
+	proto.Commitf(ctx, userOwner.Public, "bureau_open", "Open motion.")
+	userOwner.Public.Push(ctx)
+	bureau.Process(ctx, cty.Organizer(), member.Everybody)
+
+	if st := bureau.Status(ctx, cty.MemberOwner(0).Public, cty.Gov()); len(st) != 1 || st[0].Response == nil || st[0].Response.ErrorCode != bureau.ErrorInvalid {
+		t.Errorf("expecting open request with an issue number id to be refused, got %v", form.SprintJSON(st))
+	}
+	if motionapi.IsMotion(ctx, cty.Gov(), "42") {
+		t.Errorf("expecting no motion 42")
+	}
+}
@@ -0,0 +1,103 @@
+package bureau
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gov4git/gov4git/v2/proto"
+	"github.com/gov4git/gov4git/v2/proto/account"
+	"github.com/gov4git/gov4git/v2/proto/bureau"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/mail"
+	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/gov4git/v2/runtime"
+	"github.com/gov4git/gov4git/v2/test"
+	"github.com/gov4git/lib4git/form"
+	"github.com/gov4git/lib4git/git"
+	"github.com/gov4git/lib4git/testutil"
+)
+
+func TestBureauStatus(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 2)
+
+	account.Issue(ctx, cty.Gov(), cty.MemberAccountID(0), account.H(account.PluralAsset, 3.0), "test")
+
+	// no requests yet
+	if st := bureau.Status(ctx, cty.MemberOwner(0).Public, cty.Gov()); len(st) != 0 {
+		t.Fatalf("expecting no requests, got %v", form.SprintJSON(st))
+	}
+
+	// user 0 makes a valid and two invalid transfer requests
+	bureau.Transfer(ctx, cty.MemberOwner(0), cty.Gov(), member.User(""), cty.MemberUser(1), account.PluralAsset, 1.0)
+	bureau.Transfer(ctx, cty.MemberOwner(0), cty.Gov(), member.User(""), cty.MemberUser(1), account.PluralAsset, 5.0)
+	bureau.Transfer(ctx, cty.MemberOwner(0), cty.Gov(), member.User(""), member.User("nobody"), account.PluralAsset, 1.0)
+
+	// requests are pending until processed
+	st := bureau.Status(ctx, cty.MemberOwner(0).Public, cty.Gov())
+	if len(st) != 3 {
+		t.Fatalf("expecting 3 requests, got %v", form.SprintJSON(st))
+	}
+	for _, s := range st {
+		if !s.Pending || s.Response != nil {
+			t.Errorf("expecting pending request, got %v", form.SprintJSON(s))
+		}
+	}
+
+	// process and verify responses
+	chg := bureau.Process(ctx, cty.Organizer(), member.Everybody)
+	if len(chg.Result) != 3 {
+		t.Errorf("expecting 3 processed requests, got %v", form.SprintJSON(chg.Result))
+	}
+
+	st = bureau.Status(ctx, cty.MemberOwner(0).Public, cty.Gov())
+	if len(st) != 3 {
+		t.Fatalf("expecting 3 requests, got %v", form.SprintJSON(st))
+	}
+	if r := st[0].Response; st[0].Pending || r == nil || !r.OK || r.Balance == nil || r.Balance.Quantity.Float64() != 2.0 {
+		t.Errorf("expecting successful transfer leaving balance 2, got %v", form.SprintJSON(st[0]))
+	}
+	if r := st[1].Response; r == nil || r.OK || r.ErrorCode != bureau.ErrorInsufficientFunds {
+		t.Errorf("expecting insufficient funds, got %v", form.SprintJSON(st[1]))
+	}
+	if r := st[2].Response; r == nil || r.OK || r.ErrorCode != bureau.ErrorNotFound {
+		t.Errorf("expecting recipient not found, got %v", form.SprintJSON(st[2]))
+	}
+}
+
+// TestBureauStatusLegacy tests that responses written before responses recorded outcomes, which echo the request, are reported as legacy.
+func TestBureauStatusLegacy(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 2)
+
+	account.Issue(ctx, cty.Gov(), cty.MemberAccountID(0), account.H(account.PluralAsset, 3.0), "test")
+	bureau.Transfer(ctx, cty.MemberOwner(0), cty.Gov(), member.User(""), cty.MemberUser(1), account.PluralAsset, 1.0)
+
+	// respond the way the community did before responses recorded outcomes
+	govOwner := gov.CloneOwner(ctx, cty.Organizer())
+	var echo mail.Responder[bureau.Request, bureau.Request] = func(
+		ctx context.Context,
+		_ mail.SeqNo,
+		req bureau.Request,
+	) (bureau.Request, error) {
+		return req, nil
+	}
+	mail.Respond_StageOnly[bureau.Request, bureau.Request](
+		ctx,
+		govOwner.IDOwnerCloned(),
+		cty.MemberOwner(0).Public,
+		git.CloneOne(ctx, git.Address(cty.MemberOwner(0).Public)).Tree(),
+		bureau.BureauTopic,
+		echo,
+	)
+	proto.Commitf(ctx, govOwner.PublicClone(), "legacy_respond", "respond to bureau requests")
+	govOwner.Public.Push(ctx)
+
+	st := bureau.Status(ctx, cty.MemberOwner(0).Public, cty.Gov())
+	if len(st) != 1 {
+		t.Fatalf("expecting 1 request, got %v", form.SprintJSON(st))
+	}
+	if st[0].Pending || !st[0].Legacy || st[0].Response != nil {
+		t.Errorf("expecting legacy request without response, got %v", form.SprintJSON(st[0]))
+	}
+}
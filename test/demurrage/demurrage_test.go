@@ -0,0 +1,69 @@
+package demurrage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gov4git/gov4git/v2/proto/account"
+	"github.com/gov4git/gov4git/v2/proto/demurrage"
+	"github.com/gov4git/gov4git/v2/proto/history/metric"
+	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/gov4git/v2/proto/metrics"
+	"github.com/gov4git/gov4git/v2/proto/motion/motionpolicies/pmp_0"
+	"github.com/gov4git/gov4git/v2/runtime"
+	"github.com/gov4git/gov4git/v2/test"
+	"github.com/gov4git/lib4git/form"
+	"github.com/gov4git/lib4git/testutil"
+)
+
+func TestDemurrage(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 2)
+
+	account.Issue(ctx, cty.Gov(), cty.MemberAccountID(0), account.H(account.PluralAsset, 30.0), "test")
+	account.Issue(ctx, cty.Gov(), cty.MemberAccountID(1), account.H(account.PluralAsset, 5.0), "test")
+	pool0 := account.Get(ctx, cty.Gov(), pmp_0.MatchingPoolAccountID).Balance(account.PluralAsset).Quantity
+
+	demurrage.Set(ctx, cty.Gov(), demurrage.Policy{
+		Asset:       account.PluralAsset,
+		Group:       member.Everybody,
+		Rate:        0.5,
+		Period:      time.Hour,
+		Threshold:   account.Q(10.0),
+		Destination: pmp_0.MatchingPoolAccountID,
+	})
+
+	// nothing is due within the first period
+	if chg := demurrage.Apply(ctx, cty.Gov(), time.Now().Add(30*time.Minute)); len(chg.Result) != 0 {
+		t.Fatalf("expecting no demurrage, got %v", form.SprintJSON(chg.Result))
+	}
+
+	// two periods decay 75% of the balance above the threshold
+	chg := demurrage.Apply(ctx, cty.Gov(), time.Now().Add(2*time.Hour+time.Minute))
+	if len(chg.Result) != 1 || chg.Result[0].Periods != 2 || len(chg.Result[0].Decayed) != 1 {
+		t.Fatalf("unexpected demurrage %v", form.SprintJSON(chg.Result))
+	}
+	if q := account.Get(ctx, cty.Gov(), cty.MemberAccountID(0)).Balance(account.PluralAsset).Quantity; q != account.Q(15.0) {
+		t.Errorf("expecting balance 15, got %v", q)
+	}
+	if q := account.Get(ctx, cty.Gov(), cty.MemberAccountID(1)).Balance(account.PluralAsset).Quantity; q != account.Q(5.0) {
+		t.Errorf("expecting balance under the threshold to not decay, got %v", q)
+	}
+	if q := account.Get(ctx, cty.Gov(), pmp_0.MatchingPoolAccountID).Balance(account.PluralAsset).Quantity; q != pool0.Add(account.Q(15.0)) {
+		t.Errorf("expecting decayed credits in the matching pool, got %v", q)
+	}
+
+	// decay is recorded in the metrics and the ledger
+	entries := metric.List(ctx, cty.Gov())
+	series := metrics.ComputeSeries(entries, time.Now().AddDate(0, 0, -2), time.Now().AddDate(0, 0, 2))
+	if d := series.DailyCreditsDecayed.Total(); d != 15.0 {
+		t.Errorf("expecting 15 decayed credits in metrics, got %v", d)
+	}
+	if report := account.Audit(ctx, cty.Gov()); !report.IsConsistent() {
+		t.Errorf("expecting consistent accounts, got %v", form.SprintJSON(report))
+	}
+	st := account.Statement(ctx, cty.Gov(), cty.MemberAccountID(0), time.Time{}, time.Time{})
+	if last := st.Entries[len(st.Entries)-1]; last.Op != account.StatementDecay || last.Debit == nil || last.Debit.Quantity != account.Q(15.0) {
+		t.Errorf("expecting decay in statement, got %v", form.SprintJSON(last))
+	}
+}
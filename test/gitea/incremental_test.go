@@ -0,0 +1,132 @@
+package gitea
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gov4git/gov4git/v2/gitea"
+	govgh "github.com/gov4git/gov4git/v2/github"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/motion/motionapi"
+	"github.com/gov4git/gov4git/v2/proto/motion/motionproto"
+	"github.com/gov4git/gov4git/v2/runtime"
+	"github.com/gov4git/gov4git/v2/test"
+	"github.com/gov4git/lib4git/form"
+	"github.com/gov4git/lib4git/testutil"
+)
+
+func TestIncrementalSync(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 2)
+
+	stub := &stubGitea{comments: map[string][]map[string]any{}, closed: map[string]bool{}}
+	srv := httptest.NewServer(stub)
+	defer srv.Close()
+
+	t0 := time.Now().UTC().Truncate(time.Second).Add(-time.Hour)
+	managed := []map[string]any{{"name": govgh.IssueIsManagedLabel}}
+	stub.issues = []map[string]any{
+		{
+			"number": 1, "title": "concern 1", "state": "open", "labels": managed, "updated_at": t0,
+			"body": "addresses " + srv.URL + "/owner/repo/issues/2",
+		},
+		{
+			"number": 2, "title": "concern 2", "state": "open", "labels": managed, "updated_at": t0,
+		},
+	}
+	tr := gitea.NewTracker(gitea.Repo{BaseURL: srv.URL, Owner: "owner", Name: "repo"}, "", srv.Client())
+	cloned := gov.CloneOwner(ctx, cty.Organizer())
+
+	// full sync
+	full := govgh.SyncManagedIssuesSince_StageOnly(ctx, tr, cty.Organizer(), cloned, time.Time{})
+	if len(full.Opened) != 2 || !full.Cursor.Equal(t0) {
+		t.Fatalf("unexpected full sync %v", form.SprintJSON(full))
+	}
+
+	// issue 1 drops its reference, issue 2 is retitled without being marked as updated
+	t1 := t0.Add(time.Minute)
+	stub.issues[0]["body"], stub.issues[0]["updated_at"] = "", t1
+	stub.issues[1]["title"] = "concern 2 (stale)"
+
+	// incremental sync only picks up issue 1
+	inc := govgh.SyncManagedIssuesSince_StageOnly(ctx, tr, cty.Organizer(), cloned, full.Cursor.Add(time.Second))
+	if len(inc.IssuesCausingChange) != 1 || inc.IssuesCausingChange[0].Number != 1 || !inc.Cursor.Equal(t1) {
+		t.Errorf("unexpected incremental sync %v", form.SprintJSON(inc))
+	}
+	if m := motionapi.LookupMotion_Local(ctx, cloned.PublicClone(), "1"); m.RefersTo("2", motionproto.RefType("addresses")) {
+		t.Errorf("expecting reference to be removed, got %v", form.SprintJSON(m))
+	}
+	if m := motionapi.LookupMotion_Local(ctx, cloned.PublicClone(), "2"); m.Title != "concern 2" {
+		t.Errorf("expecting issue 2 to be skipped, got %v", form.SprintJSON(m))
+	}
+
+	// full reconciliation picks up issue 2
+	govgh.SyncManagedIssues_StageOnly(ctx, tr, cty.Organizer(), cloned)
+	if m := motionapi.LookupMotion_Local(ctx, cloned.PublicClone(), "2"); m.Title != "concern 2 (stale)" {
+		t.Errorf("expecting issue 2 to be synced, got %v", form.SprintJSON(m))
+	}
+
+	// issue 2 is deleted, which an incremental sync cannot observe
+	stub.issues = stub.issues[:1]
+	govgh.SyncManagedIssuesSince_StageOnly(ctx, tr, cty.Organizer(), cloned, full.Cursor.Add(time.Hour))
+	if m := motionapi.LookupMotion_Local(ctx, cloned.PublicClone(), "2"); m.Closed {
+		t.Errorf("expecting incremental sync to leave motion 2 open, got %v", form.SprintJSON(m))
+	}
+
+	// full reconciliation cancels the motion of the deleted issue, without posting notices to it
+	rec := govgh.SyncManagedIssues_StageOnly(ctx, tr, cty.Organizer(), cloned)
+	if m := motionapi.LookupMotion_Local(ctx, cloned.PublicClone(), "2"); !m.Cancelled || !rec.Cancelled["2"] {
+		t.Errorf("expecting motion 2 to be cancelled, got %v", form.SprintJSON(m))
+	}
+	govgh.DisplayNotices_StageOnly(ctx, tr, cloned.PublicClone())
+	if n := len(stub.comments["2"]); n != 0 {
+		t.Errorf("expecting no notices posted to the deleted issue, got %v", n)
+	}
+}
+
+// TestFullSyncRemovesRefsOfDeletedIssues tests that refs of deleted issues are kept by incremental syncs, and removed by full syncs.
+func TestFullSyncRemovesRefsOfDeletedIssues(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 2)
+
+	stub := &stubGitea{comments: map[string][]map[string]any{}, closed: map[string]bool{}}
+	srv := httptest.NewServer(stub)
+	defer srv.Close()
+
+	t0 := time.Now().UTC().Truncate(time.Second).Add(-time.Hour)
+	managed := []map[string]any{{"name": govgh.IssueIsManagedLabel}}
+	stub.issues = []map[string]any{
+		{
+			"number": 1, "title": "concern 1", "state": "open", "labels": managed, "updated_at": t0,
+		},
+		{
+			"number": 2, "title": "concern 2", "state": "open", "labels": managed, "updated_at": t0,
+			"body": "addresses " + srv.URL + "/owner/repo/issues/1",
+		},
+	}
+	tr := gitea.NewTracker(gitea.Repo{BaseURL: srv.URL, Owner: "owner", Name: "repo"}, "", srv.Client())
+	cloned := gov.CloneOwner(ctx, cty.Organizer())
+
+	full := govgh.SyncManagedIssuesSince_StageOnly(ctx, tr, cty.Organizer(), cloned, time.Time{})
+	ref := motionproto.Ref{From: "2", To: "1", Type: motionproto.RefType("addresses")}
+	if !full.AddedRefs[ref] {
+		t.Fatalf("expecting ref to be added, got %v", form.SprintJSON(full))
+	}
+
+	// issue 2 is deleted; an incremental sync leaves its refs alone
+	stub.issues = stub.issues[:1]
+	inc := govgh.SyncManagedIssuesSince_StageOnly(ctx, tr, cty.Organizer(), cloned, full.Cursor.Add(time.Second))
+	if len(inc.RemovedRefs) != 0 {
+		t.Errorf("expecting no refs removed, got %v", form.SprintJSON(inc.RemovedRefs))
+	}
+
+	// a full sync removes them
+	rec := govgh.SyncManagedIssues_StageOnly(ctx, tr, cty.Organizer(), cloned)
+	if !rec.RemovedRefs[ref] {
+		t.Errorf("expecting ref to be removed, got %v", form.SprintJSON(rec))
+	}
+	if m := motionapi.LookupMotion_Local(ctx, cloned.PublicClone(), "2"); m.RefersTo("1", motionproto.RefType("addresses")) {
+		t.Errorf("expecting motion 2 not to refer to motion 1, got %v", form.SprintJSON(m))
+	}
+}
@@ -0,0 +1,329 @@
+package gitea
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gov4git/gov4git/v2/gitea"
+	govgh "github.com/gov4git/gov4git/v2/github"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/id"
+	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/gov4git/v2/proto/motion/motionapi"
+	"github.com/gov4git/gov4git/v2/proto/motion/motionproto"
+	"github.com/gov4git/gov4git/v2/runtime"
+	"github.com/gov4git/gov4git/v2/test"
+	"github.com/gov4git/lib4git/form"
+	"github.com/gov4git/lib4git/git"
+	"github.com/gov4git/lib4git/testutil"
+)
+
+// stubGitea is a minimal, in-memory imitation of the Gitea issues API.
+type stubGitea struct {
+	sync.Mutex
+	issues    []map[string]any
+	comments  map[string][]map[string]any // issue number -> comments
+	perms     map[string]string           // collaborator -> permission
+	closed    map[string]bool             // issue numbers closed through the api
+	labels    []map[string]any
+	owner     string   // login of the repo owner
+	orgOwners []string // members of the owners team, if the repo owner is an organization
+}
+
+func (x *stubGitea) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	x.Lock()
+	defer x.Unlock()
+
+	if strings.HasPrefix(r.URL.Path, "/api/v1/orgs/") || strings.HasPrefix(r.URL.Path, "/api/v1/teams/") || r.URL.Path == "/api/v1/repos/owner/repo" {
+		x.serveOwners(w, r)
+		return
+	}
+
+	path := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/v1/repos/owner/repo/"), "/")
+	var resp any
+	switch {
+	case r.Method == http.MethodGet && len(path) == 1 && path[0] == "issues":
+		list := []map[string]any{}
+		for _, issue := range x.issues {
+			if hasLabels(issue, r.URL.Query().Get("labels")) && updatedSince(issue, r.URL.Query().Get("since")) {
+				list = append(list, issue)
+			}
+		}
+		resp = page(r, list)
+	case r.Method == http.MethodGet && len(path) == 2 && path[0] == "issues":
+		for _, issue := range x.issues {
+			if fmt.Sprint(issue["number"]) == path[1] {
+				resp = issue
+			}
+		}
+		if resp == nil {
+			http.NotFound(w, r)
+			return
+		}
+	case r.Method == http.MethodGet && len(path) == 3 && path[2] == "comments":
+		resp = page(r, x.comments[path[1]])
+	case r.Method == http.MethodPost && len(path) == 3 && path[2] == "comments":
+		var c map[string]any
+		json.NewDecoder(r.Body).Decode(&c)
+		x.comments[path[1]] = append(x.comments[path[1]], c)
+		resp = c
+	case r.Method == http.MethodPatch && len(path) == 2 && path[0] == "issues":
+		x.closed[path[1]] = true
+		resp = map[string]any{}
+	case r.Method == http.MethodGet && len(path) == 1 && path[0] == "collaborators":
+		logins := []string{}
+		for login := range x.perms {
+			logins = append(logins, login)
+		}
+		slices.Sort(logins)
+		resp = page(r, users(logins))
+	case r.Method == http.MethodPost && len(path) == 1 && path[0] == "issues":
+		var issue map[string]any
+		json.NewDecoder(r.Body).Decode(&issue)
+		issue["number"] = len(x.issues) + 1
+		x.issues = append(x.issues, issue)
+		resp = issue
+	case r.Method == http.MethodGet && len(path) == 1 && path[0] == "labels":
+		resp = page(r, x.labels)
+	case r.Method == http.MethodPost && len(path) == 1 && path[0] == "labels":
+		var l map[string]any
+		json.NewDecoder(r.Body).Decode(&l)
+		l["id"] = len(x.labels) + 1
+		x.labels = append(x.labels, l)
+		resp = l
+	case r.Method == http.MethodGet && len(path) == 3 && path[0] == "collaborators":
+		resp = map[string]any{"permission": x.perms[path[1]]}
+	default:
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// serveOwners serves the repo, and the teams of the repo owner, if it is an organization.
+func (x *stubGitea) serveOwners(w http.ResponseWriter, r *http.Request) {
+	isOrg := x.orgOwners != nil
+	var resp any
+	switch r.URL.Path {
+	case "/api/v1/repos/owner/repo":
+		resp = map[string]any{"owner": map[string]any{"login": x.owner}}
+	case "/api/v1/orgs/" + x.owner:
+		if !isOrg {
+			http.NotFound(w, r)
+			return
+		}
+		resp = map[string]any{"username": x.owner}
+	case "/api/v1/orgs/" + x.owner + "/teams":
+		resp = page(r, []map[string]any{
+			{"id": 1, "name": "Owners", "permission": "owner"},
+			{"id": 2, "name": "Developers", "permission": "write"},
+		})
+	case "/api/v1/teams/1/members":
+		resp = page(r, users(x.orgOwners))
+	case "/api/v1/teams/2/members":
+		resp = page(r, users([]string{"developer"}))
+	default:
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+func users(logins []string) []map[string]any {
+	r := []map[string]any{}
+	for _, login := range logins {
+		r = append(r, map[string]any{"login": login})
+	}
+	return r
+}
+
+// page returns the page of a list requested by the page and limit query parameters.
+// Like Gitea, it returns the first page of a default size when they are absent.
+func page(r *http.Request, list []map[string]any) []map[string]any {
+	p, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	if p < 1 {
+		p = 1
+	}
+	if limit < 1 {
+		limit = 30
+	}
+	start, end := min((p-1)*limit, len(list)), min(p*limit, len(list))
+	return append([]map[string]any{}, list[start:end]...)
+}
+
+func hasLabels(issue map[string]any, labels string) bool {
+	if labels == "" {
+		return true
+	}
+	for _, want := range strings.Split(labels, ",") {
+		found := false
+		for _, l := range issue["labels"].([]map[string]any) {
+			if l["name"] == want {
+				found = true
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func updatedSince(issue map[string]any, since string) bool {
+	if since == "" {
+		return true
+	}
+	s, _ := time.Parse(time.RFC3339, since)
+	u, _ := issue["updated_at"].(time.Time)
+	return !u.Before(s)
+}
+
+func TestGiteaTracker(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 2)
+
+	applicantID := id.NewTestID(ctx, t, git.MainBranch, true)
+	id.Init(ctx, applicantID.OwnerAddress())
+
+	stub := &stubGitea{
+		comments: map[string][]map[string]any{
+			"3": {{"user": map[string]any{"login": "Organizer"}, "body": "Approve."}},
+		},
+		perms:  map[string]string{"organizer": "admin", "helper": "write"},
+		closed: map[string]bool{},
+		owner:  "organizer",
+	}
+	srv := httptest.NewServer(stub)
+	defer srv.Close()
+
+	managed := []map[string]any{{"name": govgh.IssueIsManagedLabel}}
+	stub.issues = []map[string]any{
+		{
+			"number": 1, "title": "concern 1", "state": "open", "labels": managed,
+			"html_url": srv.URL + "/owner/repo/issues/1",
+			"body":     "addresses " + srv.URL + "/owner/repo/issues/2",
+			"user":     map[string]any{"login": string(cty.MemberUser(0))},
+		},
+		{
+			"number": 2, "title": "concern 2", "state": "open", "labels": managed,
+			"html_url": srv.URL + "/owner/repo/issues/2",
+			"user":     map[string]any{"login": string(cty.MemberUser(1))},
+		},
+		{
+			"number": 3, "title": "join", "state": "open", "labels": []map[string]any{}, "comments": 1,
+			"body": fmt.Sprintf("### Your public repo\n\n%v\n\n### Your public branch\n\n%v\n\n### Your email (optional)\n\n%v",
+				applicantID.Public.Dir(), git.MainBranch, "test@test"),
+			"user": map[string]any{"login": "applicant"},
+		},
+	}
+
+	tr := gitea.NewTracker(gitea.Repo{BaseURL: srv.URL, Owner: "owner", Name: "repo"}, "token", srv.Client())
+	cloned := gov.CloneOwner(ctx, cty.Organizer())
+
+	// maintainers
+	maintainers := tr.ListMaintainers(ctx)
+	if len(maintainers) != 1 || maintainers[0] != "organizer" {
+		t.Fatalf("expecting organizer as the only maintainer, got %v", maintainers)
+	}
+
+	// fetch a single issue
+	if issue := tr.GetIssue(ctx, 2); issue.Title != "concern 2" || issue.Author != string(cty.MemberUser(1)) {
+		t.Errorf("unexpected issue %v", form.SprintJSON(issue))
+	}
+
+	// sync managed issues
+	chg := govgh.SyncManagedIssues_StageOnly(ctx, tr, cty.Organizer(), cloned)
+	if len(chg.Opened) != 2 {
+		t.Errorf("expecting 2 opened motions, got %v", form.SprintJSON(chg))
+	}
+	m1 := motionapi.LookupMotion_Local(ctx, cloned.PublicClone(), "1")
+	if m1.Author != cty.MemberUser(0) || !m1.RefersTo("2", motionproto.RefType("addresses")) {
+		t.Errorf("unexpected motion %v", form.SprintJSON(m1))
+	}
+
+	// process join requests
+	report := govgh.ProcessJoinRequestIssues_StageOnly(ctx, tr, cty.Organizer(), cloned, maintainers, true)
+	if len(report.Joined) != 1 || report.Joined[0] != "applicant" {
+		t.Errorf("expecting applicant to join, got %v", form.SprintJSON(report))
+	}
+	if !member.IsUser_Local(ctx, cloned.PublicClone(), "applicant") {
+		t.Errorf("expecting applicant to be a member")
+	}
+	if !stub.closed["3"] || len(stub.comments["3"]) != 2 {
+		t.Errorf("expecting join request to be answered and closed")
+	}
+
+	// display notices
+	govgh.DisplayNotices_StageOnly(ctx, tr, cloned.PublicClone())
+	if len(stub.comments["1"]) == 0 {
+		t.Errorf("expecting notices posted on issue 1")
+	}
+}
+
+func TestGiteaMaintainers(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+
+	// more collaborators than fit in a page, with an admin on the second page
+	perms := map[string]string{}
+	for i := 0; i < 70; i++ {
+		perms[fmt.Sprintf("collaborator%02d", i)] = "read"
+	}
+	perms["collaborator65"] = "admin"
+
+	// repo owned by a user
+	stub := &stubGitea{perms: perms, owner: "Founder"}
+	srv := httptest.NewServer(stub)
+	defer srv.Close()
+	tr := gitea.NewTracker(gitea.Repo{BaseURL: srv.URL, Owner: "owner", Name: "repo"}, "", srv.Client())
+
+	if m := tr.ListMaintainers(ctx); !slices.Equal(m, []string{"collaborator65", "founder"}) {
+		t.Errorf("expecting repo owner and admin collaborator, got %v", m)
+	}
+
+	// repo owned by an organization
+	stub.owner, stub.orgOwners = "org", []string{"boss", "collaborator65"}
+	if m := tr.ListMaintainers(ctx); !slices.Equal(m, []string{"boss", "collaborator65"}) {
+		t.Errorf("expecting organization owners and admin collaborator, got %v", m)
+	}
+
+	// comments span pages
+	stub.comments = map[string][]map[string]any{"1": {}}
+	for i := 0; i < 120; i++ {
+		stub.comments["1"] = append(stub.comments["1"], map[string]any{"user": map[string]any{"login": "commenter"}, "body": strconv.Itoa(i)})
+	}
+	if cs := tr.ListComments(ctx, 1); len(cs) != 120 || cs[119].Body != "119" {
+		t.Errorf("expecting 120 comments, got %v", len(cs))
+	}
+}
+
+func TestGiteaUpsertIssueLabels(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+
+	// more labels than fit in a page, with the wanted label on the second page
+	stub := &stubGitea{}
+	for i := 0; i < 70; i++ {
+		stub.labels = append(stub.labels, map[string]any{"id": i + 1, "name": fmt.Sprintf("label%02d", i)})
+	}
+	srv := httptest.NewServer(stub)
+	defer srv.Close()
+	tr := gitea.NewTracker(gitea.Repo{BaseURL: srv.URL, Owner: "owner", Name: "repo"}, "", srv.Client())
+
+	tr.UpsertIssue(ctx, "label65", "title", "body")
+	if len(stub.labels) != 70 {
+		t.Errorf("expecting no new labels, got %v", len(stub.labels))
+	}
+	if len(stub.issues) != 1 || fmt.Sprint(stub.issues[0]["labels"]) != "[66]" {
+		t.Errorf("expecting one issue with the existing label, got %v", form.SprintJSON(stub.issues))
+	}
+}
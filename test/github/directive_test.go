@@ -88,11 +88,11 @@ func TestDirective(t *testing.T) {
 	}
 	fmt.Println(form.SprintJSON(chg.Result))
 
-	c1 := account.Get(ctx, cty.Gov(), cty.MemberAccountID(0)).Balance(account.PluralAsset).Quantity
+	c1 := account.Get(ctx, cty.Gov(), cty.MemberAccountID(0)).Balance(account.PluralAsset).Quantity.Float64()
 	if c1 != 10.0 {
 		t.Errorf("expecting %v, got %v", 10.0, c1)
 	}
-	c2 := account.Get(ctx, cty.Gov(), cty.MemberAccountID(1)).Balance(account.PluralAsset).Quantity
+	c2 := account.Get(ctx, cty.Gov(), cty.MemberAccountID(1)).Balance(account.PluralAsset).Quantity.Float64()
 	if c2 != 10.0 {
 		t.Errorf("expecting %v, got %v", 10.0, c2)
 	}
@@ -0,0 +1,168 @@
+package github
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"sync"
+	"testing"
+
+	"github.com/google/go-github/v58/github"
+	govgh "github.com/gov4git/gov4git/v2/github"
+	"github.com/gov4git/gov4git/v2/proto/account"
+	"github.com/gov4git/gov4git/v2/proto/motion/motionapi"
+	"github.com/gov4git/gov4git/v2/runtime"
+	"github.com/gov4git/gov4git/v2/test"
+	"github.com/gov4git/lib4git/testutil"
+	"github.com/migueleliasweb/go-github-mock/src/mock"
+)
+
+func TestWebhook(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 2)
+
+	// the tracker's current state of the issues, by number
+	issues := map[string]*github.Issue{}
+	var issuesLk sync.Mutex
+	setIssue := func(issue *github.Issue) {
+		issuesLk.Lock()
+		defer issuesLk.Unlock()
+		issues[fmt.Sprint(issue.GetNumber())] = issue
+	}
+	mockedHTTPClient := mock.NewMockedHTTPClient(
+		mock.WithRequestMatchHandler(mock.GetReposIssuesByOwnerByRepoByIssueNumber,
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				issuesLk.Lock()
+				defer issuesLk.Unlock()
+				issue, ok := issues[path.Base(r.URL.Path)]
+				if !ok {
+					mock.WriteError(w, http.StatusNotFound, "Not Found")
+					return
+				}
+				w.Write(mock.MustMarshal(issue))
+			}),
+		),
+		mock.WithRequestMatch(mock.GetReposCollaboratorsByOwnerByRepo,
+			[]*github.User{{Login: github.String("organizer"), Permissions: map[string]bool{"admin": true}}},
+		),
+		mock.WithRequestMatch(mock.PostReposIssuesCommentsByOwnerByRepoByIssueNumber,
+			&github.IssueComment{},
+		),
+		mock.WithRequestMatch(mock.PatchReposIssuesByOwnerByRepoByIssueNumber,
+			&github.Issue{},
+		),
+	)
+	tr := govgh.NewTracker(ctx, govgh.Repo{Owner: "owner1", Name: "repo1"}, github.NewClient(mockedHTTPClient))
+	secret := []byte("secret")
+	srv := httptest.NewServer(govgh.NewWebhookHandler(ctx, tr, cty.Organizer(), secret))
+	defer srv.Close()
+
+	// labelling an issue as managed opens a motion
+	managed := &github.IssuesEvent{
+		Action: github.String("labeled"),
+		Issue: &github.Issue{
+			Number: github.Int(1),
+			Title:  github.String("concern"),
+			State:  github.String("open"),
+			Labels: []*github.Label{{Name: github.String(govgh.IssueIsManagedLabel)}},
+			User:   &github.User{Login: github.String(string(cty.MemberUser(0)))},
+		},
+	}
+	setIssue(managed.Issue)
+	var report govgh.WebhookReport
+	if status := deliver(t, srv.URL, "issues", managed, secret, &report); status != http.StatusOK {
+		t.Fatalf("expecting delivery to succeed, got status %d", status)
+	}
+	if report.Issue != 1 || len(report.Synced.Opened) != 1 {
+		t.Errorf("expecting motion for issue 1 to be opened, got %v", report)
+	}
+	if m := motionapi.LookupMotion(ctx, cty.Gov(), "1"); m.Title != "concern" {
+		t.Errorf("expecting motion titled concern, got %v", m.Title)
+	}
+
+	// a directive is processed when its issue is opened
+	directive := &github.IssuesEvent{
+		Action: github.String("opened"),
+		Issue: &github.Issue{
+			Number: github.Int(2),
+			Title:  github.String("directive"),
+			State:  github.String("open"),
+			Labels: []*github.Label{{Name: github.String(govgh.DirectiveLabel)}},
+			Body:   github.String(fmt.Sprintf("issue 30 credits to @%v", cty.MemberUser(0))),
+			User:   &github.User{Login: github.String("organizer")},
+		},
+	}
+	setIssue(directive.Issue)
+	report = govgh.WebhookReport{}
+	if status := deliver(t, srv.URL, "issues", directive, secret, &report); status != http.StatusOK {
+		t.Fatalf("expecting delivery to succeed, got status %d", status)
+	}
+	if len(report.Directives) != 1 || report.Directives[0].Success == nil {
+		t.Errorf("expecting directive to succeed, got %v", report)
+	}
+	if c := account.Get(ctx, cty.Gov(), cty.MemberAccountID(0)).Balance(account.PluralAsset).Quantity.Float64(); c != 30.0 {
+		t.Errorf("expecting %v, got %v", 30.0, c)
+	}
+
+	// a redelivered event does not overwrite the motion with its stale snapshot of the issue
+	setIssue(&github.Issue{
+		Number: github.Int(1),
+		Title:  github.String("renamed concern"),
+		State:  github.String("open"),
+		Labels: []*github.Label{{Name: github.String(govgh.IssueIsManagedLabel)}},
+		User:   &github.User{Login: github.String(string(cty.MemberUser(0)))},
+	})
+	if status := deliver(t, srv.URL, "issues", managed, secret, nil); status != http.StatusOK {
+		t.Fatalf("expecting delivery to succeed, got status %d", status)
+	}
+	if m := motionapi.LookupMotion(ctx, cty.Gov(), "1"); m.Title != "renamed concern" {
+		t.Errorf("expecting motion titled renamed concern, got %v", m.Title)
+	}
+
+	// an event for a deleted issue is acknowledged, and cancels the motion of the issue
+	issuesLk.Lock()
+	delete(issues, "1")
+	issuesLk.Unlock()
+	deleted := &github.IssuesEvent{Action: github.String("deleted"), Issue: managed.Issue}
+	report = govgh.WebhookReport{}
+	if status := deliver(t, srv.URL, "issues", deleted, secret, &report); status != http.StatusOK {
+		t.Fatalf("expecting delivery to succeed, got status %d", status)
+	}
+	if !report.Deleted || !report.Synced.Cancelled["1"] {
+		t.Errorf("expecting motion for issue 1 to be cancelled, got %v", report)
+	}
+	if m := motionapi.LookupMotion(ctx, cty.Gov(), "1"); !m.Cancelled {
+		t.Errorf("expecting motion for deleted issue to be cancelled")
+	}
+
+	// deliveries with an invalid signature are rejected
+	if status := deliver(t, srv.URL, "issues", managed, []byte("wrong"), nil); status != http.StatusUnauthorized {
+		t.Errorf("expecting unauthorized, got status %d", status)
+	}
+}
+
+func deliver(t *testing.T, url string, eventType string, event any, secret []byte, result any) int {
+	payload, _ := json.Marshal(event)
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+
+	req, _ := http.NewRequest(http.MethodPost, url, bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(github.EventTypeHeader, eventType)
+	req.Header.Set(github.SHA256SignatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if result != nil {
+		json.NewDecoder(resp.Body).Decode(result)
+	}
+	return resp.StatusCode
+}
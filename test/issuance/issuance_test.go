@@ -0,0 +1,137 @@
+package issuance
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gov4git/gov4git/v2/proto/account"
+	"github.com/gov4git/gov4git/v2/proto/issuance"
+	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/gov4git/v2/runtime"
+	"github.com/gov4git/gov4git/v2/test"
+	"github.com/gov4git/lib4git/form"
+	"github.com/gov4git/lib4git/must"
+	"github.com/gov4git/lib4git/testutil"
+)
+
+func TestIssuance(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 2)
+
+	group := member.Group("contributors")
+	member.AddGroup(ctx, cty.Gov(), group)
+	member.AddMember(ctx, cty.Gov(), cty.MemberUser(0), group)
+	member.AddMember(ctx, cty.Gov(), cty.MemberUser(1), group)
+
+	start := time.Now().Add(-time.Minute)
+	maxBalance := account.Q(8.0)
+	issuance.Create(ctx, cty.Gov(), issuance.Schedule{
+		ID:         "ubc",
+		Group:      group,
+		Amount:     account.H(account.PluralAsset, 5.0),
+		Period:     time.Hour,
+		Start:      start,
+		MaxBalance: &maxBalance,
+		Note:       "universal basic credits",
+	})
+
+	balance := func(i int) account.Quantity {
+		return account.Get(ctx, cty.Gov(), cty.MemberAccountID(i)).Balance(account.PluralAsset).Quantity
+	}
+
+	// first period
+	chg := issuance.Run(ctx, cty.Gov(), start.Add(time.Minute))
+	if len(chg.Result) != 1 || len(chg.Result[0].Issued) != 2 {
+		t.Fatalf("expecting issuance to both members, got %v", form.SprintJSON(chg.Result))
+	}
+	if balance(0) != account.Q(5.0) || balance(1) != account.Q(5.0) {
+		t.Errorf("expecting balances of 5, got %v and %v", balance(0), balance(1))
+	}
+
+	// running again within the same period does not issue again
+	chg = issuance.Run(ctx, cty.Gov(), start.Add(59*time.Minute))
+	if len(chg.Result) != 0 {
+		t.Fatalf("expecting no issuance, got %v", form.SprintJSON(chg.Result))
+	}
+	if balance(0) != account.Q(5.0) {
+		t.Errorf("expecting balance of 5, got %v", balance(0))
+	}
+
+	// second period is capped by the maximum balance
+	account.Transfer(ctx, cty.Gov(), cty.MemberAccountID(1), cty.MemberAccountID(0), account.H(account.PluralAsset, 2.0), "test")
+	issuance.Run(ctx, cty.Gov(), start.Add(time.Hour+time.Minute))
+	if balance(0) != account.Q(8.0) || balance(1) != account.Q(8.0) {
+		t.Errorf("expecting capped balances of 8, got %v and %v", balance(0), balance(1))
+	}
+
+	// third period issues nothing to members at the cap
+	chg = issuance.Run(ctx, cty.Gov(), start.Add(2*time.Hour+time.Minute))
+	if len(chg.Result) != 1 || len(chg.Result[0].Issued) != 0 {
+		t.Errorf("expecting empty issuance, got %v", form.SprintJSON(chg.Result))
+	}
+	if s := issuance.List(ctx, cty.Gov()); len(s) != 1 || s[0].LastPeriod != 2 {
+		t.Errorf("expecting last period 2, got %v", form.SprintJSON(s))
+	}
+}
+
+// TestIssuanceMissedPeriods tests that periods which passed while the schedule was not run are skipped.
+func TestIssuanceMissedPeriods(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 2)
+
+	start := time.Now().Add(-time.Minute)
+	issuance.Create(ctx, cty.Gov(), issuance.Schedule{
+		ID:     "ubc",
+		Group:  member.Everybody,
+		Amount: account.H(account.PluralAsset, 5.0),
+		Period: time.Hour,
+		Start:  start,
+	})
+
+	issuance.Run(ctx, cty.Gov(), start.Add(time.Minute))
+	chg := issuance.Run(ctx, cty.Gov(), start.Add(3*time.Hour+time.Minute))
+	if len(chg.Result) != 1 || chg.Result[0].Period != 3 {
+		t.Fatalf("expecting only period 3 to be issued, got %v", form.SprintJSON(chg.Result))
+	}
+	if q := account.Get(ctx, cty.Gov(), cty.MemberAccountID(0)).Balance(account.PluralAsset).Quantity; q != account.Q(10.0) {
+		t.Errorf("expecting balance of 10, got %v", q)
+	}
+}
+
+// TestIssuanceFailures tests that a schedule that cannot be issued does not prevent other schedules from running,
+// and that assets issued by members can be scheduled.
+func TestIssuanceFailures(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 2)
+
+	badge := account.Asset("badge")
+	account.DefineAsset(ctx, cty.Gov(), account.AssetInfo{Asset: badge, Transferable: true, Divisible: false, Issuer: cty.MemberAccountID(1)})
+
+	group := member.Group("contributors")
+	member.AddGroup(ctx, cty.Gov(), group)
+	member.AddMember(ctx, cty.Gov(), cty.MemberUser(0), group)
+
+	start := time.Now().Add(-time.Minute)
+	issuance.Create(ctx, cty.Gov(), issuance.Schedule{ID: "badges", Group: member.Everybody, Amount: account.H(badge, 1.0), Period: time.Hour, Start: start})
+	issuance.Create(ctx, cty.Gov(), issuance.Schedule{ID: "contributors", Group: group, Amount: account.H(account.PluralAsset, 5.0), Period: time.Hour, Start: start})
+	if must.Try(func() {
+		issuance.Create(ctx, cty.Gov(), issuance.Schedule{ID: "missing", Group: "missing", Amount: account.H(account.PluralAsset, 5.0), Period: time.Hour, Start: start})
+	}) == nil {
+		t.Errorf("expecting schedule for a missing group to be refused")
+	}
+
+	// the contributors schedule fails, once its group is removed
+	member.RemoveGroup(ctx, cty.Gov(), group)
+	chg := issuance.Run(ctx, cty.Gov(), start.Add(time.Minute))
+	fmt.Println("run: ", form.SprintJSON(chg))
+	if len(chg.Result) != 2 || chg.Result[0].Error != "" || chg.Result[1].Error == "" {
+		t.Fatalf("expecting the badges schedule to run and the contributors schedule to fail, got %v", form.SprintJSON(chg.Result))
+	}
+	if q := account.Get(ctx, cty.Gov(), cty.MemberAccountID(0)).Balance(badge).Quantity; q != account.Q(1.0) {
+		t.Errorf("expecting a badge to be issued, got %v", q)
+	}
+	if s := issuance.List(ctx, cty.Gov()); s[0].LastPeriod != 0 || s[1].LastPeriod != -1 {
+		t.Errorf("expecting the failed period to be retried, got %v", form.SprintJSON(s))
+	}
+}
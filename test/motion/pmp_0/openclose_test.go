@@ -35,13 +35,13 @@ func TestOpenCancelConcernCloseProposal(t *testing.T) {
 	u1 := account.Get(ctx, cty.Gov(), cty.MemberAccountID(1)).Balance(account.PluralAsset)
 
 	exp0 := testUser0Credits + math.Abs(testUser1ProposalStrength)
-	if u0.Quantity != exp0 {
-		t.Errorf("expecting %v, got %v", exp0, u0.Quantity)
+	if u0.Quantity.Float64() != exp0 {
+		t.Errorf("expecting %v, got %v", exp0, u0.Quantity.Float64())
 	}
 
 	exp1 := testUser1Credits + testUser1ProposalStrength
-	if u1.Quantity != exp1 {
-		t.Errorf("expecting %v, got %v", exp1, u1.Quantity)
+	if u1.Quantity.Float64() != exp1 {
+		t.Errorf("expecting %v, got %v", exp1, u1.Quantity.Float64())
 	}
 }
 
@@ -60,13 +60,13 @@ func TestOpenCancelConcernCancelProposal(t *testing.T) {
 	u1 := account.Get(ctx, cty.Gov(), cty.MemberAccountID(1)).Balance(account.PluralAsset)
 
 	exp0 := testUser0Credits
-	if u0.Quantity != exp0 {
-		t.Errorf("expecting %v, got %v", exp0, u0.Quantity)
+	if u0.Quantity.Float64() != exp0 {
+		t.Errorf("expecting %v, got %v", exp0, u0.Quantity.Float64())
 	}
 
 	exp1 := testUser1Credits
-	if u1.Quantity != exp1 {
-		t.Errorf("expecting %v, got %v", exp1, u1.Quantity)
+	if u1.Quantity.Float64() != exp1 {
+		t.Errorf("expecting %v, got %v", exp1, u1.Quantity.Float64())
 	}
 }
 
@@ -85,13 +85,13 @@ func TestOpenCancelProposalCancelConcern(t *testing.T) {
 	u1 := account.Get(ctx, cty.Gov(), cty.MemberAccountID(1)).Balance(account.PluralAsset)
 
 	exp0 := testUser0Credits
-	if u0.Quantity != exp0 {
-		t.Errorf("expecting %v, got %v", exp0, u0.Quantity)
+	if u0.Quantity.Float64() != exp0 {
+		t.Errorf("expecting %v, got %v", exp0, u0.Quantity.Float64())
 	}
 
 	exp1 := testUser1Credits
-	if u1.Quantity != exp1 {
-		t.Errorf("expecting %v, got %v", exp1, u1.Quantity)
+	if u1.Quantity.Float64() != exp1 {
+		t.Errorf("expecting %v, got %v", exp1, u1.Quantity.Float64())
 	}
 }
 
@@ -128,12 +128,12 @@ func TestOpenCloseProposalCancelConcern(t *testing.T) {
 	u1 := account.Get(ctx, cty.Gov(), cty.MemberAccountID(1)).Balance(account.PluralAsset)
 
 	exp0 := testUser0Credits - math.Abs(testUser0ConcernStrenth)
-	if u0.Quantity <= exp0 {
-		t.Errorf("expecting more than %v, got %v", exp0, u0.Quantity)
+	if u0.Quantity.Float64() <= exp0 {
+		t.Errorf("expecting more than %v, got %v", exp0, u0.Quantity.Float64())
 	}
 
 	exp1 := testUser1Credits - math.Abs(testUser1ConcernStrength) - math.Abs(testUser1ProposalStrength)
-	if u1.Quantity <= exp1 {
-		t.Errorf("expecting more than %v, got %v", exp1, u1.Quantity)
+	if u1.Quantity.Float64() <= exp1 {
+		t.Errorf("expecting more than %v, got %v", exp1, u1.Quantity.Float64())
 	}
 }
@@ -0,0 +1,68 @@
+package pmp
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gov4git/gov4git/v2/proto/account"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotapi"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotproto"
+	"github.com/gov4git/gov4git/v2/proto/motion/motionapi"
+	"github.com/gov4git/gov4git/v2/proto/motion/motionpolicies/pmp_1"
+	"github.com/gov4git/gov4git/v2/proto/motion/motionpolicies/pmp_1/concern"
+	"github.com/gov4git/gov4git/v2/proto/motion/motionproto"
+	"github.com/gov4git/gov4git/v2/runtime"
+	"github.com/gov4git/gov4git/v2/test"
+	"github.com/gov4git/lib4git/must"
+	"github.com/gov4git/lib4git/testutil"
+)
+
+func TestConvictionPriorityPoll(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 2)
+
+	concern.SetPriorityPollPolicy(ctx, cty.Organizer(), concern.ConcernConvictionPriorityPollPolicyName)
+
+	motionapi.OpenMotion(
+		ctx,
+		cty.Organizer(),
+		testConcernID,
+		motionproto.MotionConcernType,
+		pmp_1.ConcernPolicyName,
+		cty.MemberUser(0),
+		"concern #1",
+		"body #1",
+		"https://1",
+		nil)
+
+	pollID := pmp_1.ConcernPollBallotName(testConcernID)
+	if p := ballotapi.Show(ctx, cty.Gov(), pollID).Ad.Policy; p != concern.ConcernConvictionPriorityPollPolicyName {
+		t.Fatalf("expecting priority poll policy %v, got %v", concern.ConcernConvictionPriorityPollPolicyName, p)
+	}
+
+	account.Issue(ctx, cty.Gov(), cty.MemberAccountID(0), account.H(account.PluralAsset, 16.0), "test")
+	ballotapi.Vote(ctx, cty.MemberOwner(0), cty.Gov(), pollID, ballotproto.OneElection(pmp_1.ConcernBallotChoice, 16.0))
+	ballotapi.TallyAll(ctx, cty.Organizer(), 2)
+	motionapi.Pipeline(ctx, cty.Organizer())
+
+	// the voter is charged for committed credits, while the poll score accrues slowly
+	tally := ballotapi.Show(ctx, cty.Gov(), pollID).Tally
+	if charge := tally.Charges[cty.MemberUser(0)]; charge != 16.0 {
+		t.Errorf("expecting charge 16, got %v", charge)
+	}
+	score := tally.Scores[pmp_1.ConcernBallotChoice]
+	if !(score > 0 && score < 4.0) {
+		t.Errorf("expecting accrued score between 0 and 4, got %v", score)
+	}
+
+	// the concern's priority score is the cost of priority
+	state := motionapi.LoadPolicyState[*pmp_1.ConcernState](ctx, cty.Gov(), testConcernID)
+	if math.Abs(state.PriorityScore-16.0) > 1e-6 {
+		t.Errorf("expecting priority score 16, got %v", state.PriorityScore)
+	}
+
+	// unknown priority poll policies are rejected
+	if must.Try(func() { concern.SetPriorityPollPolicy(ctx, cty.Organizer(), "qv") }) == nil {
+		t.Errorf("expecting unknown priority poll policy to be rejected")
+	}
+}
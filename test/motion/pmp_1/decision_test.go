@@ -0,0 +1,35 @@
+package pmp
+
+import (
+	"math"
+	"testing"
+
+	"github.com/gov4git/gov4git/v2/proto/motion/motionapi"
+	"github.com/gov4git/gov4git/v2/proto/motion/motionpolicies/pmp_1"
+	"github.com/gov4git/lib4git/form"
+)
+
+func TestMotionViewReportsDecision(t *testing.T) {
+	c := testCaseWithoutMatch
+	ctx, cty := SetupTest(t, c)
+
+	// the priority poll of the concern has 30 credits in favor and 20 against
+	mv := motionapi.ShowMotion(ctx, cty.Gov(), testConcernID)
+	if len(mv.Ballots) != 1 {
+		t.Fatalf("expecting one ballot, got %v", form.SprintJSON(mv.Ballots))
+	}
+	d := mv.Ballots[0].BallotDecision
+	if d == nil || !d.Passed || d.Choice != pmp_1.ConcernBallotChoice {
+		t.Fatalf("expecting the priority poll to pass, got %v", form.SprintJSON(d))
+	}
+	exp := math.Sqrt(30) / (math.Sqrt(30) + math.Sqrt(20))
+	if math.Abs(d.Support-exp) > 0.001 {
+		t.Errorf("expecting support %v, got %v", exp, d.Support)
+	}
+
+	// closed ballots report no decision
+	motionapi.CancelMotion(ctx, cty.Organizer(), testConcernID)
+	if d := motionapi.ShowMotion(ctx, cty.Gov(), testConcernID).Ballots[0].BallotDecision; d != nil {
+		t.Errorf("expecting no decision for a closed ballot, got %v", form.SprintJSON(d))
+	}
+}
@@ -22,13 +22,13 @@ func TestOpenCancelConcernCloseProposal(t *testing.T) {
 	u1 := account.Get(ctx, cty.Gov(), cty.MemberAccountID(1)).Balance(account.PluralAsset)
 
 	exp0 := c.User0Credits - math.Abs(c.User0ProposalStrength) + 16.733
-	if math.Abs(u0.Quantity-exp0) > 0.1 {
-		t.Errorf("expecting %v, got %v", exp0, u0.Quantity)
+	if math.Abs(u0.Quantity.Float64()-exp0) > 0.1 {
+		t.Errorf("expecting %v, got %v", exp0, u0.Quantity.Float64())
 	}
 
 	exp1 := c.User1Credits + c.User1ProposalStrength
-	if math.Abs(u1.Quantity-exp1) > 0.1 {
-		t.Errorf("expecting %v, got %v", exp1, u1.Quantity)
+	if math.Abs(u1.Quantity.Float64()-exp1) > 0.1 {
+		t.Errorf("expecting %v, got %v", exp1, u1.Quantity.Float64())
 	}
 }
 
@@ -44,13 +44,13 @@ func TestOpenCancelConcernCancelProposal(t *testing.T) {
 	u1 := account.Get(ctx, cty.Gov(), cty.MemberAccountID(1)).Balance(account.PluralAsset)
 
 	exp0 := c.User0Credits
-	if u0.Quantity != exp0 {
-		t.Errorf("expecting %v, got %v", exp0, u0.Quantity)
+	if u0.Quantity.Float64() != exp0 {
+		t.Errorf("expecting %v, got %v", exp0, u0.Quantity.Float64())
 	}
 
 	exp1 := c.User1Credits
-	if u1.Quantity != exp1 {
-		t.Errorf("expecting %v, got %v", exp1, u1.Quantity)
+	if u1.Quantity.Float64() != exp1 {
+		t.Errorf("expecting %v, got %v", exp1, u1.Quantity.Float64())
 	}
 }
 
@@ -66,13 +66,13 @@ func TestOpenCancelProposalCancelConcern(t *testing.T) {
 	u1 := account.Get(ctx, cty.Gov(), cty.MemberAccountID(1)).Balance(account.PluralAsset)
 
 	exp0 := c.User0Credits
-	if u0.Quantity != exp0 {
-		t.Errorf("expecting %v, got %v", exp0, u0.Quantity)
+	if u0.Quantity.Float64() != exp0 {
+		t.Errorf("expecting %v, got %v", exp0, u0.Quantity.Float64())
 	}
 
 	exp1 := c.User1Credits
-	if u1.Quantity != exp1 {
-		t.Errorf("expecting %v, got %v", exp1, u1.Quantity)
+	if u1.Quantity.Float64() != exp1 {
+		t.Errorf("expecting %v, got %v", exp1, u1.Quantity.Float64())
 	}
 }
 
@@ -96,16 +96,16 @@ func TestAcceptLinkedProposalWithoutMatch(t *testing.T) {
 	u1 := account.Get(ctx, cty.Gov(), cty.MemberAccountID(1)).Balance(account.PluralAsset)
 	u2 := account.Get(ctx, cty.Gov(), cty.MemberAccountID(2)).Balance(account.PluralAsset)
 
-	if math.Abs(u0.Quantity-c.User0EndBalance) > 0.01 {
-		t.Errorf("expecting %v, got %v", c.User0EndBalance, u0.Quantity)
+	if math.Abs(u0.Quantity.Float64()-c.User0EndBalance) > 0.01 {
+		t.Errorf("expecting %v, got %v", c.User0EndBalance, u0.Quantity.Float64())
 	}
 
-	if math.Abs(u1.Quantity-c.User1EndBalance) > 0.01 {
-		t.Errorf("expecting %v, got %v", c.User1EndBalance, u1.Quantity)
+	if math.Abs(u1.Quantity.Float64()-c.User1EndBalance) > 0.01 {
+		t.Errorf("expecting %v, got %v", c.User1EndBalance, u1.Quantity.Float64())
 	}
 
-	if math.Abs(u2.Quantity-c.User2EndBalance) > 0.01 {
-		t.Errorf("expecting %v, got %v", c.User2EndBalance, u2.Quantity)
+	if math.Abs(u2.Quantity.Float64()-c.User2EndBalance) > 0.01 {
+		t.Errorf("expecting %v, got %v", c.User2EndBalance, u2.Quantity.Float64())
 	}
 }
 
@@ -129,15 +129,15 @@ func TestAcceptLinkedProposalWithMatch(t *testing.T) {
 	u1 := account.Get(ctx, cty.Gov(), cty.MemberAccountID(1)).Balance(account.PluralAsset)
 	u2 := account.Get(ctx, cty.Gov(), cty.MemberAccountID(2)).Balance(account.PluralAsset)
 
-	if math.Abs(u0.Quantity-c.User0EndBalance) > 0.01 {
-		t.Errorf("expecting %v, got %v", c.User0EndBalance, u0.Quantity)
+	if math.Abs(u0.Quantity.Float64()-c.User0EndBalance) > 0.01 {
+		t.Errorf("expecting %v, got %v", c.User0EndBalance, u0.Quantity.Float64())
 	}
 
-	if math.Abs(u1.Quantity-c.User1EndBalance) > 0.01 {
-		t.Errorf("expecting %v, got %v", c.User1EndBalance, u1.Quantity)
+	if math.Abs(u1.Quantity.Float64()-c.User1EndBalance) > 0.01 {
+		t.Errorf("expecting %v, got %v", c.User1EndBalance, u1.Quantity.Float64())
 	}
 
-	if math.Abs(u2.Quantity-c.User2EndBalance) > 0.01 {
-		t.Errorf("expecting %v, got %v", c.User2EndBalance, u2.Quantity)
+	if math.Abs(u2.Quantity.Float64()-c.User2EndBalance) > 0.01 {
+		t.Errorf("expecting %v, got %v", c.User2EndBalance, u2.Quantity.Float64())
 	}
 }
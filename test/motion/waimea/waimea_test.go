@@ -31,16 +31,16 @@ func TestCancelConcernCancelProposal(t *testing.T) {
 	u1 := account.Get(ctx, cty.Gov(), cty.MemberAccountID(1)).Balance(account.PluralAsset)
 	u2 := account.Get(ctx, cty.Gov(), cty.MemberAccountID(2)).Balance(account.PluralAsset)
 
-	if math.Abs(u0.Quantity-c.Voter0EndBalance) > 0.01 {
-		t.Errorf("expecting %v, got %v", c.Voter0EndBalance, u0.Quantity)
+	if math.Abs(u0.Quantity.Float64()-c.Voter0EndBalance) > 0.01 {
+		t.Errorf("expecting %v, got %v", c.Voter0EndBalance, u0.Quantity.Float64())
 	}
 
-	if math.Abs(u1.Quantity-c.Voter1EndBalance) > 0.01 {
-		t.Errorf("expecting %v, got %v", c.Voter1EndBalance, u1.Quantity)
+	if math.Abs(u1.Quantity.Float64()-c.Voter1EndBalance) > 0.01 {
+		t.Errorf("expecting %v, got %v", c.Voter1EndBalance, u1.Quantity.Float64())
 	}
 
-	if math.Abs(u2.Quantity-c.AuthorEndBalance) > 0.01 {
-		t.Errorf("expecting %v, got %v", c.AuthorEndBalance, u2.Quantity)
+	if math.Abs(u2.Quantity.Float64()-c.AuthorEndBalance) > 0.01 {
+		t.Errorf("expecting %v, got %v", c.AuthorEndBalance, u2.Quantity.Float64())
 	}
 }
 
@@ -65,16 +65,16 @@ func TestCancelProposalCancelConcern(t *testing.T) {
 	u1 := account.Get(ctx, cty.Gov(), cty.MemberAccountID(1)).Balance(account.PluralAsset)
 	u2 := account.Get(ctx, cty.Gov(), cty.MemberAccountID(2)).Balance(account.PluralAsset)
 
-	if math.Abs(u0.Quantity-c.Voter0EndBalance) > 0.01 {
-		t.Errorf("expecting %v, got %v", c.Voter0EndBalance, u0.Quantity)
+	if math.Abs(u0.Quantity.Float64()-c.Voter0EndBalance) > 0.01 {
+		t.Errorf("expecting %v, got %v", c.Voter0EndBalance, u0.Quantity.Float64())
 	}
 
-	if math.Abs(u1.Quantity-c.Voter1EndBalance) > 0.01 {
-		t.Errorf("expecting %v, got %v", c.Voter1EndBalance, u1.Quantity)
+	if math.Abs(u1.Quantity.Float64()-c.Voter1EndBalance) > 0.01 {
+		t.Errorf("expecting %v, got %v", c.Voter1EndBalance, u1.Quantity.Float64())
 	}
 
-	if math.Abs(u2.Quantity-c.AuthorEndBalance) > 0.01 {
-		t.Errorf("expecting %v, got %v", c.AuthorEndBalance, u2.Quantity)
+	if math.Abs(u2.Quantity.Float64()-c.AuthorEndBalance) > 0.01 {
+		t.Errorf("expecting %v, got %v", c.AuthorEndBalance, u2.Quantity.Float64())
 	}
 }
 
@@ -99,16 +99,16 @@ func TestCancelConcernAcceptProposal(t *testing.T) {
 	u1 := account.Get(ctx, cty.Gov(), cty.MemberAccountID(1)).Balance(account.PluralAsset)
 	u2 := account.Get(ctx, cty.Gov(), cty.MemberAccountID(2)).Balance(account.PluralAsset)
 
-	if math.Abs(u0.Quantity-c.Voter0EndBalance) > 0.01 {
-		t.Errorf("expecting %v, got %v", c.Voter0EndBalance, u0.Quantity)
+	if math.Abs(u0.Quantity.Float64()-c.Voter0EndBalance) > 0.01 {
+		t.Errorf("expecting %v, got %v", c.Voter0EndBalance, u0.Quantity.Float64())
 	}
 
-	if math.Abs(u1.Quantity-c.Voter1EndBalance) > 0.01 {
-		t.Errorf("expecting %v, got %v", c.Voter1EndBalance, u1.Quantity)
+	if math.Abs(u1.Quantity.Float64()-c.Voter1EndBalance) > 0.01 {
+		t.Errorf("expecting %v, got %v", c.Voter1EndBalance, u1.Quantity.Float64())
 	}
 
-	if math.Abs(u2.Quantity-c.AuthorEndBalance) > 0.01 {
-		t.Errorf("expecting %v, got %v", c.AuthorEndBalance, u2.Quantity)
+	if math.Abs(u2.Quantity.Float64()-c.AuthorEndBalance) > 0.01 {
+		t.Errorf("expecting %v, got %v", c.AuthorEndBalance, u2.Quantity.Float64())
 	}
 }
 
@@ -132,16 +132,16 @@ func TestAcceptProposal(t *testing.T) {
 	u1 := account.Get(ctx, cty.Gov(), cty.MemberAccountID(1)).Balance(account.PluralAsset)
 	u2 := account.Get(ctx, cty.Gov(), cty.MemberAccountID(2)).Balance(account.PluralAsset)
 
-	if math.Abs(u0.Quantity-c.Voter0EndBalance) > 0.01 {
-		t.Errorf("expecting %v, got %v", c.Voter0EndBalance, u0.Quantity)
+	if math.Abs(u0.Quantity.Float64()-c.Voter0EndBalance) > 0.01 {
+		t.Errorf("expecting %v, got %v", c.Voter0EndBalance, u0.Quantity.Float64())
 	}
 
-	if math.Abs(u1.Quantity-c.Voter1EndBalance) > 0.01 {
-		t.Errorf("expecting %v, got %v", c.Voter1EndBalance, u1.Quantity)
+	if math.Abs(u1.Quantity.Float64()-c.Voter1EndBalance) > 0.01 {
+		t.Errorf("expecting %v, got %v", c.Voter1EndBalance, u1.Quantity.Float64())
 	}
 
-	if math.Abs(u2.Quantity-c.AuthorEndBalance) > 0.01 {
-		t.Errorf("expecting %v, got %v", c.AuthorEndBalance, u2.Quantity)
+	if math.Abs(u2.Quantity.Float64()-c.AuthorEndBalance) > 0.01 {
+		t.Errorf("expecting %v, got %v", c.AuthorEndBalance, u2.Quantity.Float64())
 	}
 }
 
@@ -156,15 +156,15 @@ func TestRejectProposal(t *testing.T) {
 	u1 := account.Get(ctx, cty.Gov(), cty.MemberAccountID(1)).Balance(account.PluralAsset)
 	u2 := account.Get(ctx, cty.Gov(), cty.MemberAccountID(2)).Balance(account.PluralAsset)
 
-	if math.Abs(u0.Quantity-c.Voter0EndBalance) > 0.01 {
-		t.Errorf("expecting %v, got %v", c.Voter0EndBalance, u0.Quantity)
+	if math.Abs(u0.Quantity.Float64()-c.Voter0EndBalance) > 0.01 {
+		t.Errorf("expecting %v, got %v", c.Voter0EndBalance, u0.Quantity.Float64())
 	}
 
-	if math.Abs(u1.Quantity-c.Voter1EndBalance) > 0.01 {
-		t.Errorf("expecting %v, got %v", c.Voter1EndBalance, u1.Quantity)
+	if math.Abs(u1.Quantity.Float64()-c.Voter1EndBalance) > 0.01 {
+		t.Errorf("expecting %v, got %v", c.Voter1EndBalance, u1.Quantity.Float64())
 	}
 
-	if math.Abs(u2.Quantity-c.AuthorEndBalance) > 0.01 {
-		t.Errorf("expecting %v, got %v", c.AuthorEndBalance, u2.Quantity)
+	if math.Abs(u2.Quantity.Float64()-c.AuthorEndBalance) > 0.01 {
+		t.Errorf("expecting %v, got %v", c.AuthorEndBalance, u2.Quantity.Float64())
 	}
 }
@@ -0,0 +1,46 @@
+package zero
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/motion/motionapi"
+	"github.com/gov4git/gov4git/v2/proto/motion/motionpolicies/zero"
+	"github.com/gov4git/gov4git/v2/proto/motion/motionproto"
+	"github.com/gov4git/gov4git/v2/proto/notice"
+	"github.com/gov4git/gov4git/v2/runtime"
+	"github.com/gov4git/gov4git/v2/test"
+	"github.com/gov4git/lib4git/testutil"
+)
+
+func TestThread(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 2)
+
+	id := motionproto.MotionID("123")
+	motionapi.OpenMotion(ctx, cty.Organizer(), id, motionproto.MotionConcernType, zero.ZeroPolicyName, cty.MemberUser(0), "concern", "description", "", []string{"a", "b"})
+
+	// labels
+	motionapi.LabelMotion(ctx, cty.Organizer(), id, []string{"c"}, []string{"a"})
+	if m := motionapi.LookupMotion(ctx, cty.Gov(), id); strings.Join(m.Labels, ",") != "b,c" {
+		t.Errorf("expecting labels b,c, got %v", m.Labels)
+	}
+
+	// comments
+	motionapi.CommentMotion(ctx, cty.Organizer(), id, cty.MemberUser(1), "first comment")
+	thread := motionapi.LoadMotionThread(ctx, cty.Gov(), id)
+	if !strings.Contains(thread, "first comment") || !strings.Contains(thread, "@"+string(cty.MemberUser(1))) {
+		t.Errorf("expecting comment in thread, got %q", thread)
+	}
+
+	// notices are written to the thread once
+	cloned := gov.CloneOwner(ctx, cty.Organizer())
+	motionapi.AppendMotionNotices_StageOnly(ctx, cloned.PublicClone(), id, notice.Noticef(ctx, "a notice"))
+	motionapi.DisplayNoticesInThreads_StageOnly(ctx, cloned.PublicClone())
+	motionapi.DisplayNoticesInThreads_StageOnly(ctx, cloned.PublicClone())
+	thread = motionapi.LoadMotionThread_Local(ctx, cloned.PublicClone(), id)
+	if strings.Count(thread, "a notice") != 1 || !strings.HasPrefix(thread, "#### Comment") {
+		t.Errorf("expecting notice to follow comment in thread, got %q", thread)
+	}
+}
@@ -0,0 +1,115 @@
+package retry
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	govgh "github.com/gov4git/gov4git/v2/github"
+	"github.com/gov4git/gov4git/v2/proto"
+	"github.com/gov4git/gov4git/v2/proto/account"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/runtime"
+	"github.com/gov4git/gov4git/v2/test"
+	"github.com/gov4git/gov4git/v2/tracker"
+	"github.com/gov4git/lib4git/must"
+	"github.com/gov4git/lib4git/testutil"
+)
+
+func TestRetryReplaysOnConflict(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 2)
+
+	attempts := 0
+	proto.Retry(ctx, func() {
+		attempts++
+		cloned := gov.Clone(ctx, cty.Gov())
+		account.Issue_StageOnly(ctx, cloned, cty.MemberAccountID(0), account.H(account.PluralAsset, 1), "first writer")
+		if attempts == 1 {
+			// a second writer pushes between the clone and the push of the first
+			account.Issue(ctx, cty.Gov(), cty.MemberAccountID(1), account.H(account.PluralAsset, 1), "second writer")
+		}
+		proto.Commitf(ctx, cloned, "test_retry", "issue to first member")
+	})
+
+	if attempts != 2 {
+		t.Errorf("expecting 2 attempts, got %d", attempts)
+	}
+	for i := 0; i < 2; i++ {
+		if q := account.Get(ctx, cty.Gov(), cty.MemberAccountID(i)).Balance(account.PluralAsset).Quantity.Float64(); q != 1.0 {
+			t.Errorf("expecting balance 1 for member %d, got %v", i, q)
+		}
+	}
+}
+
+// commentTracker records the comments posted to it.
+type commentTracker struct {
+	tracker.Tracker
+	comments []string
+}
+
+func (x *commentTracker) PostComment(ctx context.Context, number int64, body string) {
+	x.comments = append(x.comments, body)
+}
+
+func TestRetryWithTrackerWritesOnce(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 2)
+
+	tr := &commentTracker{}
+	attempts := 0
+	govgh.RetryWithTracker(ctx, tr, func(tr tracker.Tracker) struct{} {
+		attempts++
+		cloned := gov.Clone(ctx, cty.Gov())
+		account.Issue_StageOnly(ctx, cloned, cty.MemberAccountID(0), account.H(account.PluralAsset, 1), "first writer")
+		tr.PostComment(ctx, 1, fmt.Sprintf("attempt %d", attempts))
+		if attempts == 1 {
+			// a second writer pushes between the clone and the push of the first
+			account.Issue(ctx, cty.Gov(), cty.MemberAccountID(1), account.H(account.PluralAsset, 1), "second writer")
+		}
+		proto.Commitf(ctx, cloned, "test_retry", "issue to first member")
+		return struct{}{}
+	})
+
+	if attempts != 2 {
+		t.Errorf("expecting 2 attempts, got %d", attempts)
+	}
+	if len(tr.comments) != 1 || tr.comments[0] != "attempt 2" {
+		t.Errorf("expecting only the comment of the successful attempt, got %v", tr.comments)
+	}
+}
+
+func TestConcurrentWriters(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 2)
+
+	saveAttempts, saveBackoff := proto.MaxPushAttempts, proto.PushRetryBackoff
+	proto.MaxPushAttempts, proto.PushRetryBackoff = 20, 10*time.Millisecond
+	defer func() { proto.MaxPushAttempts, proto.PushRetryBackoff = saveAttempts, saveBackoff }()
+
+	const n = 5
+	var wg sync.WaitGroup
+	for w := 0; w < 2; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < n; i++ {
+				err := must.Try(func() {
+					account.Issue(ctx, cty.Gov(), cty.MemberAccountID(w), account.H(account.PluralAsset, 1), "race")
+				})
+				if err != nil {
+					t.Errorf("writer %d failed (%v)", w, err)
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	for w := 0; w < 2; w++ {
+		if q := account.Get(ctx, cty.Gov(), cty.MemberAccountID(w)).Balance(account.PluralAsset).Quantity.Float64(); q != n {
+			t.Errorf("expecting balance %v for member %d, got %v", n, w, q)
+		}
+	}
+}
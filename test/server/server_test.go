@@ -0,0 +1,183 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gov4git/gov4git/v2/gov4git/api"
+	"github.com/gov4git/gov4git/v2/gov4git/server"
+	"github.com/gov4git/gov4git/v2/proto/account"
+	"github.com/gov4git/gov4git/v2/proto/gov"
+	"github.com/gov4git/gov4git/v2/proto/motion/motionpolicies/zero"
+	"github.com/gov4git/gov4git/v2/proto/motion/motionproto"
+	"github.com/gov4git/gov4git/v2/runtime"
+	"github.com/gov4git/gov4git/v2/test"
+	"github.com/gov4git/lib4git/testutil"
+)
+
+func TestServer(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 2)
+
+	setup := api.Setup{Gov: cty.Gov(), Organizer: cty.Organizer(), Member: cty.MemberOwner(0)}
+	srv := httptest.NewServer(server.NewServer(ctx, setup, testToken).Handler())
+	defer srv.Close()
+
+	// open a motion
+	req := map[string]any{
+		"id":     "123",
+		"type":   motionproto.MotionConcernType,
+		"policy": zero.ZeroPolicyName,
+		"author": cty.MemberUser(0),
+		"title":  "concern",
+	}
+	var opened struct {
+		Returned struct {
+			Motion struct {
+				ID string `json:"id"`
+			} `json:"motion"`
+		} `json:"returned"`
+	}
+	if status := post(t, srv.URL+"/v1/motion/open", req, &opened); status != http.StatusOK {
+		t.Fatalf("opening motion returned status %d", status)
+	}
+	if opened.Returned.Motion.ID != "123" {
+		t.Errorf("expecting motion 123, got %v", opened)
+	}
+
+	// show the motion
+	var shown struct {
+		Returned struct {
+			Motion struct {
+				Title string `json:"title"`
+			} `json:"motion"`
+		} `json:"returned"`
+	}
+	if status := get(t, srv.URL+"/v1/motion?id=123", &shown); status != http.StatusOK || shown.Returned.Motion.Title != "concern" {
+		t.Errorf("expecting motion 123, got status %d and %v", status, shown)
+	}
+
+	// list members
+	var listed struct {
+		Returned []string `json:"returned"`
+	}
+	if status := get(t, srv.URL+"/v1/members", &listed); status != http.StatusOK || len(listed.Returned) != 4 {
+		t.Errorf("expecting four members, got status %d and %v", status, listed)
+	}
+
+	// request validation
+	var invalid struct {
+		Status api.Status `json:"status"`
+	}
+	if status := post(t, srv.URL+"/v1/motion/open", map[string]any{"id": "124"}, &invalid); status != http.StatusBadRequest {
+		t.Errorf("expecting bad request for missing fields, got %d", status)
+	}
+	if status := post(t, srv.URL+"/v1/motion/open", map[string]any{"id": "124", "unknown": 1}, &invalid); status != http.StatusBadRequest {
+		t.Errorf("expecting bad request for unknown fields, got %d", status)
+	}
+	if status := get(t, srv.URL+"/v1/motion/open", &invalid); status != http.StatusMethodNotAllowed {
+		t.Errorf("expecting method not allowed, got %d", status)
+	}
+	if status := get(t, srv.URL+"/v1/motion?id=999", &invalid); status != http.StatusNotFound || invalid.Status != api.StatusError {
+		t.Errorf("expecting not found for missing motion, got %d", status)
+	}
+	if status := post(t, srv.URL+"/v1/motion/open", req, &invalid); status != http.StatusBadRequest || invalid.Status != api.StatusError {
+		t.Errorf("expecting bad request for existing motion, got %d", status)
+	}
+
+	// required fields can be passed with zero values
+	issue := map[string]any{"to": cty.MemberAccountID(0), "asset": account.PluralAsset, "quantity": 0}
+	if status := post(t, srv.URL+"/v1/account/issue", issue, &invalid); status != http.StatusOK {
+		t.Errorf("expecting a zero quantity to be accepted, got %d", status)
+	}
+	transfer := map[string]any{"from": cty.MemberAccountID(0), "to": cty.MemberAccountID(1), "asset": account.PluralAsset, "quantity": 5}
+	if status := post(t, srv.URL+"/v1/account/transfer", transfer, &invalid); status != http.StatusBadRequest {
+		t.Errorf("expecting bad request for a transfer exceeding the balance, got %d", status)
+	}
+
+	// failures of the server, unlike invalid requests, are internal errors
+	unreachable := api.Setup{Gov: gov.Address{Repo: "file:///nonexistent", Branch: "main"}, Organizer: cty.Organizer(), Member: cty.MemberOwner(0)}
+	failing := httptest.NewServer(server.NewServer(ctx, unreachable, testToken).Handler())
+	defer failing.Close()
+	if status := get(t, failing.URL+"/v1/members", &invalid); status != http.StatusInternalServerError {
+		t.Errorf("expecting internal error for an unreachable community, got %d", status)
+	}
+
+	// authentication and content type
+	if status := send(t, http.MethodGet, srv.URL+"/v1/members", "", "", nil); status != http.StatusUnauthorized {
+		t.Errorf("expecting unauthorized without a token, got %d", status)
+	}
+	if status := send(t, http.MethodGet, srv.URL+"/v1/members", "wrong", "", nil); status != http.StatusUnauthorized {
+		t.Errorf("expecting unauthorized with a wrong token, got %d", status)
+	}
+	buf, _ := json.Marshal(map[string]any{"id": "125", "type": motionproto.MotionConcernType, "policy": zero.ZeroPolicyName, "title": "concern"})
+	if status := send(t, http.MethodPost, srv.URL+"/v1/motion/open", testToken, "text/plain", buf); status != http.StatusUnsupportedMediaType {
+		t.Errorf("expecting unsupported media type for a text body, got %d", status)
+	}
+	if status := send(t, http.MethodPost, srv.URL+"/v1/motion/open", "", "application/json", buf); status != http.StatusUnauthorized {
+		t.Errorf("expecting unauthorized write without a token, got %d", status)
+	}
+
+	// openapi
+	var spec struct {
+		Paths      map[string]map[string]any `json:"paths"`
+		Components struct {
+			Schemas map[string]any `json:"schemas"`
+		} `json:"components"`
+	}
+	get(t, srv.URL+"/openapi.json", &spec)
+	if _, ok := spec.Paths["/v1/motion/open"]["post"]; !ok {
+		t.Errorf("expecting post /v1/motion/open in openapi paths")
+	}
+	if _, ok := spec.Paths["/v1/motions"]["get"]; !ok {
+		t.Errorf("expecting get /v1/motions in openapi paths")
+	}
+	if _, ok := spec.Components.Schemas["server.OpenMotionRequest"]; !ok {
+		t.Errorf("expecting schema for open motion requests")
+	}
+}
+
+const testToken = "test-token"
+
+func get(t *testing.T, url string, result any) int {
+	return call(t, http.MethodGet, url, nil, result)
+}
+
+func post(t *testing.T, url string, req any, result any) int {
+	buf, _ := json.Marshal(req)
+	return call(t, http.MethodPost, url, buf, result)
+}
+
+func call(t *testing.T, method string, url string, body []byte, result any) int {
+	resp := do(t, method, url, testToken, "application/json", body)
+	defer resp.Body.Close()
+	json.NewDecoder(resp.Body).Decode(result)
+	return resp.StatusCode
+}
+
+func send(t *testing.T, method string, url string, token string, contentType string, body []byte) int {
+	resp := do(t, method, url, token, contentType, body)
+	resp.Body.Close()
+	return resp.StatusCode
+}
+
+func do(t *testing.T, method string, url string, token string, contentType string, body []byte) *http.Response {
+	req, err := http.NewRequest(method, url, bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp
+}
@@ -0,0 +1,79 @@
+package sync
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gov4git/gov4git/v2/proto/account"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotapi"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotio"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotproto"
+	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/gov4git/v2/proto/purpose"
+	"github.com/gov4git/gov4git/v2/proto/sync"
+	"github.com/gov4git/gov4git/v2/runtime"
+	"github.com/gov4git/gov4git/v2/test"
+	"github.com/gov4git/lib4git/form"
+	"github.com/gov4git/lib4git/git"
+	"github.com/gov4git/lib4git/testutil"
+)
+
+// TestSyncPublicRepos tests that a sync fetches every member's public repo once, and reuses it for all ballots and the bureau.
+func TestSyncPublicRepos(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 2)
+
+	choices := []string{"x", "y", "z"}
+	strat := ballotio.QVPolicyName
+	for _, name := range []string{"a/b/c", "d/e/f"} {
+		ballotapi.Open(ctx, strat, cty.Organizer(), ballotproto.ParseBallotID(name), account.NobodyAccountID, purpose.Unspecified, "", name, name, choices, member.Everybody)
+	}
+
+	account.Issue(ctx, cty.Gov(), cty.MemberAccountID(0), account.H(account.PluralAsset, 5.0), "test")
+	ballotapi.Vote(ctx, cty.MemberOwner(0), cty.Gov(), ballotproto.ParseBallotID("a/b/c"), ballotproto.Elections{ballotproto.NewElection(choices[0], 1.0)})
+	ballotapi.Vote(ctx, cty.MemberOwner(0), cty.Gov(), ballotproto.ParseBallotID("d/e/f"), ballotproto.Elections{ballotproto.NewElection(choices[1], 1.0)})
+
+	syncChg := sync.Sync(ctx, cty.Organizer(), 2)
+	fmt.Println("sync: ", form.SprintJSON(syncChg))
+
+	// members and silent members are fetched once by the tally, and reused by the bureau
+	stats := syncChg.Result["public_repos"].(member.PublicRepoStats)
+	if stats.Fetched != 2 || stats.Unreachable != 2 || stats.Reused != 4 {
+		t.Errorf("expecting 2 fetched, 2 unreachable and 4 reused repos, got %v", form.SprintJSON(stats))
+	}
+
+	for _, name := range []string{"a/b/c", "d/e/f"} {
+		if n := ballotapi.Show(ctx, cty.Gov(), ballotproto.ParseBallotID(name)).Tally.NumVoters(); n != 1 {
+			t.Errorf("expecting 1 voter on ballot %v, got %v", name, n)
+		}
+	}
+}
+
+// TestSyncPublicReposGitCache tests that, with a git cache, a sync fetches the public repos of members through the cache.
+func TestSyncPublicReposGitCache(t *testing.T) {
+	cacheDir := filepath.Join(t.TempDir(), "cache")
+	ctx := member.WithGitCache(testutil.NewCtx(t, false), cacheDir)
+	cty := test.NewTestCommunity(t, ctx, 2)
+
+	choices := []string{"x", "y", "z"}
+	ballotapi.Open(ctx, ballotio.QVPolicyName, cty.Organizer(), ballotproto.ParseBallotID("a/b/c"), account.NobodyAccountID, purpose.Unspecified, "", "a/b/c", "a/b/c", choices, member.Everybody)
+	account.Issue(ctx, cty.Gov(), cty.MemberAccountID(0), account.H(account.PluralAsset, 5.0), "test")
+	ballotapi.Vote(ctx, cty.MemberOwner(0), cty.Gov(), ballotproto.ParseBallotID("a/b/c"), ballotproto.Elections{ballotproto.NewElection(choices[0], 1.0)})
+
+	// drop the replicas cached so far, so that the sync must fetch the public repos of members into the cache
+	if err := os.RemoveAll(cacheDir); err != nil {
+		t.Fatal(err)
+	}
+	syncChg := sync.Sync(ctx, cty.Organizer(), 2)
+	fmt.Println("sync: ", form.SprintJSON(syncChg))
+
+	if n := ballotapi.Show(ctx, cty.Gov(), ballotproto.ParseBallotID("a/b/c")).Tally.NumVoters(); n != 1 {
+		t.Errorf("expecting 1 voter, got %v", n)
+	}
+	addr := git.Address(cty.MemberOwner(0).Public)
+	if _, err := os.Stat(filepath.Join(cacheDir, form.StringHashForFilename(addr.String()))); err != nil {
+		t.Errorf("expecting public repo %v to be cached (%v)", addr, err)
+	}
+}
@@ -0,0 +1,90 @@
+package sync
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/gov4git/gov4git/v2/proto/account"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotapi"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotio"
+	"github.com/gov4git/gov4git/v2/proto/ballot/ballotproto"
+	"github.com/gov4git/gov4git/v2/proto/member"
+	"github.com/gov4git/gov4git/v2/proto/purpose"
+	"github.com/gov4git/gov4git/v2/proto/sync"
+	"github.com/gov4git/gov4git/v2/runtime"
+	"github.com/gov4git/gov4git/v2/test"
+	"github.com/gov4git/lib4git/form"
+	"github.com/gov4git/lib4git/must"
+	"github.com/gov4git/lib4git/testutil"
+)
+
+func TestSchedule(t *testing.T) {
+	ctx := testutil.NewCtx(t, runtime.TestWithCache)
+	cty := test.NewTestCommunity(t, ctx, 2)
+
+	now := time.Now()
+	later := func(d time.Duration) *time.Time { x := now.Add(d); return &x }
+
+	futureBallot := ballotproto.ParseBallotID("schedule/future")
+	timedBallot := ballotproto.ParseBallotID("schedule/timed")
+	dueBallot := ballotproto.ParseBallotID("schedule/due")
+	choices := []string{"x"}
+
+	// open ballots
+	strat := ballotio.QVPolicyName
+	ballotapi.Open(ctx, strat, cty.Organizer(), futureBallot, account.NobodyAccountID, purpose.Unspecified, "", "future", "future ballot", choices, member.Everybody,
+		ballotapi.WithSchedule(later(time.Hour), nil, nil),
+	)
+	ballotapi.Open(ctx, strat, cty.Organizer(), timedBallot, account.NobodyAccountID, purpose.Unspecified, "", "timed", "timed ballot", choices, member.Everybody,
+		ballotapi.WithSchedule(nil, later(time.Hour), later(2*time.Hour)),
+	)
+	ballotapi.Open(ctx, strat, cty.Organizer(), dueBallot, account.NobodyAccountID, purpose.Unspecified, "", "due", "due ballot", choices, member.Everybody,
+		ballotapi.WithSchedule(nil, nil, later(0)),
+	)
+
+	// give credits to users
+	account.Issue(ctx, cty.Gov(), cty.MemberAccountID(0), account.H(account.PluralAsset, 5.0), "test")
+
+	// votes are refused before the ballot opens
+	if must.Try(func() {
+		ballotapi.Vote(ctx, cty.MemberOwner(0), cty.Gov(), futureBallot, ballotproto.OneElection("x", 1.0))
+	}) == nil {
+		t.Fatalf("vote before opening time should fail")
+	}
+
+	// vote and sync: the due ballot is closed
+	ballotapi.Vote(ctx, cty.MemberOwner(0), cty.Gov(), timedBallot, ballotproto.OneElection("x", 4.0))
+	syncChg := sync.Sync(ctx, cty.Organizer(), 2)
+	fmt.Println("sync: ", form.SprintJSON(syncChg))
+
+	if !ballotapi.Show(ctx, cty.Gov(), dueBallot).Ad.Closed {
+		t.Errorf("expecting due ballot to be closed")
+	}
+	ast := ballotapi.Show(ctx, cty.Gov(), timedBallot)
+	if ast.Ad.Frozen || ast.Ad.Closed || ast.Tally.Scores["x"] != 2.0 {
+		t.Errorf("expecting timed ballot to be open and tallied, got %v", form.SprintJSON(ast))
+	}
+
+	// after the freeze deadline
+	schedChg := ballotapi.Schedule(ctx, cty.Organizer(), now.Add(90*time.Minute), 2)
+	if !slices.Equal(schedChg.Result.Frozen, []ballotproto.BallotID{timedBallot}) || len(schedChg.Result.Closed) != 0 {
+		t.Errorf("expecting timed ballot to be frozen, got %v", form.SprintJSON(schedChg.Result))
+	}
+	if !ballotapi.Show(ctx, cty.Gov(), timedBallot).Ad.Frozen {
+		t.Errorf("expecting timed ballot to be frozen")
+	}
+	if n := len(ballotapi.LoadBallotNotices(ctx, cty.Gov(), timedBallot).NoticeStates); n != 1 {
+		t.Errorf("expecting 1 notice, got %v", n)
+	}
+
+	// after the close deadline
+	schedChg = ballotapi.Schedule(ctx, cty.Organizer(), now.Add(3*time.Hour), 2)
+	if !slices.Equal(schedChg.Result.Closed, []ballotproto.BallotID{timedBallot}) {
+		t.Errorf("expecting timed ballot to be closed, got %v", form.SprintJSON(schedChg.Result))
+	}
+	if !ballotapi.Show(ctx, cty.Gov(), timedBallot).Ad.Closed {
+		t.Errorf("expecting timed ballot to be closed")
+	}
+}
@@ -0,0 +1,53 @@
+package tracker
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Deferred is a Tracker that reads from an underlying tracker, but defers writes until Flush is called.
+// Operations that are retried on push conflicts stage their changes against a Deferred tracker, and flush it after a successful push,
+// so that comments and other writes to the issue tracker are made once, and only if the changes they report were pushed.
+type Deferred struct {
+	Tracker
+	lk     sync.Mutex
+	writes []func(ctx context.Context)
+}
+
+func NewDeferred(tr Tracker) *Deferred {
+	return &Deferred{Tracker: tr}
+}
+
+func (x *Deferred) String() string {
+	return fmt.Sprint(x.Tracker)
+}
+
+func (x *Deferred) enqueue(write func(ctx context.Context)) {
+	x.lk.Lock()
+	defer x.lk.Unlock()
+	x.writes = append(x.writes, write)
+}
+
+func (x *Deferred) PostComment(ctx context.Context, number int64, body string) {
+	x.enqueue(func(ctx context.Context) { x.Tracker.PostComment(ctx, number, body) })
+}
+
+func (x *Deferred) CloseIssue(ctx context.Context, number int64) {
+	x.enqueue(func(ctx context.Context) { x.Tracker.CloseIssue(ctx, number) })
+}
+
+func (x *Deferred) UpsertIssue(ctx context.Context, label string, title string, body string) {
+	x.enqueue(func(ctx context.Context) { x.Tracker.UpsertIssue(ctx, label, title, body) })
+}
+
+// Flush performs the deferred writes in the order they were made.
+func (x *Deferred) Flush(ctx context.Context) {
+	x.lk.Lock()
+	writes := x.writes
+	x.writes = nil
+	x.lk.Unlock()
+	for _, write := range writes {
+		write(ctx)
+	}
+}
@@ -0,0 +1,81 @@
+// Package tracker abstracts the issue tracker (GitHub, Gitea, etc.) that hosts the issues and pull requests of a governed project.
+package tracker
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gov4git/lib4git/git"
+)
+
+// Tracker is the interface through which governance reads from and writes to an issue tracker.
+// Following the conventions of this codebase, implementations panic (using must) on errors.
+type Tracker interface {
+	// ListIssues returns all issues and pull requests in the given state, carrying all of the given labels.
+	ListIssues(ctx context.Context, state State, labels ...string) []Issue
+	// ListIssuesSince returns all issues and pull requests, in any state, that were updated at or after since.
+	ListIssuesSince(ctx context.Context, since time.Time) []Issue
+	// GetIssue returns the current state of the issue or pull request with the given number.
+	// It panics with an error wrapping ErrIssueNotFound, if the issue was deleted or transferred to another repo.
+	GetIssue(ctx context.Context, number int64) Issue
+	// IsMerged reports whether the pull request with the given number has been merged.
+	IsMerged(ctx context.Context, number int64) bool
+	// ListComments returns the comments on the issue or pull request with the given number.
+	ListComments(ctx context.Context, number int64) []Comment
+	// ListMaintainers returns the logins of the maintainers of the project.
+	ListMaintainers(ctx context.Context) []string
+
+	// PostComment adds a comment to the issue or pull request with the given number.
+	PostComment(ctx context.Context, number int64, body string)
+	// CloseIssue closes the issue or pull request with the given number.
+	CloseIssue(ctx context.Context, number int64)
+	// UpsertIssue updates the title and body of the first open issue carrying label, or creates one if none exists.
+	UpsertIssue(ctx context.Context, label string, title string, body string)
+
+	// ParseRefs extracts references to issues or pull requests from the body of an issue.
+	ParseRefs(body string) []Ref
+	// ParseIssueURL returns the number of the issue or pull request, given its URL.
+	ParseIssueURL(url string) (int64, bool)
+	// RawFileURL returns a URL serving the raw contents of a file in a repo hosted by the tracker.
+	RawFileURL(ctx context.Context, addr git.Address, path string) string
+}
+
+// ErrIssueNotFound is the cause of failures to get issues that no longer exist in the issue tracker.
+var ErrIssueNotFound = errors.New("issue not found")
+
+type State string
+
+const (
+	StateOpen   State = "open"
+	StateClosed State = "closed"
+	StateAll    State = "all"
+)
+
+type Issue struct {
+	Number      int64      `json:"number"`
+	URL         string     `json:"url"`
+	Author      string     `json:"author"` // lowercase login; empty if not available
+	AuthorEmail string     `json:"author_email"`
+	Title       string     `json:"title"`
+	Body        string     `json:"body"`
+	Labels      []string   `json:"labels"` // sorted
+	Comments    int        `json:"comments"`
+	Locked      bool       `json:"locked"`
+	Closed      bool       `json:"closed"`
+	PullRequest bool       `json:"pull_request"`
+	CreatedAt   *time.Time `json:"created_at"`
+	UpdatedAt   *time.Time `json:"updated_at"`
+	ClosedAt    *time.Time `json:"closed_at"`
+}
+
+type Comment struct {
+	Author string `json:"author"` // lowercase login; empty if not available
+	Body   string `json:"body"`
+}
+
+// Ref is a reference from the body of an issue to another issue or pull request, like "resolves https://.../issues/2".
+type Ref struct {
+	To   int64  `json:"to"`
+	Type string `json:"type"` // lowercase
+}